@@ -0,0 +1,102 @@
+// Package app provides the accounts application service.
+package app
+
+import (
+	"slices"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	"github.com/fd1az/tiger-tui/business/accounts/domain"
+)
+
+// Repository is the port the service reads accounts through.
+type Repository interface {
+	QueryAccounts(q domain.AccountQuery) ([]types.Account, error)
+	LookupAccounts(ids []types.Uint128) ([]types.Account, error)
+	GetAccountBalances(accountID types.Uint128) ([]types.AccountBalance, error)
+	CreateAccounts(accounts []types.Account) ([]types.AccountEventResult, error)
+}
+
+// Service pages through accounts for the UI.
+type Service struct {
+	repo Repository
+}
+
+// NewService creates a new accounts service.
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// LoadPage fetches one page of accounts and computes the cursor for the next.
+// A page shorter than the requested limit means the range is exhausted.
+// The query's Match is applied after the cursor is taken, so a page may come
+// back empty with more to follow.
+func (s *Service) LoadPage(q domain.AccountQuery) (domain.AccountPage, error) {
+	filter := q.Filter()
+	accounts, err := s.repo.QueryAccounts(q)
+	if err != nil {
+		return domain.AccountPage{}, err
+	}
+
+	page := domain.AccountPage{Accounts: accounts, Scanned: len(accounts), Next: q}
+	if n := len(accounts); n > 0 && uint32(n) == filter.Limit {
+		page.Next = q.NextQuery(accounts[n-1].Timestamp)
+		page.HasMore = true
+	}
+	if q.Match != nil {
+		page.Accounts = slices.DeleteFunc(accounts, func(a types.Account) bool { return !q.Match(a) })
+	}
+	return page, nil
+}
+
+// Lookup fetches a single account by ID. The bool is false when no account
+// has that ID.
+func (s *Service) Lookup(id types.Uint128) (types.Account, bool, error) {
+	accounts, err := s.repo.LookupAccounts([]types.Uint128{id})
+	if err != nil || len(accounts) == 0 {
+		return types.Account{}, false, err
+	}
+	return accounts[0], true, nil
+}
+
+// LoadHistory returns the balance snapshots of an account oldest first.
+// The account must have been created with the history flag; TigerBeetle
+// returns no snapshots otherwise.
+func (s *Service) LoadHistory(accountID types.Uint128) ([]types.AccountBalance, error) {
+	balances, err := s.repo.GetAccountBalances(accountID)
+	if err != nil {
+		return nil, err
+	}
+	// Fetched newest first so the batch limit keeps the latest snapshots.
+	for i, j := 0, len(balances)-1; i < j; i, j = i+1, j-1 {
+		balances[i], balances[j] = balances[j], balances[i]
+	}
+	return balances, nil
+}
+
+// Create submits accounts in batches of at most MaxBatchSize, keeping linked
+// chains within one batch, and returns one outcome per account, in order.
+func (s *Service) Create(accounts []types.Account) ([]domain.CreateOutcome, error) {
+	outcomes := make([]domain.CreateOutcome, 0, len(accounts))
+	linked := func(a types.Account) bool { return a.AccountFlags().Linked }
+	for _, batch := range domain.Batches(accounts, domain.MaxBatchSize, linked) {
+		results, err := s.repo.CreateAccounts(batch)
+		if err != nil {
+			return outcomes, err
+		}
+		outcomes = append(outcomes, domain.Outcomes(batch, results)...)
+	}
+	return outcomes, nil
+}
+
+// Import submits a validated import plan (see domain.PrepareImport) when
+// every row is valid; a dry run stops after validation. The report has one
+// result per row; if a batch fails to send, rows from that batch on are
+// reported as not submitted alongside the error.
+func (s *Service) Import(plan domain.ImportPlan, dryRun bool) (domain.ImportReport, error) {
+	if plan.Invalid > 0 || dryRun {
+		return plan.Validated(), nil
+	}
+	outcomes, err := s.Create(plan.Accounts)
+	return plan.Report(outcomes), err
+}
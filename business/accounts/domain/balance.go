@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"math/big"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// NetBalance returns the posted balance on the account's normal side:
+// debits minus credits for debit-normal accounts (credits_must_not_exceed_debits),
+// credits minus debits otherwise.
+func NetBalance(flags uint16, debitsPosted, creditsPosted types.Uint128) *big.Int {
+	dr := debitsPosted.BigInt()
+	cr := creditsPosted.BigInt()
+	if (types.Account{Flags: flags}).AccountFlags().CreditsMustNotExceedDebits {
+		return new(big.Int).Sub(&dr, &cr)
+	}
+	return new(big.Int).Sub(&cr, &dr)
+}
@@ -1,6 +1,14 @@
-// Package domain provides account and ledger domain types for the Terrace chart of accounts.
+// Package domain provides account and ledger domain types for a configurable
+// chart of accounts (Terrace ledger-v2 by default).
 package domain
 
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
 // LedgerAsset maps a TigerBeetle ledger ID to an asset symbol and display info.
 type LedgerAsset struct {
 	Symbol   string
@@ -8,20 +16,92 @@ type LedgerAsset struct {
 	Decimals int
 }
 
-// Ledgers maps ledger IDs to asset info (Terrace ledger-v2).
-var Ledgers = map[uint32]LedgerAsset{
-	1:   {Symbol: "USD", Name: "US Dollar", Decimals: 2},
-	2:   {Symbol: "EUR", Name: "Euro", Decimals: 2},
-	100: {Symbol: "BTC", Name: "Bitcoin", Decimals: 8},
-	101: {Symbol: "ETH", Name: "Ethereum", Decimals: 8},
-	102: {Symbol: "USDC", Name: "USD Coin", Decimals: 6},
-	103: {Symbol: "USDT", Name: "Tether", Decimals: 6},
-	104: {Symbol: "SOL", Name: "Solana", Decimals: 9},
+// Chart is a chart of accounts: ledger assets, account and transfer type
+// names, and venue names.
+type Chart struct {
+	Name          string
+	Ledgers       map[uint32]LedgerAsset
+	AccountTypes  map[uint16]string
+	TransferTypes map[uint16]string
+	Venues        map[uint32]string
+}
+
+// DefaultChart returns the built-in Terrace ledger-v2 chart.
+func DefaultChart() Chart {
+	return Chart{
+		Name: "terrace-ledger-v2",
+		Ledgers: map[uint32]LedgerAsset{
+			1:   {Symbol: "USD", Name: "US Dollar", Decimals: 2},
+			2:   {Symbol: "EUR", Name: "Euro", Decimals: 2},
+			100: {Symbol: "BTC", Name: "Bitcoin", Decimals: 8},
+			101: {Symbol: "ETH", Name: "Ethereum", Decimals: 8},
+			102: {Symbol: "USDC", Name: "USD Coin", Decimals: 6},
+			103: {Symbol: "USDT", Name: "Tether", Decimals: 6},
+			104: {Symbol: "SOL", Name: "Solana", Decimals: 9},
+		},
+		AccountTypes: map[uint16]string{
+			1:   "USER_WALLET_DEFI",
+			2:   "USER_ACCT_CEFI",
+			100: "VENUE_BINANCE",
+			101: "VENUE_OKX",
+			102: "VENUE_BYBIT",
+			103: "VENUE_BITGO",
+			200: "HOLD_TRADE",
+			201: "HOLD_WITHDRAWAL",
+			202: "HOLD_SETTLEMENT",
+			300: "FEES_COLLECTED",
+			301: "FEES_VENUE",
+			400: "SETTLEMENT_TRANSIT",
+		},
+		TransferTypes: map[uint16]string{
+			1:  "DEPOSIT",
+			2:  "WITHDRAWAL",
+			3:  "TRADE_BUY",
+			4:  "TRADE_SELL",
+			5:  "SETTLEMENT",
+			6:  "FEE",
+			7:  "REBATE",
+			10: "INTERNAL_MOVE",
+			11: "REBALANCE",
+			12: "HOLD_RESERVE",
+			13: "HOLD_RELEASE",
+			14: "HOLD_TIMEOUT",
+		},
+		Venues: map[uint32]string{
+			0: "Internal",
+			1: "Binance",
+			2: "OKX",
+			3: "Bybit",
+			4: "BitGo",
+			5: "Coinbase",
+		},
+	}
+}
+
+// active holds the active chart. UseChart swaps it atomically, so a chart
+// can be replaced (on connecting with another profile) while commands
+// running in the background still read the previous one.
+var active atomic.Pointer[Chart]
+
+func init() {
+	UseChart(DefaultChart())
+}
+
+// UseChart makes c the active chart of accounts. The chart's maps must not
+// be modified afterwards.
+func UseChart(c Chart) {
+	active.Store(&c)
+}
+
+// ActiveChart returns the active chart of accounts. Its maps are shared and
+// must not be modified.
+func ActiveChart() Chart {
+	return *active.Load()
 }
 
 // LedgerSymbol returns the asset symbol for a ledger ID, or the ID as string.
 func LedgerSymbol(id uint32) string {
-	if a, ok := Ledgers[id]; ok {
+	if a, ok := ActiveChart().Ledgers[id]; ok {
 		return a.Symbol
 	}
 	return ""
@@ -33,68 +113,118 @@ type AccountType struct {
 	Name string
 }
 
-// AccountTypes maps account codes to type names (Terrace ledger-v2).
-var AccountTypes = map[uint16]string{
-	1:   "USER_WALLET_DEFI",
-	2:   "USER_ACCT_CEFI",
-	100: "VENUE_BINANCE",
-	101: "VENUE_OKX",
-	102: "VENUE_BYBIT",
-	103: "VENUE_BITGO",
-	200: "HOLD_TRADE",
-	201: "HOLD_WITHDRAWAL",
-	202: "HOLD_SETTLEMENT",
-	300: "FEES_COLLECTED",
-	301: "FEES_VENUE",
-	400: "SETTLEMENT_TRANSIT",
-}
-
 // AccountTypeName returns the type name for an account code, or "UNKNOWN".
 func AccountTypeName(code uint16) string {
-	if name, ok := AccountTypes[code]; ok {
+	if name, ok := ActiveChart().AccountTypes[code]; ok {
 		return name
 	}
 	return "UNKNOWN"
 }
 
-// TransferTypes maps transfer codes to type names (Terrace ledger-v2).
-var TransferTypes = map[uint16]string{
-	1:  "DEPOSIT",
-	2:  "WITHDRAWAL",
-	3:  "TRADE_BUY",
-	4:  "TRADE_SELL",
-	5:  "SETTLEMENT",
-	6:  "FEE",
-	7:  "REBATE",
-	10: "INTERNAL_MOVE",
-	11: "REBALANCE",
-	12: "HOLD_RESERVE",
-	13: "HOLD_RELEASE",
-	14: "HOLD_TIMEOUT",
-}
-
 // TransferTypeName returns the type name for a transfer code, or "UNKNOWN".
 func TransferTypeName(code uint16) string {
-	if name, ok := TransferTypes[code]; ok {
+	if name, ok := ActiveChart().TransferTypes[code]; ok {
 		return name
 	}
 	return "UNKNOWN"
 }
 
-// Venues maps venue IDs (user_data_32) to venue names.
-var Venues = map[uint32]string{
-	0: "Internal",
-	1: "Binance",
-	2: "OKX",
-	3: "Bybit",
-	4: "BitGo",
-	5: "Coinbase",
-}
-
 // VenueName returns the venue name for a user_data_32 value, or "Unknown".
 func VenueName(id uint32) string {
-	if name, ok := Venues[id]; ok {
+	if name, ok := ActiveChart().Venues[id]; ok {
 		return name
 	}
 	return "Unknown"
 }
+
+// LedgerBySymbol resolves a ledger from its asset symbol (case-insensitive)
+// or its numeric ID. Only ledgers in the active chart resolve.
+func LedgerBySymbol(s string) (uint32, bool) {
+	ledgers := ActiveChart().Ledgers
+	s = strings.TrimSpace(s)
+	for id, a := range ledgers {
+		if strings.EqualFold(a.Symbol, s) {
+			return id, true
+		}
+	}
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	_, ok := ledgers[uint32(n)]
+	return uint32(n), ok
+}
+
+// AccountCodeByName resolves an account code from its type name
+// (case-insensitive) or its number. Only codes in the active chart resolve.
+func AccountCodeByName(s string) (uint16, bool) {
+	return byName(ActiveChart().AccountTypes, s)
+}
+
+// TransferCodeByName resolves a transfer code from its type name
+// (case-insensitive) or its number. Only codes in the active chart resolve.
+func TransferCodeByName(s string) (uint16, bool) {
+	return byName(ActiveChart().TransferTypes, s)
+}
+
+// VenueByName resolves a venue ID from its name (case-insensitive) or its
+// number. Only venues in the active chart resolve.
+func VenueByName(s string) (uint32, bool) {
+	return byName(ActiveChart().Venues, s)
+}
+
+func byName[K uint16 | uint32](m map[K]string, s string) (K, bool) {
+	s = strings.TrimSpace(s)
+	for k, name := range m {
+		if strings.EqualFold(name, s) {
+			return k, true
+		}
+	}
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil || uint64(K(n)) != n {
+		return 0, false
+	}
+	_, ok := m[K(n)]
+	return K(n), ok
+}
+
+// LedgerIDs returns the mapped ledger IDs in ascending order.
+func LedgerIDs() []uint32 {
+	ledgers := ActiveChart().Ledgers
+	ids := make([]uint32, 0, len(ledgers))
+	for id := range ledgers {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// AccountCodes returns the mapped account codes in ascending order.
+func AccountCodes() []uint16 {
+	return sortedCodes(ActiveChart().AccountTypes)
+}
+
+// TransferCodes returns the mapped transfer codes in ascending order.
+func TransferCodes() []uint16 {
+	return sortedCodes(ActiveChart().TransferTypes)
+}
+
+// VenueIDs returns the mapped venue IDs in ascending order.
+func VenueIDs() []uint32 {
+	venues := ActiveChart().Venues
+	ids := make([]uint32, 0, len(venues))
+	for id := range venues {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func sortedCodes(m map[uint16]string) []uint16 {
+	codes := make([]uint16, 0, len(m))
+	for c := range m {
+		codes = append(codes, c)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+	return codes
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// CreateOutcome pairs a submitted account with its CreateAccountResult.
+type CreateOutcome struct {
+	Account types.Account
+	Result  types.CreateAccountResult
+}
+
+// OK reports whether the account was created.
+func (o CreateOutcome) OK() bool {
+	return o.Result == types.AccountOK
+}
+
+// Settled reports whether the account is in the cluster: created now, or by
+// an earlier submit of the same ID.
+func (o CreateOutcome) Settled() bool {
+	return o.OK() || o.Result == types.AccountExists
+}
+
+// Outcomes expands TigerBeetle's sparse results (failures only) into one
+// outcome per submitted account.
+func Outcomes(accounts []types.Account, results []types.AccountEventResult) []CreateOutcome {
+	out := make([]CreateOutcome, len(accounts))
+	for i, acc := range accounts {
+		out[i] = CreateOutcome{Account: acc, Result: types.AccountOK}
+	}
+	for _, r := range results {
+		if int(r.Index) < len(out) {
+			out[r.Index].Result = r.Result
+		}
+	}
+	return out
+}
+
+// Batches splits events into batches of at most max. A batch ends on a
+// linked chain boundary whenever possible, since TigerBeetle only applies a
+// chain atomically within one request; a chain longer than max is split
+// anyway and rejected by TigerBeetle.
+func Batches[T any](events []T, max int, linked func(T) bool) [][]T {
+	var batches [][]T
+	for len(events) > 0 {
+		end := len(events)
+		if end > max {
+			end = max
+			cut := end
+			for cut > 0 && linked(events[cut-1]) {
+				cut--
+			}
+			if cut > 0 {
+				end = cut
+			}
+		}
+		batches = append(batches, events[:end])
+		events = events[end:]
+	}
+	return batches
+}
+
+// AccountResultName returns the TigerBeetle snake_case name of a result,
+// e.g. "exists_with_different_flags".
+func AccountResultName(r types.CreateAccountResult) string {
+	return ResultName(r.String(), "Account")
+}
+
+// ResultName converts a generated result constant name such as
+// "TransferExceedsCredits" into TigerBeetle's snake_case form without the
+// given prefix ("exceeds_credits"). Acronyms and numbers are words of their
+// own: "PendingIDMustNotBeZero" is "pending_id_must_not_be_zero" and
+// "ExistsWithDifferentUserData128" is "exists_with_different_user_data_128".
+func ResultName(name, prefix string) string {
+	rs := []rune(strings.TrimPrefix(name, prefix))
+	var sb strings.Builder
+	for i, r := range rs {
+		if i > 0 && wordStart(rs, i) {
+			sb.WriteByte('_')
+		}
+		sb.WriteRune(unicode.ToLower(r))
+	}
+	return sb.String()
+}
+
+// wordStart reports whether rs[i] starts a word of a CamelCase name: an
+// upper-case letter after a lower-case letter or digit, the last capital of
+// an acronym followed by lower case ("IDMust"), or a switch between letters
+// and digits.
+func wordStart(rs []rune, i int) bool {
+	prev, r := rs[i-1], rs[i]
+	switch {
+	case unicode.IsDigit(r) != unicode.IsDigit(prev):
+		return true
+	case unicode.IsUpper(r) && !unicode.IsUpper(prev):
+		return true
+	case unicode.IsUpper(r) && i+1 < len(rs) && unicode.IsLower(rs[i+1]):
+		return true
+	}
+	return false
+}
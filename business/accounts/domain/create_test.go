@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+func TestAccountResultName(t *testing.T) {
+	tests := []struct {
+		result types.CreateAccountResult
+		want   string
+	}{
+		{types.AccountOK, "ok"},
+		{types.AccountLinkedEventFailed, "linked_event_failed"},
+		{types.AccountLinkedEventChainOpen, "linked_event_chain_open"},
+		{types.AccountImportedEventExpected, "imported_event_expected"},
+		{types.AccountImportedEventNotExpected, "imported_event_not_expected"},
+		{types.AccountTimestampMustBeZero, "timestamp_must_be_zero"},
+		{types.AccountImportedEventTimestampOutOfRange, "imported_event_timestamp_out_of_range"},
+		{types.AccountImportedEventTimestampMustNotAdvance, "imported_event_timestamp_must_not_advance"},
+		{types.AccountReservedField, "reserved_field"},
+		{types.AccountReservedFlag, "reserved_flag"},
+		{types.AccountIDMustNotBeZero, "id_must_not_be_zero"},
+		{types.AccountIDMustNotBeIntMax, "id_must_not_be_int_max"},
+		{types.AccountExistsWithDifferentFlags, "exists_with_different_flags"},
+		{types.AccountExistsWithDifferentUserData128, "exists_with_different_user_data_128"},
+		{types.AccountExistsWithDifferentUserData64, "exists_with_different_user_data_64"},
+		{types.AccountExistsWithDifferentUserData32, "exists_with_different_user_data_32"},
+		{types.AccountExistsWithDifferentLedger, "exists_with_different_ledger"},
+		{types.AccountExistsWithDifferentCode, "exists_with_different_code"},
+		{types.AccountExists, "exists"},
+		{types.AccountFlagsAreMutuallyExclusive, "flags_are_mutually_exclusive"},
+		{types.AccountDebitsPendingMustBeZero, "debits_pending_must_be_zero"},
+		{types.AccountDebitsPostedMustBeZero, "debits_posted_must_be_zero"},
+		{types.AccountCreditsPendingMustBeZero, "credits_pending_must_be_zero"},
+		{types.AccountCreditsPostedMustBeZero, "credits_posted_must_be_zero"},
+		{types.AccountLedgerMustNotBeZero, "ledger_must_not_be_zero"},
+		{types.AccountCodeMustNotBeZero, "code_must_not_be_zero"},
+		{types.AccountImportedEventTimestampMustNotRegress, "imported_event_timestamp_must_not_regress"},
+	}
+
+	seen := map[types.CreateAccountResult]bool{}
+	for _, tt := range tests {
+		seen[tt.result] = true
+		if got := AccountResultName(tt.result); got != tt.want {
+			t.Errorf("AccountResultName(%s) = %q, want %q", tt.result, got, tt.want)
+		}
+	}
+
+	// Every result the client defines is in the table.
+	for r := types.CreateAccountResult(0); r < 256; r++ {
+		if !strings.HasPrefix(r.String(), "CreateAccountResult(") && !seen[r] {
+			t.Errorf("%s is missing from the table", r)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// AccountFlagNames returns the names of the flags set on an account, in bit
+// order, using TigerBeetle's snake_case names.
+func AccountFlagNames(flags uint16) []string {
+	f := types.Account{Flags: flags}.AccountFlags()
+	var names []string
+	if f.Linked {
+		names = append(names, "linked")
+	}
+	if f.DebitsMustNotExceedCredits {
+		names = append(names, "debits_must_not_exceed_credits")
+	}
+	if f.CreditsMustNotExceedDebits {
+		names = append(names, "credits_must_not_exceed_debits")
+	}
+	if f.History {
+		names = append(names, "history")
+	}
+	if f.Imported {
+		names = append(names, "imported")
+	}
+	if f.Closed {
+		names = append(names, "closed")
+	}
+	return names
+}
+
+// ParseAccountFlags parses flag names as produced by AccountFlagNames,
+// separated by "|", "," or spaces, e.g. "linked|history".
+func ParseAccountFlags(s string) (types.AccountFlags, error) {
+	var f types.AccountFlags
+	names := strings.FieldsFunc(s, func(r rune) bool {
+		return r == '|' || r == ',' || r == ' '
+	})
+	for _, name := range names {
+		switch strings.ToLower(name) {
+		case "linked":
+			f.Linked = true
+		case "debits_must_not_exceed_credits":
+			f.DebitsMustNotExceedCredits = true
+		case "credits_must_not_exceed_debits":
+			f.CreditsMustNotExceedDebits = true
+		case "history":
+			f.History = true
+		case "imported":
+			f.Imported = true
+		case "closed":
+			f.Closed = true
+		default:
+			return f, fmt.Errorf("unknown flag %q", name)
+		}
+	}
+	return f, nil
+}
+
+// HasHistory reports whether the account keeps balance history.
+func HasHistory(acc types.Account) bool {
+	return acc.AccountFlags().History
+}
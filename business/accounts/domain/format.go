@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// FormatID renders a 128-bit ID as a decimal string.
+func FormatID(id types.Uint128) string {
+	n := id.BigInt()
+	return n.String()
+}
+
+// FormatAmount renders an amount in minor units scaled by the ledger's
+// Decimals, with thousands separators. Unknown ledgers are rendered unscaled.
+func FormatAmount(amount types.Uint128, ledger uint32) string {
+	return NewMoney(amount, ledger).Format()
+}
+
+// FormatBigAmount is FormatAmount for arbitrary precision values such as
+// sums and signed differences.
+func FormatBigAmount(n *big.Int, ledger uint32) string {
+	if n.Sign() < 0 {
+		return "-" + scale(new(big.Int).Neg(n), ledgerDecimals(ledger))
+	}
+	return scale(n, ledgerDecimals(ledger))
+}
+
+// ParseAmount parses a human amount such as "1,250.5" or "12.5 USDC" into
+// minor units; see ParseMoney.
+func ParseAmount(s string, ledger uint32) (types.Uint128, error) {
+	m, err := ParseMoney(s, ledger)
+	return m.Amount, err
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// FormatTimestamp renders a TigerBeetle timestamp (nanoseconds since the Unix
+// epoch) in UTC with millisecond precision.
+func FormatTimestamp(ts uint64) string {
+	if ts == 0 {
+		return "-"
+	}
+	return time.Unix(0, int64(ts)).UTC().Format("2006-01-02 15:04:05.000")
+}
+
+// LedgerLabel returns the ledger's symbol, or its numeric ID if unmapped.
+func LedgerLabel(id uint32) string {
+	if s := LedgerSymbol(id); s != "" {
+		return s
+	}
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// scale renders a non-negative minor-unit value with decimals fractional
+// digits and a grouped whole part.
+func scale(n *big.Int, decimals int) string {
+	digits := n.String()
+	if decimals == 0 {
+		return groupThousands(digits)
+	}
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	cut := len(digits) - decimals
+	return groupThousands(digits[:cut]) + "." + digits[cut:]
+}
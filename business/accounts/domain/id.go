@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// maxUint128 is 2^128 - 1.
+var maxUint128 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+
+// ParseUint128 parses a full 128-bit unsigned decimal integer. Signs are
+// not accepted, "+1" included.
+func ParseUint128(s string) (types.Uint128, error) {
+	s = strings.TrimSpace(s)
+	return parseDigits(s, s, 10, "an unsigned decimal integer")
+}
+
+// ParseNumber parses a 128-bit unsigned integer written as decimal or
+// 0x-prefixed hex.
+func ParseNumber(s string) (types.Uint128, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		return parseDigits(s, s[2:], 16, "a hex integer")
+	}
+	return ParseUint128(s)
+}
+
+// ParseID parses a 128-bit ID written as a decimal integer, 0x-prefixed hex,
+// or a UUID (8-4-4-4-12 hex digits, read as one big-endian number).
+func ParseID(s string) (types.Uint128, error) {
+	s = strings.TrimSpace(s)
+	if isUUID(s) {
+		return parseDigits(s, strings.ReplaceAll(s, "-", ""), 16, "a hex integer")
+	}
+	return ParseNumber(s)
+}
+
+// parseDigits parses unsigned digits in base. s is the input as typed, for
+// errors; what names the form expected.
+func parseDigits(s, digits string, base int, what string) (types.Uint128, error) {
+	n, ok := new(big.Int).SetString(digits, base)
+	if !ok || digits == "" || strings.ContainsAny(digits, "+-") {
+		return types.Uint128{}, fmt.Errorf("%q is not %s", s, what)
+	}
+	if n.Cmp(maxUint128) > 0 {
+		return types.Uint128{}, fmt.Errorf("%q exceeds 128 bits", s)
+	}
+	return types.BigIntToUint128(*n), nil
+}
+
+// FormatHex formats an ID as 0x-prefixed hex.
+func FormatHex(id types.Uint128) string {
+	n := id.BigInt()
+	return "0x" + n.Text(16)
+}
+
+// FormatUUID formats an ID as a UUID string.
+func FormatUUID(id types.Uint128) string {
+	n := id.BigInt()
+	h := fmt.Sprintf("%032s", n.Text(16))
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:32]
+}
+
+// isUUID reports whether s has the 8-4-4-4-12 UUID layout.
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, r := range s {
+		switch i {
+		case 8, 13, 18, 23:
+			if r != '-' {
+				return false
+			}
+		default:
+			if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+				return false
+			}
+		}
+	}
+	return true
+}
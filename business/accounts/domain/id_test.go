@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+func TestParseID(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string // decimal
+		wantErr string
+	}{
+		{"0", "0", ""},
+		{"42", "42", ""},
+		{" 42 ", "42", ""},
+		{maxUint128Decimal, maxUint128Decimal, ""},
+		{"0x2a", "42", ""},
+		{"0X2A", "42", ""},
+		{"0xffffffffffffffffffffffffffffffff", maxUint128Decimal, ""},
+		{"00000000-0000-0000-0000-00000000002a", "42", ""},
+		{"ffffffff-ffff-ffff-ffff-ffffffffffff", maxUint128Decimal, ""},
+		{"0190A1B2-C3D4-7E5F-8A9B-0C1D2E3F4A5B", "2080198374579512478928253972136282715", ""},
+
+		{"", "", "not an unsigned decimal integer"},
+		{"-1", "", "not an unsigned decimal integer"},
+		{"+1", "", "not an unsigned decimal integer"},
+		{"1_000", "", "not an unsigned decimal integer"},
+		{"1.5", "", "not an unsigned decimal integer"},
+		{"2a", "", "not an unsigned decimal integer"},
+		{"340282366920938463463374607431768211456", "", "exceeds 128 bits"},
+		{"0x", "", "not a hex integer"},
+		{"0x-1", "", "not a hex integer"},
+		{"0x+1", "", "not a hex integer"},
+		{"0xg", "", "not a hex integer"},
+		{"0x100000000000000000000000000000000", "", "exceeds 128 bits"},
+		{"00000000-0000-0000-0000-00000000002", "", "not an unsigned decimal integer"},
+		{"00000000_0000-0000-0000-00000000002a", "", "not an unsigned decimal integer"},
+		{"0000000g-0000-0000-0000-00000000002a", "", "not an unsigned decimal integer"},
+	}
+	for _, tt := range tests {
+		id, err := ParseID(tt.in)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseID(%q) error = %v, want %q", tt.in, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseID(%q) error = %v", tt.in, err)
+			continue
+		}
+		if got := FormatID(id); got != tt.want {
+			t.Errorf("ParseID(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFormatIDRoundTrip(t *testing.T) {
+	tests := []struct {
+		id        string // decimal
+		hex, uuid string
+	}{
+		{"0", "0x0", "00000000-0000-0000-0000-000000000000"},
+		{"42", "0x2a", "00000000-0000-0000-0000-00000000002a"},
+		{maxUint128Decimal, "0xffffffffffffffffffffffffffffffff", "ffffffff-ffff-ffff-ffff-ffffffffffff"},
+	}
+	for _, tt := range tests {
+		id := mustUint128(t, tt.id)
+		if got := FormatHex(id); got != tt.hex {
+			t.Errorf("FormatHex(%s) = %q, want %q", tt.id, got, tt.hex)
+		}
+		if got := FormatUUID(id); got != tt.uuid {
+			t.Errorf("FormatUUID(%s) = %q, want %q", tt.id, got, tt.uuid)
+		}
+		for _, s := range []string{FormatID(id), tt.hex, tt.uuid} {
+			if back, err := ParseID(s); err != nil || back != id {
+				t.Errorf("ParseID(%q) = %v, %v, want %s", s, back, err, tt.id)
+			}
+		}
+	}
+
+	id := types.ID()
+	if back, err := ParseID(FormatUUID(id)); err != nil || back != id {
+		t.Errorf("ParseID(FormatUUID(%s)) = %v, %v", FormatID(id), back, err)
+	}
+}
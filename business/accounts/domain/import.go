@@ -0,0 +1,290 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// ImportColumns are the keys of an account import row.
+var ImportColumns = []string{
+	"id", "ledger", "type", "venue", "flags", "user_data_128", "user_data_64",
+}
+
+// Import result names besides TigerBeetle's create result names.
+const (
+	ImportInvalid      = "invalid"       // the row failed offline validation
+	ImportValid        = "valid"         // the row passed validation; nothing was sent
+	ImportNotSubmitted = "not_submitted" // the row was valid but its batch was not sent
+)
+
+// ImportRow is one account as written in an import row. Values are text
+// using chart names (e.g. ledger "USD", type "USER_WALLET_DEFI", venue
+// "Binance"); Account resolves them against the active chart.
+type ImportRow struct {
+	Line        int // 1-based line in the source
+	ID          string
+	Ledger      string
+	Type        string
+	Venue       string
+	Flags       string
+	UserData128 string
+	UserData64  string
+}
+
+// Set assigns the value of an import column. It reports false for a column
+// name that is not in ImportColumns.
+func (r *ImportRow) Set(column, value string) bool {
+	value = strings.TrimSpace(value)
+	switch strings.ToLower(strings.TrimSpace(column)) {
+	case "id":
+		r.ID = value
+	case "ledger":
+		r.Ledger = value
+	case "type":
+		r.Type = value
+	case "venue":
+		r.Venue = value
+	case "flags":
+		r.Flags = value
+	case "user_data_128":
+		r.UserData128 = value
+	case "user_data_64":
+		r.UserData64 = value
+	default:
+		return false
+	}
+	return true
+}
+
+// Account validates the row offline and returns the account it describes.
+// An empty ID gets a fresh time-based ID.
+func (r ImportRow) Account() (types.Account, error) {
+	a := types.Account{}
+	flags, err := ParseAccountFlags(r.Flags)
+	if err != nil {
+		return a, fmt.Errorf("flags: %w", err)
+	}
+	if flags.DebitsMustNotExceedCredits && flags.CreditsMustNotExceedDebits {
+		return a, fmt.Errorf("flags: debits_must_not_exceed_credits and credits_must_not_exceed_debits are mutually exclusive")
+	}
+	a.Flags = flags.ToUint16()
+
+	if r.ID != "" {
+		if a.ID, err = ParseID(r.ID); err != nil {
+			return a, fmt.Errorf("id: %w", err)
+		}
+	} else {
+		a.ID = types.ID()
+	}
+
+	var ok bool
+	if r.Ledger == "" {
+		return a, fmt.Errorf("ledger is required")
+	}
+	if a.Ledger, ok = LedgerBySymbol(r.Ledger); !ok {
+		return a, fmt.Errorf("ledger: %q is not in the %s chart", r.Ledger, ActiveChart().Name)
+	}
+	if r.Type == "" {
+		return a, fmt.Errorf("type is required")
+	}
+	if a.Code, ok = AccountCodeByName(r.Type); !ok {
+		return a, fmt.Errorf("type: %q is not in the %s chart", r.Type, ActiveChart().Name)
+	}
+	if r.Venue != "" {
+		if a.UserData32, ok = VenueByName(r.Venue); !ok {
+			return a, fmt.Errorf("venue: %q is not in the %s chart", r.Venue, ActiveChart().Name)
+		}
+	}
+
+	if r.UserData128 != "" {
+		if a.UserData128, err = ParseID(r.UserData128); err != nil {
+			return a, fmt.Errorf("user_data_128: %w", err)
+		}
+	}
+	if r.UserData64 != "" {
+		if a.UserData64, err = strconv.ParseUint(r.UserData64, 10, 64); err != nil {
+			return a, fmt.Errorf("user_data_64: %q is not an unsigned 64-bit integer", r.UserData64)
+		}
+	}
+	return a, nil
+}
+
+// ImportPlan is the offline validation of account import rows.
+type ImportPlan struct {
+	Rows     []ImportRow
+	Accounts []types.Account // one per valid row, in order
+	Errors   []error         // one per row; nil for valid rows
+	Invalid  int
+}
+
+// PrepareImport validates every row. Besides each row's own checks, IDs must
+// be unique within the input and the last linked chain must be closed.
+func PrepareImport(rows []ImportRow) ImportPlan {
+	p := ImportPlan{Rows: rows, Errors: make([]error, len(rows))}
+	seen := make(map[types.Uint128]int, len(rows))
+	for i, row := range rows {
+		a, err := row.Account()
+		if err == nil {
+			if line, dup := seen[a.ID]; dup {
+				err = fmt.Errorf("id %s already used on line %d", FormatID(a.ID), line)
+			}
+		}
+		if err == nil && i == len(rows)-1 && a.AccountFlags().Linked {
+			err = fmt.Errorf("linked chain is not closed: the last row cannot be linked")
+		}
+		if err != nil {
+			p.Errors[i] = err
+			p.Invalid++
+			continue
+		}
+		seen[a.ID] = row.Line
+		p.Accounts = append(p.Accounts, a)
+	}
+	return p
+}
+
+// Link chains every account of the plan, so they are created all together
+// or not at all. A chain must fit in one request.
+func (p *ImportPlan) Link() error {
+	if len(p.Accounts) > MaxBatchSize {
+		return fmt.Errorf("a linked chain holds at most %d events, got %d", MaxBatchSize, len(p.Accounts))
+	}
+	linked := types.AccountFlags{Linked: true}.ToUint16()
+	for i := 0; i < len(p.Accounts)-1; i++ {
+		p.Accounts[i].Flags |= linked
+	}
+	return nil
+}
+
+// Validated builds the report of a plan that was not submitted: valid rows
+// are reported as ImportValid.
+func (p ImportPlan) Validated() ImportReport {
+	return p.report(nil, false)
+}
+
+// Report builds the per-row report of a submitted plan. outcomes are the
+// results of submitting p.Accounts, possibly cut short by a failed request;
+// rows without an outcome are reported as not submitted.
+func (p ImportPlan) Report(outcomes []CreateOutcome) ImportReport {
+	return p.report(outcomes, true)
+}
+
+func (p ImportPlan) report(outcomes []CreateOutcome, submitted bool) ImportReport {
+	lines := make([]int, len(p.Rows))
+	for i, row := range p.Rows {
+		lines[i] = row.Line
+	}
+	ids := make([]types.Uint128, len(p.Accounts))
+	for i, a := range p.Accounts {
+		ids[i] = a.ID
+	}
+	results := make([]string, len(outcomes))
+	for i, o := range outcomes {
+		results[i] = AccountResultName(o.Result)
+	}
+	return NewImportReport(lines, p.Errors, ids, results, submitted)
+}
+
+// ImportResult is the outcome of one import row: a TigerBeetle create result
+// name ("ok", "exists", "exceeds_credits", ...), ImportInvalid with the
+// validation error, ImportValid, or ImportNotSubmitted.
+type ImportResult struct {
+	Line   int
+	ID     types.Uint128
+	Result string
+	Error  string
+}
+
+// OK reports whether the row's event was created.
+func (r ImportResult) OK() bool {
+	return r.Result == "ok"
+}
+
+// ImportReport is the per-row outcome of an import with totals.
+type ImportReport struct {
+	Results      []ImportResult
+	Submitted    bool
+	Created      int
+	Existed      int
+	Failed       int
+	Invalid      int
+	Valid        int
+	NotSubmitted int
+}
+
+// NewImportReport builds the report of an import of accounts or transfers.
+// lines and errs have one entry per row (errs is nil for valid rows); ids
+// has one entry per valid row, in order, and results the create result
+// names of those submitted. Valid rows without a result are ImportValid when
+// nothing was submitted and ImportNotSubmitted otherwise.
+func NewImportReport(lines []int, errs []error, ids []types.Uint128, results []string, submitted bool) ImportReport {
+	rep := ImportReport{Results: make([]ImportResult, len(lines)), Submitted: submitted}
+	next := 0
+	for i, line := range lines {
+		res := ImportResult{Line: line}
+		switch {
+		case errs[i] != nil:
+			res.Result = ImportInvalid
+			res.Error = errs[i].Error()
+			rep.Invalid++
+		case next < len(results):
+			res.ID = ids[next]
+			res.Result = results[next]
+			switch res.Result {
+			case "ok":
+				rep.Created++
+			case "exists":
+				rep.Existed++
+			default:
+				rep.Failed++
+			}
+		case !submitted:
+			res.ID = ids[next]
+			res.Result = ImportValid
+			rep.Valid++
+		default:
+			res.ID = ids[next]
+			res.Result = ImportNotSubmitted
+			rep.NotSubmitted++
+		}
+		if errs[i] == nil {
+			next++
+		}
+		rep.Results[i] = res
+	}
+	return rep
+}
+
+// Summary renders the totals in one line, e.g. "120 rows: 118 created,
+// 2 failed".
+func (r ImportReport) Summary() string {
+	parts := []string{}
+	for _, c := range []struct {
+		n    int
+		name string
+	}{
+		{r.Created, "created"},
+		{r.Existed, "already existed"},
+		{r.Failed, "failed"},
+		{r.Invalid, "invalid"},
+		{r.Valid, "valid"},
+		{r.NotSubmitted, "not submitted"},
+	} {
+		if c.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", c.n, c.name))
+		}
+	}
+	if len(parts) == 0 {
+		return fmt.Sprintf("%d rows", len(r.Results))
+	}
+	return fmt.Sprintf("%d rows: %s", len(r.Results), strings.Join(parts, ", "))
+}
+
+// Failures returns the number of rows that were neither created nor already
+// present.
+func (r ImportReport) Failures() int {
+	return r.Failed + r.Invalid + r.NotSubmitted
+}
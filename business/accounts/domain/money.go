@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// Money is an amount in minor units on a ledger. Formatting and parsing use
+// the ledger's Decimals and Symbol from the active chart; arithmetic is exact
+// and never wraps around 128 bits.
+type Money struct {
+	Amount types.Uint128
+	Ledger uint32
+}
+
+// NewMoney wraps an amount in minor units.
+func NewMoney(amount types.Uint128, ledger uint32) Money {
+	return Money{Amount: amount, Ledger: ledger}
+}
+
+// ParseMoney parses human input such as "1,250.5", "1250.50 USDC" or
+// "0.25 usdc" into minor units. Thousands separators must group by three; a
+// symbol, if given, must match the ledger's. More fractional digits than the
+// ledger's Decimals is an error rather than a silent rounding.
+func ParseMoney(s string, ledger uint32) (Money, error) {
+	m := Money{Ledger: ledger}
+	s = strings.TrimSpace(s)
+
+	number, symbol, hasSymbol := strings.Cut(s, " ")
+	if hasSymbol {
+		symbol = strings.TrimSpace(symbol)
+		want := LedgerSymbol(ledger)
+		if want == "" || !strings.EqualFold(symbol, want) {
+			return m, fmt.Errorf("%q is not in %s", s, LedgerLabel(ledger))
+		}
+	}
+
+	whole, frac, _ := strings.Cut(number, ".")
+	whole, ok := ungroup(whole)
+	if !ok || whole+frac == "" || !isDigits(whole) || !isDigits(frac) {
+		return m, fmt.Errorf("%q is not a valid amount", s)
+	}
+	decimals := ledgerDecimals(ledger)
+	if len(frac) > decimals {
+		return m, fmt.Errorf("%q has more than %d decimal places for %s", s, decimals, LedgerLabel(ledger))
+	}
+
+	amount, err := ParseUint128(whole + frac + strings.Repeat("0", decimals-len(frac)))
+	if err != nil {
+		return m, fmt.Errorf("%q is too large", s)
+	}
+	m.Amount = amount
+	return m, nil
+}
+
+// BigInt returns the amount in minor units.
+func (m Money) BigInt() *big.Int {
+	n := m.Amount.BigInt()
+	return &n
+}
+
+// IsZero reports whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.Amount == types.Uint128{}
+}
+
+// Cmp compares amounts in minor units: -1 if m < o, 0 if equal, +1 if m > o.
+// Ledgers are not compared.
+func (m Money) Cmp(o Money) int {
+	return m.BigInt().Cmp(o.BigInt())
+}
+
+// Add returns m + o. Both must be on the same ledger and the sum must fit in
+// 128 bits.
+func (m Money) Add(o Money) (Money, error) {
+	if m.Ledger != o.Ledger {
+		return m, fmt.Errorf("cannot add %s to %s", LedgerLabel(o.Ledger), LedgerLabel(m.Ledger))
+	}
+	sum := new(big.Int).Add(m.BigInt(), o.BigInt())
+	if sum.Cmp(maxUint128) > 0 {
+		return m, fmt.Errorf("%s + %s overflows 128 bits", m, o)
+	}
+	return Money{Amount: types.BigIntToUint128(*sum), Ledger: m.Ledger}, nil
+}
+
+// Sub returns m - o. Both must be on the same ledger and o must not exceed m.
+func (m Money) Sub(o Money) (Money, error) {
+	if m.Ledger != o.Ledger {
+		return m, fmt.Errorf("cannot subtract %s from %s", LedgerLabel(o.Ledger), LedgerLabel(m.Ledger))
+	}
+	diff := new(big.Int).Sub(m.BigInt(), o.BigInt())
+	if diff.Sign() < 0 {
+		return m, fmt.Errorf("%s - %s is negative", m, o)
+	}
+	return Money{Amount: types.BigIntToUint128(*diff), Ledger: m.Ledger}, nil
+}
+
+// Format renders the amount scaled by the ledger's Decimals with thousands
+// separators, without the symbol (for table columns).
+func (m Money) Format() string {
+	return FormatBigAmount(m.BigInt(), m.Ledger)
+}
+
+// String renders the amount followed by the ledger label, e.g.
+// "1,250.500000 USDC".
+func (m Money) String() string {
+	return m.Format() + " " + LedgerLabel(m.Ledger)
+}
+
+// ledgerDecimals returns the ledger's Decimals, or 0 if unmapped.
+func ledgerDecimals(ledger uint32) int {
+	if asset, ok := ActiveChart().Ledgers[ledger]; ok {
+		return asset.Decimals
+	}
+	return 0
+}
+
+// groupThousands inserts "," every three digits from the right.
+func groupThousands(digits string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+	var sb strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		sb.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if sb.Len() > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(digits[i : i+3])
+	}
+	return sb.String()
+}
+
+// ungroup removes thousands separators, checking that every group after the
+// first has exactly three digits.
+func ungroup(s string) (string, bool) {
+	if !strings.Contains(s, ",") {
+		return s, true
+	}
+	groups := strings.Split(s, ",")
+	if len(groups[0]) == 0 || len(groups[0]) > 3 {
+		return "", false
+	}
+	for _, g := range groups[1:] {
+		if len(g) != 3 {
+			return "", false
+		}
+	}
+	return strings.Join(groups, ""), true
+}
@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+const maxUint128Decimal = "340282366920938463463374607431768211455"
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in      string
+		ledger  uint32
+		want    string // minor units
+		wantErr string
+	}{
+		{"0", 1, "0", ""},
+		{"12", 1, "1200", ""},
+		{"12.5", 1, "1250", ""},
+		{"12.50", 1, "1250", ""},
+		{".5", 1, "50", ""},
+		{"5.", 1, "500", ""},
+		{"1,250.5", 1, "125050", ""},
+		{"1,234,567", 1, "123456700", ""},
+		{"  0.25 usd  ", 1, "25", ""},
+		{"1250.50 USDC", 102, "1250500000", ""},
+		{"0.00000001", 100, "1", ""},
+		{"0.000000001 SOL", 104, "1", ""},
+		{"42", 999, "42", ""},
+		{"3402823669209384634633746074317682114.55", 1, maxUint128Decimal, ""},
+		{maxUint128Decimal, 999, maxUint128Decimal, ""},
+
+		{"12.505", 1, "", "more than 2 decimal places"},
+		{"0.0000000001", 100, "", "more than 8 decimal places"},
+		{"1.5", 999, "", "more than 0 decimal places"},
+		{"-1", 1, "", "not a valid amount"},
+		{"-0.5", 1, "", "not a valid amount"},
+		{"+1", 1, "", "not a valid amount"},
+		{"", 1, "", "not a valid amount"},
+		{".", 1, "", "not a valid amount"},
+		{"1.2.3", 1, "", "not a valid amount"},
+		{"1e3", 1, "", "not a valid amount"},
+		{"12,50", 1, "", "not a valid amount"},
+		{"1,2345", 1, "", "not a valid amount"},
+		{",123", 1, "", "not a valid amount"},
+		{"1234,567", 1, "", "not a valid amount"},
+		{"12 EUR", 1, "", "is not in USD"},
+		{"12 USD", 999, "", "is not in 999"},
+		{"3402823669209384634633746074317682114.56", 1, "", "too large"},
+		{"340282366920938463463374607431768211456", 999, "", "too large"},
+	}
+	for _, tt := range tests {
+		m, err := ParseMoney(tt.in, tt.ledger)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseMoney(%q, %d) error = %v, want %q", tt.in, tt.ledger, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseMoney(%q, %d) error = %v", tt.in, tt.ledger, err)
+			continue
+		}
+		if got := m.BigInt().String(); got != tt.want || m.Ledger != tt.ledger {
+			t.Errorf("ParseMoney(%q, %d) = %s on %d, want %s", tt.in, tt.ledger, got, m.Ledger, tt.want)
+		}
+	}
+}
+
+func TestMoneyFormat(t *testing.T) {
+	tests := []struct {
+		amount string // minor units
+		ledger uint32
+		want   string
+	}{
+		{"0", 1, "0.00"},
+		{"5", 1, "0.05"},
+		{"50", 1, "0.50"},
+		{"125050", 1, "1,250.50"},
+		{"123456789", 1, "1,234,567.89"},
+		{"1", 100, "0.00000001"},
+		{"1250500000", 102, "1,250.500000"},
+		{"1", 104, "0.000000001"},
+		{"1234", 999, "1,234"},
+		{maxUint128Decimal, 1, "3,402,823,669,209,384,634,633,746,074,317,682,114.55"},
+	}
+	for _, tt := range tests {
+		m := NewMoney(mustUint128(t, tt.amount), tt.ledger)
+		if got := m.Format(); got != tt.want {
+			t.Errorf("Format(%s on %d) = %q, want %q", tt.amount, tt.ledger, got, tt.want)
+		}
+		// Formatting and parsing round-trip.
+		back, err := ParseMoney(m.Format(), tt.ledger)
+		if err != nil || back != m {
+			t.Errorf("ParseMoney(%q, %d) = %v, %v, want %v", m.Format(), tt.ledger, back, err, m)
+		}
+	}
+
+	if got := NewMoney(types.ToUint128(125050), 102).String(); got != "0.125050 USDC" {
+		t.Errorf("String() = %q, want %q", got, "0.125050 USDC")
+	}
+	if got := FormatBigAmount(big.NewInt(-125050), 1); got != "-1,250.50" {
+		t.Errorf("FormatBigAmount(-125050) = %q, want %q", got, "-1,250.50")
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	usd := func(s string) Money { return NewMoney(mustUint128(t, s), 1) }
+	tests := []struct {
+		name    string
+		op      func() (Money, error)
+		want    string
+		wantErr string
+	}{
+		{"add", func() (Money, error) { return usd("150").Add(usd("250")) }, "400", ""},
+		{"add to max", func() (Money, error) { return usd("340282366920938463463374607431768211454").Add(usd("1")) }, maxUint128Decimal, ""},
+		{"add overflow", func() (Money, error) { return usd(maxUint128Decimal).Add(usd("1")) }, "", "overflows 128 bits"},
+		{"add across ledgers", func() (Money, error) { return usd("1").Add(NewMoney(types.ToUint128(1), 2)) }, "", "cannot add EUR to USD"},
+		{"sub", func() (Money, error) { return usd("250").Sub(usd("150")) }, "100", ""},
+		{"sub to zero", func() (Money, error) { return usd("250").Sub(usd("250")) }, "0", ""},
+		{"sub negative", func() (Money, error) { return usd("150").Sub(usd("250")) }, "", "is negative"},
+		{"sub across ledgers", func() (Money, error) { return usd("1").Sub(NewMoney(types.ToUint128(1), 2)) }, "", "cannot subtract EUR from USD"},
+	}
+	for _, tt := range tests {
+		got, err := tt.op()
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: error = %v", tt.name, err)
+			continue
+		}
+		if got.BigInt().String() != tt.want {
+			t.Errorf("%s = %s, want %s", tt.name, got.BigInt(), tt.want)
+		}
+	}
+
+	if usd("1").Cmp(usd("2")) != -1 || usd("2").Cmp(usd("2")) != 0 || usd("3").Cmp(usd("2")) != 1 {
+		t.Error("Cmp does not order amounts")
+	}
+	if !usd("0").IsZero() || usd("1").IsZero() {
+		t.Error("IsZero is wrong")
+	}
+}
+
+func mustUint128(t *testing.T, s string) types.Uint128 {
+	t.Helper()
+	n, err := ParseUint128(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
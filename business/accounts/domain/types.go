@@ -0,0 +1,88 @@
+package domain
+
+import "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+// MaxBatchSize is the default TigerBeetle limit on events per request.
+const MaxBatchSize = 8189
+
+// DefaultPageSize is the number of accounts fetched per page by the dashboard.
+const DefaultPageSize = 1000
+
+// AccountQuery describes one page request over QueryAccounts.
+// TimestampMin/TimestampMax act as the pagination cursor: a page's Next query
+// narrows the range past the last account returned.
+type AccountQuery struct {
+	Ledger       uint32
+	Code         uint16
+	UserData128  types.Uint128
+	UserData64   uint64
+	UserData32   uint32
+	TimestampMin uint64
+	TimestampMax uint64
+	Limit        uint32
+	Reversed     bool
+
+	// Match, when set, keeps only the accounts it accepts from each page,
+	// for conditions QueryFilter cannot express.
+	Match func(types.Account) bool
+}
+
+// Filter converts the query to a TigerBeetle QueryFilter, clamping Limit to
+// MaxBatchSize.
+func (q AccountQuery) Filter() types.QueryFilter {
+	limit := q.Limit
+	if limit == 0 {
+		limit = DefaultPageSize
+	}
+	if limit > MaxBatchSize {
+		limit = MaxBatchSize
+	}
+
+	return types.QueryFilter{
+		UserData128:  q.UserData128,
+		UserData64:   q.UserData64,
+		UserData32:   q.UserData32,
+		Ledger:       q.Ledger,
+		Code:         q.Code,
+		TimestampMin: q.TimestampMin,
+		TimestampMax: q.TimestampMax,
+		Limit:        limit,
+		Flags:        types.QueryFilterFlags{Reversed: q.Reversed}.ToUint32(),
+	}
+}
+
+// AccountPage is one page of accounts plus the query for the page after it.
+// Scanned counts the accounts fetched before the query's Match dropped any.
+type AccountPage struct {
+	Accounts []types.Account
+	Scanned  int
+	Next     AccountQuery
+	HasMore  bool
+}
+
+// NextQuery returns the query that continues after the last timestamp seen.
+// Ascending pages move TimestampMin forward; reversed pages move TimestampMax
+// backward.
+func (q AccountQuery) NextQuery(lastTimestamp uint64) AccountQuery {
+	next := q
+	if q.Reversed {
+		next.TimestampMax = lastTimestamp - 1
+	} else {
+		next.TimestampMin = lastTimestamp + 1
+	}
+	return next
+}
+
+// BalancesQuery builds the AccountFilter for the most recent balance
+// snapshots of an account (GetAccountBalances), newest first.
+func BalancesQuery(accountID types.Uint128) types.AccountFilter {
+	return types.AccountFilter{
+		AccountID: accountID,
+		Limit:     MaxBatchSize,
+		Flags: types.AccountFilterFlags{
+			Debits:   true,
+			Credits:  true,
+			Reversed: true,
+		}.ToUint32(),
+	}
+}
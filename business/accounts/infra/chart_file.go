@@ -0,0 +1,158 @@
+package infra
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/fd1az/tiger-tui/business/accounts/domain"
+	"github.com/fd1az/tiger-tui/internal/apperror"
+)
+
+// maxDecimals is the most decimals a 128-bit amount can carry.
+const maxDecimals = 38
+
+// chartFile is the on-disk chart of accounts. Entries are lists rather than
+// maps so duplicate IDs can be reported instead of silently merged.
+type chartFile struct {
+	Name    string `mapstructure:"name"`
+	Ledgers []struct {
+		ID       uint32 `mapstructure:"id"`
+		Symbol   string `mapstructure:"symbol"`
+		Name     string `mapstructure:"name"`
+		Decimals *int   `mapstructure:"decimals"`
+	} `mapstructure:"ledgers"`
+	AccountTypes  []chartCode `mapstructure:"account_types"`
+	TransferTypes []chartCode `mapstructure:"transfer_types"`
+	Venues        []struct {
+		ID   uint32 `mapstructure:"id"`
+		Name string `mapstructure:"name"`
+	} `mapstructure:"venues"`
+}
+
+type chartCode struct {
+	Code uint16 `mapstructure:"code"`
+	Name string `mapstructure:"name"`
+}
+
+// LoadChart reads a chart of accounts from a YAML or JSON file (by
+// extension) and validates it. Every problem found is reported in one
+// CodeConfigurationError.
+func LoadChart(path string) (domain.Chart, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return domain.Chart{}, apperror.New(apperror.CodeConfigurationError,
+			apperror.WithMessage(fmt.Sprintf("failed to read chart of accounts: %v", err)),
+			apperror.WithContext(path),
+			apperror.WithCause(err))
+	}
+
+	var f chartFile
+	if err := v.Unmarshal(&f); err != nil {
+		return domain.Chart{}, apperror.New(apperror.CodeConfigurationError,
+			apperror.WithMessage(fmt.Sprintf("failed to parse chart of accounts: %v", err)),
+			apperror.WithContext(path),
+			apperror.WithCause(err))
+	}
+
+	chart, problems := f.toChart()
+	if len(problems) > 0 {
+		return domain.Chart{}, apperror.New(apperror.CodeConfigurationError,
+			apperror.WithMessage("invalid chart of accounts: "+strings.Join(problems, "; ")),
+			apperror.WithContext(path))
+	}
+	if chart.Name == "" {
+		chart.Name = path
+	}
+	return chart, nil
+}
+
+// toChart converts the file to a domain chart, collecting validation
+// problems.
+func (f chartFile) toChart() (domain.Chart, []string) {
+	var problems []string
+	c := domain.Chart{
+		Name:          f.Name,
+		Ledgers:       make(map[uint32]domain.LedgerAsset, len(f.Ledgers)),
+		AccountTypes:  make(map[uint16]string, len(f.AccountTypes)),
+		TransferTypes: make(map[uint16]string, len(f.TransferTypes)),
+		Venues:        make(map[uint32]string, len(f.Venues)),
+	}
+
+	if len(f.Ledgers) == 0 {
+		problems = append(problems, "no ledgers defined")
+	}
+	seen := map[uint32]bool{}
+	symbols := map[string]uint32{} // by upper case; symbols resolve case-insensitively
+	for _, l := range f.Ledgers {
+		if seen[l.ID] {
+			problems = append(problems, fmt.Sprintf("duplicate ledger %d", l.ID))
+			continue
+		}
+		seen[l.ID] = true
+		if l.ID == 0 {
+			problems = append(problems, "ledger 0: missing or zero id")
+		}
+		symbol := strings.ToUpper(l.Symbol)
+		if l.Symbol == "" {
+			problems = append(problems, fmt.Sprintf("ledger %d: missing symbol", l.ID))
+		} else if other, dup := symbols[symbol]; dup {
+			problems = append(problems, fmt.Sprintf("ledger %d: symbol %s already used by ledger %d", l.ID, l.Symbol, other))
+		}
+		symbols[symbol] = l.ID
+		switch {
+		case l.Decimals == nil:
+			problems = append(problems, fmt.Sprintf("ledger %d: missing decimals", l.ID))
+			continue
+		case *l.Decimals < 0 || *l.Decimals > maxDecimals:
+			problems = append(problems, fmt.Sprintf("ledger %d: decimals must be between 0 and %d", l.ID, maxDecimals))
+			continue
+		}
+		c.Ledgers[l.ID] = domain.LedgerAsset{Symbol: l.Symbol, Name: l.Name, Decimals: *l.Decimals}
+	}
+
+	problems = append(problems, collectCodes("account type", f.AccountTypes, c.AccountTypes)...)
+	problems = append(problems, collectCodes("transfer type", f.TransferTypes, c.TransferTypes)...)
+
+	for _, v := range f.Venues {
+		if _, dup := c.Venues[v.ID]; dup {
+			problems = append(problems, fmt.Sprintf("duplicate venue %d", v.ID))
+			continue
+		}
+		if v.Name == "" {
+			problems = append(problems, fmt.Sprintf("venue %d: missing name", v.ID))
+		}
+		c.Venues[v.ID] = v.Name
+	}
+
+	return c, problems
+}
+
+// collectCodes fills dst from entries, reporting zero and duplicate codes
+// and duplicate names. Names resolve case-insensitively, so they are compared
+// that way.
+func collectCodes(kind string, entries []chartCode, dst map[uint16]string) []string {
+	var problems []string
+	names := map[string]uint16{}
+	for _, e := range entries {
+		if _, dup := dst[e.Code]; dup {
+			problems = append(problems, fmt.Sprintf("duplicate %s code %d", kind, e.Code))
+			continue
+		}
+		if e.Code == 0 {
+			problems = append(problems, fmt.Sprintf("%s 0: missing or zero code", kind))
+		}
+		name := strings.ToUpper(e.Name)
+		switch other, dup := names[name]; {
+		case e.Name == "":
+			problems = append(problems, fmt.Sprintf("%s %d: missing name", kind, e.Code))
+		case dup:
+			problems = append(problems, fmt.Sprintf("%s %d: name %s already used by code %d", kind, e.Code, e.Name, other))
+		}
+		names[name] = e.Code
+		dst[e.Code] = e.Name
+	}
+	return problems
+}
@@ -0,0 +1,149 @@
+package infra
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fd1az/tiger-tui/internal/apperror"
+)
+
+func writeChart(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadChart(t *testing.T) {
+	path := writeChart(t, "chart.yaml", `
+name: my-product
+ledgers:
+  - { id: 1, symbol: USD, name: US Dollar, decimals: 2 }
+  - { id: 102, symbol: USDC, name: USD Coin, decimals: 6 }
+  - { id: 7, symbol: PTS, name: Points, decimals: 0 }
+account_types:
+  - { code: 1, name: USER_WALLET }
+  - { code: 200, name: HOLD_TRADE }
+transfer_types:
+  - { code: 1, name: DEPOSIT }
+venues:
+  - { id: 0, name: Internal }
+`)
+	c, err := LoadChart(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Name != "my-product" || len(c.Ledgers) != 3 || c.Ledgers[102].Decimals != 6 || c.Ledgers[7].Decimals != 0 {
+		t.Errorf("LoadChart = %+v", c)
+	}
+	if c.AccountTypes[200] != "HOLD_TRADE" || c.TransferTypes[1] != "DEPOSIT" || c.Venues[0] != "Internal" {
+		t.Errorf("LoadChart = %+v", c)
+	}
+
+	// JSON by extension; a chart without a name is named by its path.
+	path = writeChart(t, "chart.json", `{"ledgers": [{"id": 1, "symbol": "USD", "decimals": 2}]}`)
+	if c, err := LoadChart(path); err != nil || c.Name != path {
+		t.Errorf("LoadChart(json) = %+v, %v, want it named %s", c, err, path)
+	}
+}
+
+func TestLoadChartErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"no ledgers", `name: empty`, []string{"no ledgers defined"}},
+		{"missing decimals", `
+ledgers:
+  - { id: 1, symbol: USD }
+`, []string{"ledger 1: missing decimals"}},
+		{"decimals out of range", `
+ledgers:
+  - { id: 1, symbol: USD, decimals: -1 }
+  - { id: 2, symbol: EUR, decimals: 39 }
+`, []string{"ledger 1: decimals must be between 0 and 38", "ledger 2: decimals must be between 0 and 38"}},
+		{"ledger without id", `
+ledgers:
+  - { symbol: USD, decimals: 2 }
+`, []string{"ledger 0: missing or zero id"}},
+		{"missing symbol", `
+ledgers:
+  - { id: 1, decimals: 2 }
+`, []string{"ledger 1: missing symbol"}},
+		{"duplicate ledger", `
+ledgers:
+  - { id: 1, symbol: USD, decimals: 2 }
+  - { id: 1, symbol: EUR, decimals: 2 }
+`, []string{"duplicate ledger 1"}},
+		{"duplicate symbol", `
+ledgers:
+  - { id: 1, symbol: USD, decimals: 2 }
+  - { id: 2, symbol: USD, decimals: 2 }
+`, []string{"ledger 2: symbol USD already used by ledger 1"}},
+		{"duplicate symbol in another case", `
+ledgers:
+  - { id: 1, symbol: USDC, decimals: 6 }
+  - { id: 2, symbol: usdc, decimals: 6 }
+`, []string{"ledger 2: symbol usdc already used by ledger 1"}},
+		{"account type problems", `
+ledgers:
+  - { id: 1, symbol: USD, decimals: 2 }
+account_types:
+  - { code: 1, name: USER_WALLET }
+  - { code: 1, name: OTHER }
+  - { code: 2, name: user_wallet }
+  - { code: 3 }
+  - { name: NO_CODE }
+`, []string{
+			"duplicate account type code 1",
+			"account type 2: name user_wallet already used by code 1",
+			"account type 3: missing name",
+			"account type 0: missing or zero code",
+		}},
+		{"transfer type problems", `
+ledgers:
+  - { id: 1, symbol: USD, decimals: 2 }
+transfer_types:
+  - { code: 1, name: DEPOSIT }
+  - { code: 2, name: Deposit }
+`, []string{"transfer type 2: name Deposit already used by code 1"}},
+		{"venue problems", `
+ledgers:
+  - { id: 1, symbol: USD, decimals: 2 }
+venues:
+  - { id: 0, name: Internal }
+  - { id: 0, name: Again }
+  - { id: 1 }
+`, []string{"duplicate venue 0", "venue 1: missing name"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadChart(writeChart(t, "chart.yaml", tt.content))
+			if apperror.GetCode(err) != apperror.CodeConfigurationError {
+				t.Fatalf("LoadChart error = %v, want a %s", err, apperror.CodeConfigurationError)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("LoadChart error = %q, want it to report %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadChartUnreadable(t *testing.T) {
+	for _, path := range []string{
+		filepath.Join(t.TempDir(), "missing.yaml"),
+		writeChart(t, "chart.yaml", "ledgers: [ {"),
+		writeChart(t, "chart.yaml", "ledgers: not-a-list"),
+	} {
+		if _, err := LoadChart(path); err == nil {
+			t.Errorf("LoadChart(%s) succeeded", path)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package infra
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fd1az/tiger-tui/business/accounts/domain"
+	"github.com/fd1az/tiger-tui/internal/apperror"
+)
+
+// maxImportLine bounds one NDJSON line.
+const maxImportLine = 1 << 20
+
+// ReadNDJSON decodes one JSON object per line and calls fn with its 1-based
+// line number and its values as text (see jsonText). Blank lines are
+// skipped.
+func ReadNDJSON(r io.Reader, fn func(line int, fields map[string]string) error) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), maxImportLine)
+
+	for line := 1; sc.Scan(); line++ {
+		text := bytes.TrimSpace(sc.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		dec := json.NewDecoder(bytes.NewReader(text))
+		dec.UseNumber()
+		var obj map[string]any
+		if err := dec.Decode(&obj); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		fields := make(map[string]string, len(obj))
+		for k, v := range obj {
+			value, err := jsonText(v)
+			if err != nil {
+				return fmt.Errorf("line %d: %s: %w", line, k, err)
+			}
+			fields[k] = value
+		}
+		if err := fn(line, fields); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	return sc.Err()
+}
+
+// jsonText converts an NDJSON value to column text. Numbers keep their exact
+// digits; a list of strings (flags) is joined with "|".
+func jsonText(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case []any:
+		parts := make([]string, len(v))
+		for i, e := range v {
+			s, ok := e.(string)
+			if !ok {
+				return "", fmt.Errorf("list items must be strings")
+			}
+			parts[i] = s
+		}
+		return strings.Join(parts, "|"), nil
+	}
+	return "", fmt.Errorf("unsupported value %v", v)
+}
+
+// ReadImportNDJSON reads account rows, one object per line with
+// domain.ImportColumns keys. name identifies the input in errors.
+func ReadImportNDJSON(r io.Reader, name string) ([]domain.ImportRow, error) {
+	var rows []domain.ImportRow
+	err := ReadNDJSON(r, func(line int, fields map[string]string) error {
+		row := domain.ImportRow{Line: line}
+		for k, v := range fields {
+			if !row.Set(k, v) {
+				return fmt.Errorf("unknown key %q", k)
+			}
+		}
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		return nil, apperror.New(apperror.CodeImportReadFailed,
+			apperror.WithMessage(fmt.Sprintf("failed to read accounts: %v", err)),
+			apperror.WithContext(name),
+			apperror.WithCause(err))
+	}
+	return rows, nil
+}
+
+// WriteImportNDJSON writes one result object per line: line, id (omitted
+// for invalid rows), result and error.
+func WriteImportNDJSON(w io.Writer, results []domain.ImportResult) error {
+	enc := json.NewEncoder(w)
+	for _, r := range results {
+		id := ""
+		if r.Result != domain.ImportInvalid {
+			id = domain.FormatID(r.ID)
+		}
+		err := enc.Encode(struct {
+			Line   int    `json:"line"`
+			ID     string `json:"id,omitempty"`
+			Result string `json:"result"`
+			Error  string `json:"error,omitempty"`
+		}{r.Line, id, r.Result, r.Error})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Package infra provides the TigerBeetle-backed accounts repository.
+package infra
+
+import (
+	tb "github.com/tigerbeetle/tigerbeetle-go"
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	"github.com/fd1az/tiger-tui/business/accounts/domain"
+	"github.com/fd1az/tiger-tui/internal/apperror"
+)
+
+// Repository reads accounts from TigerBeetle.
+type Repository struct {
+	client tb.Client
+}
+
+// NewRepository creates a repository over a connected TigerBeetle client.
+func NewRepository(client tb.Client) *Repository {
+	return &Repository{client: client}
+}
+
+// QueryAccounts runs a single QueryAccounts request for the given query.
+func (r *Repository) QueryAccounts(q domain.AccountQuery) ([]types.Account, error) {
+	accounts, err := r.client.QueryAccounts(q.Filter())
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.CodeTBRequestFailed, "query accounts")
+	}
+	return accounts, nil
+}
+
+// LookupAccounts fetches accounts by ID. Unknown IDs are omitted.
+func (r *Repository) LookupAccounts(ids []types.Uint128) ([]types.Account, error) {
+	accounts, err := r.client.LookupAccounts(ids)
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.CodeTBRequestFailed, "lookup accounts")
+	}
+	return accounts, nil
+}
+
+// GetAccountBalances runs a GetAccountBalances request for one account.
+func (r *Repository) GetAccountBalances(accountID types.Uint128) ([]types.AccountBalance, error) {
+	balances, err := r.client.GetAccountBalances(domain.BalancesQuery(accountID))
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.CodeTBRequestFailed, "get account balances")
+	}
+	return balances, nil
+}
+
+// CreateAccounts submits a batch of accounts and returns the failed results.
+func (r *Repository) CreateAccounts(accounts []types.Account) ([]types.AccountEventResult, error) {
+	results, err := r.client.CreateAccounts(accounts)
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.CodeAccountCreateFailed, "create accounts")
+	}
+	return results, nil
+}
@@ -0,0 +1,41 @@
+// Package app provides the balance sheet application service.
+package app
+
+import (
+	accountsdomain "github.com/fd1az/tiger-tui/business/accounts/domain"
+	"github.com/fd1az/tiger-tui/business/balancesheet/domain"
+)
+
+// AccountSource is the port the service reads accounts through; the accounts
+// app.Service satisfies it.
+type AccountSource interface {
+	LoadPage(q accountsdomain.AccountQuery) (accountsdomain.AccountPage, error)
+}
+
+// Service builds the balance sheet from every account in the cluster.
+type Service struct {
+	accounts AccountSource
+}
+
+// NewService creates a new balance sheet service.
+func NewService(accounts AccountSource) *Service {
+	return &Service{accounts: accounts}
+}
+
+// Load pages through all accounts in full batches and aggregates them.
+func (s *Service) Load() (domain.Summary, error) {
+	b := domain.NewBuilder()
+	q := accountsdomain.AccountQuery{Limit: accountsdomain.MaxBatchSize}
+	for {
+		page, err := s.accounts.LoadPage(q)
+		if err != nil {
+			return domain.Summary{}, err
+		}
+		b.Add(page.Accounts)
+		if !page.HasMore {
+			break
+		}
+		q = page.Next
+	}
+	return b.Summary(), nil
+}
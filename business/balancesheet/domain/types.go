@@ -0,0 +1,154 @@
+// Package domain provides balance sheet aggregation and trial balance checks.
+package domain
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// Verdict is the trial balance outcome for a ledger.
+type Verdict int
+
+const (
+	Balanced Verdict = iota
+	Unbalanced
+)
+
+// String returns the verdict name.
+func (v Verdict) String() string {
+	if v == Unbalanced {
+		return "unbalanced"
+	}
+	return "balanced"
+}
+
+// Totals holds summed account balances. Values are arbitrary precision so
+// sums of uint128 balances cannot overflow.
+type Totals struct {
+	DebitsPosted   *big.Int
+	CreditsPosted  *big.Int
+	DebitsPending  *big.Int
+	CreditsPending *big.Int
+}
+
+func newTotals() Totals {
+	return Totals{
+		DebitsPosted:   new(big.Int),
+		CreditsPosted:  new(big.Int),
+		DebitsPending:  new(big.Int),
+		CreditsPending: new(big.Int),
+	}
+}
+
+func (t Totals) add(acc types.Account) {
+	addUint128(t.DebitsPosted, acc.DebitsPosted)
+	addUint128(t.CreditsPosted, acc.CreditsPosted)
+	addUint128(t.DebitsPending, acc.DebitsPending)
+	addUint128(t.CreditsPending, acc.CreditsPending)
+}
+
+func addUint128(sum *big.Int, v types.Uint128) {
+	n := v.BigInt()
+	sum.Add(sum, &n)
+}
+
+// TypeTotals is the aggregate for one account code within a ledger.
+type TypeTotals struct {
+	Code     uint16
+	Accounts int
+	Totals   Totals
+}
+
+// LedgerSummary is the trial balance of one ledger.
+type LedgerSummary struct {
+	Ledger   uint32
+	Types    []TypeTotals // sorted by code
+	Total    Totals
+	Verdict  Verdict
+	Accounts int
+
+	// PostedDiscrepancy and PendingDiscrepancy are debits minus credits;
+	// negative when credits exceed debits.
+	PostedDiscrepancy  *big.Int
+	PendingDiscrepancy *big.Int
+}
+
+// Summary is the balance sheet for every ledger in the cluster.
+type Summary struct {
+	Ledgers  []LedgerSummary // sorted by ledger ID
+	Accounts int
+}
+
+// Unbalanced returns the number of ledgers that failed the trial balance.
+func (s Summary) Unbalanced() int {
+	n := 0
+	for _, l := range s.Ledgers {
+		if l.Verdict == Unbalanced {
+			n++
+		}
+	}
+	return n
+}
+
+// Builder accumulates accounts into a Summary one page at a time.
+type Builder struct {
+	ledgers map[uint32]map[uint16]*TypeTotals
+	count   int
+}
+
+// NewBuilder creates an empty builder.
+func NewBuilder() *Builder {
+	return &Builder{ledgers: map[uint32]map[uint16]*TypeTotals{}}
+}
+
+// Add folds accounts into the running totals.
+func (b *Builder) Add(accounts []types.Account) {
+	for _, acc := range accounts {
+		codes, ok := b.ledgers[acc.Ledger]
+		if !ok {
+			codes = map[uint16]*TypeTotals{}
+			b.ledgers[acc.Ledger] = codes
+		}
+		tt, ok := codes[acc.Code]
+		if !ok {
+			tt = &TypeTotals{Code: acc.Code, Totals: newTotals()}
+			codes[acc.Code] = tt
+		}
+		tt.Totals.add(acc)
+		tt.Accounts++
+		b.count++
+	}
+}
+
+// Summary computes per-ledger totals and trial balance verdicts.
+// A ledger balances when posted debits equal posted credits and pending
+// debits equal pending credits.
+func (b *Builder) Summary() Summary {
+	s := Summary{Accounts: b.count}
+
+	for ledger, codes := range b.ledgers {
+		ls := LedgerSummary{Ledger: ledger, Total: newTotals()}
+		for _, tt := range codes {
+			ls.Types = append(ls.Types, *tt)
+			ls.Accounts += tt.Accounts
+			ls.Total.DebitsPosted.Add(ls.Total.DebitsPosted, tt.Totals.DebitsPosted)
+			ls.Total.CreditsPosted.Add(ls.Total.CreditsPosted, tt.Totals.CreditsPosted)
+			ls.Total.DebitsPending.Add(ls.Total.DebitsPending, tt.Totals.DebitsPending)
+			ls.Total.CreditsPending.Add(ls.Total.CreditsPending, tt.Totals.CreditsPending)
+		}
+		sort.Slice(ls.Types, func(i, j int) bool { return ls.Types[i].Code < ls.Types[j].Code })
+
+		ls.PostedDiscrepancy = new(big.Int).Sub(ls.Total.DebitsPosted, ls.Total.CreditsPosted)
+		ls.PendingDiscrepancy = new(big.Int).Sub(ls.Total.DebitsPending, ls.Total.CreditsPending)
+		if ls.PostedDiscrepancy.Sign() != 0 || ls.PendingDiscrepancy.Sign() != 0 {
+			ls.Verdict = Unbalanced
+		}
+
+		s.Ledgers = append(s.Ledgers, ls)
+	}
+	sort.Slice(s.Ledgers, func(i, j int) bool { return s.Ledgers[i].Ledger < s.Ledgers[j].Ledger })
+
+	return s
+}
@@ -0,0 +1,147 @@
+// Package domain provides the settings a TigerBeetle connection is made
+// with: the cluster ID and the replica addresses.
+package domain
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	accounts "github.com/fd1az/tiger-tui/business/accounts/domain"
+)
+
+// MaxReplicas is the most replicas a TigerBeetle cluster runs.
+const MaxReplicas = 6
+
+// ParseClusterID parses a cluster ID as a decimal or 0x-prefixed hex
+// integer of up to 128 bits, with the same rules as account and transfer
+// IDs.
+func ParseClusterID(s string) (types.Uint128, error) {
+	id, err := accounts.ParseNumber(s)
+	if err != nil {
+		return types.Uint128{}, fmt.Errorf("cluster ID %w", err)
+	}
+	return id, nil
+}
+
+// FormatClusterID formats a cluster ID as decimal, the form the outbox
+// journals under whichever way the ID was typed.
+func FormatClusterID(id types.Uint128) string {
+	n := id.BigInt()
+	return n.String()
+}
+
+// ParseAddresses splits a comma-separated list of replica addresses and
+// validates it.
+func ParseAddresses(s string) ([]string, error) {
+	var addrs []string
+	for _, a := range strings.Split(s, ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			addrs = append(addrs, a)
+		}
+	}
+	if err := ValidateAddresses(addrs); err != nil {
+		return nil, err
+	}
+	return addrs, nil
+}
+
+// ValidateAddresses checks every replica address and that there are one to
+// MaxReplicas of them, without repeats.
+func ValidateAddresses(addrs []string) error {
+	if len(addrs) == 0 {
+		return fmt.Errorf("at least one replica address is required")
+	}
+	if len(addrs) > MaxReplicas {
+		return fmt.Errorf("%d replica addresses given; a cluster has at most %d", len(addrs), MaxReplicas)
+	}
+	seen := map[string]bool{}
+	for _, a := range addrs {
+		if err := ValidateAddress(a); err != nil {
+			return err
+		}
+		if seen[a] {
+			return fmt.Errorf("replica address %q is listed twice", a)
+		}
+		seen[a] = true
+	}
+	return nil
+}
+
+// ValidateAddress checks one replica address in a form the TigerBeetle
+// client accepts: a port ("3000", on 127.0.0.1), an IPv4 address with or
+// without a port ("10.0.0.1:3000", "10.0.0.1" on port 3001), or an IPv6
+// address in brackets ("[::1]:3000") or bare ("::1"). Host names are not
+// resolved by the client, so they are rejected.
+func ValidateAddress(a string) error {
+	if a == "" {
+		return fmt.Errorf("replica address is empty")
+	}
+	if isDigits(a) {
+		return validatePort(a, a)
+	}
+
+	host, port := a, ""
+	switch {
+	case strings.HasPrefix(a, "["):
+		end := strings.Index(a, "]")
+		if end < 0 {
+			return fmt.Errorf("replica address %q is missing the closing ]", a)
+		}
+		host, port = a[1:end], a[end+1:]
+		if port != "" {
+			if !strings.HasPrefix(port, ":") {
+				return fmt.Errorf("replica address %q: expected :port after ]", a)
+			}
+			port = port[1:]
+		}
+		if ip := net.ParseIP(host); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("replica address %q: %q is not an IPv6 address", a, host)
+		}
+	case strings.Count(a, ":") > 1:
+		// A bare IPv6 address; one with a port needs brackets.
+		if ip := net.ParseIP(a); ip == nil {
+			return fmt.Errorf("replica address %q is not an IPv6 address; write [address]:port for a port", a)
+		}
+		return nil
+	default:
+		if h, p, ok := strings.Cut(a, ":"); ok {
+			host, port = h, p
+		}
+		if ip := net.ParseIP(host); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("replica address %q: %q is not an IPv4 address (host names are not resolved)", a, host)
+		}
+	}
+	if port == "" && strings.HasSuffix(a, ":") {
+		return fmt.Errorf("replica address %q is missing the port after :", a)
+	}
+	if port != "" {
+		return validatePort(a, port)
+	}
+	return nil
+}
+
+// validatePort checks that port is a TCP port number 1-65535.
+func validatePort(addr, port string) error {
+	n, err := strconv.Atoi(port)
+	if err != nil || !isDigits(port) || n < 1 || n > 65535 {
+		return fmt.Errorf("replica address %q: port %q is not 1-65535", addr, port)
+	}
+	return nil
+}
+
+// isDigits reports whether s is a non-empty run of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseClusterID(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string // decimal
+		wantErr string
+	}{
+		{"0", "0", ""},
+		{" 42 ", "42", ""},
+		{"0x2A", "42", ""},
+		{"0x2a", "42", ""},
+		{"0X2a", "42", ""},
+		{"18446744073709551616", "18446744073709551616", ""}, // 2^64
+		{"340282366920938463463374607431768211455", "340282366920938463463374607431768211455", ""},
+		{"0xffffffffffffffffffffffffffffffff", "340282366920938463463374607431768211455", ""},
+
+		{"340282366920938463463374607431768211456", "", "exceeds 128 bits"},
+		{"0x100000000000000000000000000000000", "", "exceeds 128 bits"},
+		{"", "", "not an unsigned decimal integer"},
+		{"-1", "", "not an unsigned decimal integer"},
+		{"+1", "", "not an unsigned decimal integer"},
+		{"1_000", "", "not an unsigned decimal integer"},
+		{"0x", "", "not a hex integer"},
+		{"0x-1", "", "not a hex integer"},
+		{"0x+1", "", "not a hex integer"},
+		{"abc", "", "not an unsigned decimal integer"},
+		{"1.5", "", "not an unsigned decimal integer"},
+		{"00000000-0000-0000-0000-00000000002a", "", "not an unsigned decimal integer"}, // IDs only
+	}
+	for _, tt := range tests {
+		id, err := ParseClusterID(tt.in)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseClusterID(%q) error = %v, want %q", tt.in, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseClusterID(%q) error = %v", tt.in, err)
+			continue
+		}
+		if got := FormatClusterID(id); got != tt.want {
+			t.Errorf("ParseClusterID(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestValidateAddresses(t *testing.T) {
+	tests := []struct {
+		addrs   []string
+		wantErr string
+	}{
+		{[]string{"3000"}, ""},
+		{[]string{"1"}, ""},
+		{[]string{"65535"}, ""},
+		{[]string{"127.0.0.1"}, ""},
+		{[]string{"10.0.0.1:3000"}, ""},
+		{[]string{"[::1]:3000"}, ""},
+		{[]string{"[::1]"}, ""},
+		{[]string{"::1"}, ""},
+		{[]string{"[fe80::1]:3001", "10.0.0.2:3001", "3002"}, ""},
+		{[]string{"3001", "3002", "3003", "3004", "3005", "3006"}, ""},
+
+		{nil, "at least one replica address"},
+		{[]string{"3001", "3002", "3003", "3004", "3005", "3006", "3007"}, "7 replica addresses given; a cluster has at most 6"},
+		{[]string{"3000", "3000"}, "listed twice"},
+		{[]string{""}, "is empty"},
+		{[]string{"0"}, "is not 1-65535"},
+		{[]string{"65536"}, "is not 1-65535"},
+		{[]string{"10.0.0.1:0"}, "is not 1-65535"},
+		{[]string{"10.0.0.1:70000"}, "is not 1-65535"},
+		{[]string{"10.0.0.1:+80"}, "is not 1-65535"},
+		{[]string{"10.0.0.1:"}, "missing the port"},
+		{[]string{"[::1]:"}, "missing the port"},
+		{[]string{"[::1]:0"}, "is not 1-65535"},
+		{[]string{"[::1"}, "missing the closing ]"},
+		{[]string{"[::1]3000"}, "expected :port after ]"},
+		{[]string{"[10.0.0.1]:3000"}, "is not an IPv6 address"},
+		{[]string{"[localhost]:3000"}, "is not an IPv6 address"},
+		{[]string{"::1:3000:x"}, "is not an IPv6 address"},
+		{[]string{"localhost:3000"}, "host names are not resolved"},
+		{[]string{"example.com"}, "host names are not resolved"},
+		{[]string{"10.0.0.256:3000"}, "is not an IPv4 address"},
+	}
+	for _, tt := range tests {
+		err := ValidateAddresses(tt.addrs)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("ValidateAddresses(%q) error = %v", tt.addrs, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("ValidateAddresses(%q) error = %v, want %q", tt.addrs, err, tt.wantErr)
+		}
+	}
+}
+
+func TestParseAddresses(t *testing.T) {
+	got, err := ParseAddresses(" 3001, 10.0.0.2:3002 ,,[::1]:3003 ")
+	want := []string{"3001", "10.0.0.2:3002", "[::1]:3003"}
+	if err != nil || strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("ParseAddresses = %q, %v, want %q", got, err, want)
+	}
+	if _, err := ParseAddresses(" , "); err == nil {
+		t.Error("ParseAddresses of no addresses succeeded")
+	}
+}
@@ -2,28 +2,39 @@ package infra
 
 import (
 	"fmt"
-	"strconv"
 	"time"
 
 	tb "github.com/tigerbeetle/tigerbeetle-go"
 	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	"github.com/fd1az/tiger-tui/business/connection/domain"
+	"github.com/fd1az/tiger-tui/internal/apperror"
 )
 
 const healthCheckTimeout = 5 * time.Second
 
 // Client wraps the TigerBeetle Go client with a health-check on connect.
 type Client struct {
-	raw tb.Client
+	raw       tb.Client
+	clusterID types.Uint128
 }
 
 // Connect creates a TigerBeetle client and verifies connectivity with a
 // health-check query. NewClient itself retries in the background and won't
 // fail immediately when the server is down, so we run a QueryAccounts(Limit:1)
-// behind a timeout to confirm real connectivity.
+// behind a timeout to confirm real connectivity. The cluster ID is a decimal
+// or 0x hex uint128; addresses are validated before the client sees them.
 func Connect(clusterID string, addresses []string) (*Client, error) {
-	id, err := parseClusterID(clusterID)
+	id, err := domain.ParseClusterID(clusterID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid cluster ID %q: %w", clusterID, err)
+		return nil, apperror.New(apperror.CodeTBInvalidCluster,
+			apperror.WithMessage(err.Error()),
+			apperror.WithCause(err))
+	}
+	if err := domain.ValidateAddresses(addresses); err != nil {
+		return nil, apperror.New(apperror.CodeTBInvalidAddress,
+			apperror.WithMessage(err.Error()),
+			apperror.WithCause(err))
 	}
 
 	raw, err := tb.NewClient(id, addresses)
@@ -51,7 +62,7 @@ func Connect(clusterID string, addresses []string) (*Client, error) {
 		return nil, fmt.Errorf("connection timed out after %s", healthCheckTimeout)
 	}
 
-	return &Client{raw: raw}, nil
+	return &Client{raw: raw, clusterID: id}, nil
 }
 
 // Close closes the underlying TigerBeetle client.
@@ -66,10 +77,7 @@ func (c *Client) Raw() tb.Client {
 	return c.raw
 }
 
-func parseClusterID(s string) (types.Uint128, error) {
-	n, err := strconv.ParseUint(s, 10, 64)
-	if err != nil {
-		return types.Uint128{}, err
-	}
-	return types.ToUint128(n), nil
+// ClusterID returns the connected cluster's ID as decimal.
+func (c *Client) ClusterID() string {
+	return domain.FormatClusterID(c.clusterID)
 }
@@ -0,0 +1,224 @@
+// Package app provides the export application service: a job that streams
+// records from the cluster (or from a view already in memory) into a file a
+// page at a time.
+package app
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	accountsdomain "github.com/fd1az/tiger-tui/business/accounts/domain"
+	"github.com/fd1az/tiger-tui/business/export/domain"
+	transfersdomain "github.com/fd1az/tiger-tui/business/transfers/domain"
+)
+
+// AccountSource is the port accounts are paged through; the accounts
+// app.Service satisfies it.
+type AccountSource interface {
+	LoadPage(q accountsdomain.AccountQuery) (accountsdomain.AccountPage, error)
+}
+
+// TransferSource is the port transfers are paged through; the transfers
+// app.Service satisfies it.
+type TransferSource interface {
+	LoadPage(q transfersdomain.TransferQuery) (transfersdomain.TransferPage, error)
+}
+
+// Sink is the port records are written through.
+type Sink interface {
+	Write(records [][]string) error
+	// Close completes the file.
+	Close() error
+	// Abort discards a partial file.
+	Abort() error
+}
+
+// Source yields export records a page at a time.
+type Source interface {
+	Next() (records [][]string, more bool, err error)
+}
+
+// Accounts pages through every account matching q, in q's order, in full
+// batches.
+func Accounts(src AccountSource, q accountsdomain.AccountQuery) Source {
+	q.Limit = accountsdomain.MaxBatchSize
+	return &accountPages{src: src, q: q}
+}
+
+type accountPages struct {
+	src AccountSource
+	q   accountsdomain.AccountQuery
+}
+
+func (p *accountPages) Next() ([][]string, bool, error) {
+	page, err := p.src.LoadPage(p.q)
+	if err != nil {
+		return nil, false, err
+	}
+	records := make([][]string, len(page.Accounts))
+	for i, a := range page.Accounts {
+		records[i] = domain.AccountRecord(a)
+	}
+	p.q = page.Next
+	return records, page.HasMore, nil
+}
+
+// Transfers pages through every transfer matching q, in q's order, in full
+// batches.
+func Transfers(src TransferSource, q transfersdomain.TransferQuery) Source {
+	q.Limit = accountsdomain.MaxBatchSize
+	return &transferPages{src: src, q: q}
+}
+
+type transferPages struct {
+	src TransferSource
+	q   transfersdomain.TransferQuery
+}
+
+func (p *transferPages) Next() ([][]string, bool, error) {
+	page, err := p.src.LoadPage(p.q)
+	if err != nil {
+		return nil, false, err
+	}
+	records := transferRecords(page.Transfers)
+	p.q = page.Next
+	return records, page.HasMore, nil
+}
+
+func transferRecords(transfers []types.Transfer) [][]string {
+	records := make([][]string, len(transfers))
+	for i, t := range transfers {
+		records[i] = domain.TransferRecord(t)
+	}
+	return records
+}
+
+// Records yields records already in memory, such as the balance sheet or the
+// pending holds on screen.
+func Records(records [][]string) Source {
+	return &memory{records: records}
+}
+
+type memory struct {
+	records [][]string
+}
+
+func (m *memory) Next() ([][]string, bool, error) {
+	// Hand out at most a batch per step so progress still moves.
+	n := len(m.records)
+	if n > accountsdomain.MaxBatchSize {
+		n = accountsdomain.MaxBatchSize
+	}
+	page := m.records[:n]
+	m.records = m.records[n:]
+	return page, len(m.records) > 0, nil
+}
+
+// Select narrows every record of src to the fields at idx, as chosen by
+// domain.Layout.
+func Select(src Source, idx []int) Source {
+	return &selected{src: src, idx: idx}
+}
+
+type selected struct {
+	src Source
+	idx []int
+}
+
+func (s *selected) Next() ([][]string, bool, error) {
+	records, more, err := s.src.Next()
+	for i, r := range records {
+		records[i] = domain.Pick(r, s.idx)
+	}
+	return records, more, err
+}
+
+// ErrCanceled is returned by the step after Cancel.
+var ErrCanceled = errors.New("export canceled")
+
+// Job streams a Source into a Sink one page per Step, so a caller can report
+// progress and stay responsive between pages.
+type Job struct {
+	View string // what is exported, e.g. "transfers"
+	Path string
+
+	source   Source
+	open     func() (Sink, error)
+	sink     Sink
+	rows     int
+	done     bool
+	canceled atomic.Bool
+}
+
+// NewJob creates an export job. open creates the sink on the first Step, so
+// creating a job does no I/O.
+func NewJob(view, path string, source Source, open func() (Sink, error)) *Job {
+	return &Job{View: view, Path: path, source: source, open: open}
+}
+
+// Step writes the next page. It reports true once the file is complete; on
+// error the partial file is discarded.
+func (j *Job) Step() (bool, error) {
+	if j.done {
+		return true, nil
+	}
+	if j.canceled.Load() {
+		j.done = true
+		if j.sink != nil {
+			j.sink.Abort()
+		}
+		return true, ErrCanceled
+	}
+	if j.sink == nil {
+		sink, err := j.open()
+		if err != nil {
+			j.done = true
+			return true, err
+		}
+		j.sink = sink
+	}
+	records, more, err := j.source.Next()
+	if err == nil {
+		err = j.sink.Write(records)
+	}
+	if err != nil {
+		j.done = true
+		j.sink.Abort()
+		return true, err
+	}
+	j.rows += len(records)
+	if more {
+		return false, nil
+	}
+	j.done = true
+	return true, j.sink.Close()
+}
+
+// Run steps the job to completion.
+func (j *Job) Run() error {
+	for {
+		done, err := j.Step()
+		if done || err != nil {
+			return err
+		}
+	}
+}
+
+// Cancel asks the job to stop. It is safe to call while a Step runs on
+// another goroutine; the next Step discards the partial file and returns
+// ErrCanceled.
+func (j *Job) Cancel() {
+	j.canceled.Store(true)
+}
+
+// Canceled reports whether Cancel was called.
+func (j *Job) Canceled() bool {
+	return j.canceled.Load()
+}
+
+// Rows returns the number of records written so far.
+func (j *Job) Rows() int {
+	return j.rows
+}
@@ -0,0 +1,238 @@
+// Package domain provides export formats and the records written for each
+// dashboard view. Every value is text: uint128 fields are exact decimal
+// strings next to their formatted counterparts, so no consumer loses
+// precision to floating point.
+package domain
+
+import (
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	accounts "github.com/fd1az/tiger-tui/business/accounts/domain"
+	bsdomain "github.com/fd1az/tiger-tui/business/balancesheet/domain"
+	transfers "github.com/fd1az/tiger-tui/business/transfers/domain"
+)
+
+// Format is an export file format.
+type Format string
+
+const (
+	CSV    Format = "csv"
+	JSON   Format = "json"   // one array of objects
+	NDJSON Format = "ndjson" // one object per line
+)
+
+// Formats lists the export formats in picker order.
+var Formats = []Format{CSV, JSON, NDJSON}
+
+// FormatFromPath returns the format for a file extension (.csv, .json,
+// .ndjson or .jsonl).
+func FormatFromPath(path string) (Format, bool) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return CSV, true
+	case ".json":
+		return JSON, true
+	case ".ndjson", ".jsonl":
+		return NDJSON, true
+	}
+	return "", false
+}
+
+// FileName returns a default export file name for a view, e.g.
+// "transfers-20250102-150405.csv".
+func FileName(view string, f Format, now time.Time) string {
+	return fmt.Sprintf("%s-%s.%s", view, now.Format("20060102-150405"), f)
+}
+
+// AccountColumns are the fields of an exported account.
+var AccountColumns = []string{
+	"id", "ledger", "ledger_symbol", "code", "type",
+	"debits_pending", "debits_pending_formatted",
+	"debits_posted", "debits_posted_formatted",
+	"credits_pending", "credits_pending_formatted",
+	"credits_posted", "credits_posted_formatted",
+	"balance", "balance_formatted",
+	"user_data_128", "user_data_64", "user_data_32", "venue",
+	"flags", "flag_names", "timestamp", "time",
+}
+
+// AccountRecord returns an account's values in AccountColumns order.
+func AccountRecord(a types.Account) []string {
+	balance := accounts.NetBalance(a.Flags, a.DebitsPosted, a.CreditsPosted)
+	return []string{
+		accounts.FormatID(a.ID),
+		u32(a.Ledger), accounts.LedgerSymbol(a.Ledger),
+		u16(a.Code), accounts.AccountTypeName(a.Code),
+		accounts.FormatID(a.DebitsPending), accounts.FormatAmount(a.DebitsPending, a.Ledger),
+		accounts.FormatID(a.DebitsPosted), accounts.FormatAmount(a.DebitsPosted, a.Ledger),
+		accounts.FormatID(a.CreditsPending), accounts.FormatAmount(a.CreditsPending, a.Ledger),
+		accounts.FormatID(a.CreditsPosted), accounts.FormatAmount(a.CreditsPosted, a.Ledger),
+		balance.String(), signedAmount(balance, a.Ledger),
+		accounts.FormatID(a.UserData128), strconv.FormatUint(a.UserData64, 10),
+		u32(a.UserData32), accounts.VenueName(a.UserData32),
+		u16(a.Flags), strings.Join(accounts.AccountFlagNames(a.Flags), "|"),
+		strconv.FormatUint(a.Timestamp, 10), timestamp(a.Timestamp),
+	}
+}
+
+// TransferColumns are the fields of an exported transfer.
+var TransferColumns = []string{
+	"id", "debit_account_id", "credit_account_id",
+	"amount", "amount_formatted", "ledger", "ledger_symbol", "code", "type",
+	"pending_id", "timeout",
+	"user_data_128", "user_data_64", "user_data_32", "venue",
+	"flags", "flag_names", "timestamp", "time",
+}
+
+// TransferRecord returns a transfer's values in TransferColumns order.
+func TransferRecord(t types.Transfer) []string {
+	return []string{
+		accounts.FormatID(t.ID),
+		accounts.FormatID(t.DebitAccountID), accounts.FormatID(t.CreditAccountID),
+		accounts.FormatID(t.Amount), accounts.FormatAmount(t.Amount, t.Ledger),
+		u32(t.Ledger), accounts.LedgerSymbol(t.Ledger),
+		u16(t.Code), accounts.TransferTypeName(t.Code),
+		accounts.FormatID(t.PendingID), u32(t.Timeout),
+		accounts.FormatID(t.UserData128), strconv.FormatUint(t.UserData64, 10),
+		u32(t.UserData32), accounts.VenueName(t.UserData32),
+		u16(t.Flags), strings.Join(transfers.TransferFlagNames(t.Flags), "|"),
+		strconv.FormatUint(t.Timestamp, 10), timestamp(t.Timestamp),
+	}
+}
+
+// HoldColumns are the fields of an exported pending transfer: the transfer's
+// fields followed by its expiry.
+var HoldColumns = append(append([]string{}, TransferColumns...), "expires_at", "remaining_seconds")
+
+// HoldRecord returns a hold's values in HoldColumns order. Holds without a
+// timeout have empty expiry fields.
+func HoldRecord(h transfers.Hold, now time.Time) []string {
+	expires, remaining := "", ""
+	if h.HasTimeout() {
+		expires = h.ExpiresAt().UTC().Format(time.RFC3339Nano)
+		remaining = strconv.FormatInt(int64(h.Remaining(now).Seconds()), 10)
+	}
+	return append(TransferRecord(h.Transfer), expires, remaining)
+}
+
+// columnFields maps a dashboard table column to the export fields it shows.
+// Columns not listed export the field of the same name; columns with no
+// field, such as the transfer status marker or a hold's age, are skipped.
+var columnFields = map[string][]string{
+	"ledger":          {"ledger", "ledger_symbol"},
+	"code":            {"code", "type"},
+	"debit_account":   {"debit_account_id"},
+	"credit_account":  {"credit_account_id"},
+	"amount":          {"amount", "amount_formatted"},
+	"debits_pending":  {"debits_pending", "debits_pending_formatted"},
+	"debits_posted":   {"debits_posted", "debits_posted_formatted"},
+	"credits_pending": {"credits_pending", "credits_pending_formatted"},
+	"credits_posted":  {"credits_posted", "credits_posted_formatted"},
+	"balance":         {"balance", "balance_formatted"},
+	"venue":           {"user_data_32", "venue"},
+	"flags":           {"flags", "flag_names"},
+	"timestamp":       {"timestamp", "time"},
+	"expires":         {"expires_at", "remaining_seconds"},
+}
+
+// Layout picks the export fields of the table columns shown, in the table's
+// column order. It returns the chosen field names and their indices in
+// columns; when none of the shown columns has a field, every field is kept.
+func Layout(columns, shown []string) ([]string, []int) {
+	var names []string
+	var idx []int
+	for _, key := range shown {
+		fields, ok := columnFields[key]
+		if !ok {
+			fields = []string{key}
+		}
+		for _, f := range fields {
+			if i := slices.Index(columns, f); i >= 0 && !slices.Contains(idx, i) {
+				names = append(names, f)
+				idx = append(idx, i)
+			}
+		}
+	}
+	if len(idx) == 0 {
+		idx = make([]int, len(columns))
+		for i := range idx {
+			idx[i] = i
+		}
+		return columns, idx
+	}
+	return names, idx
+}
+
+// Pick returns the values of record at idx, in that order.
+func Pick(record []string, idx []int) []string {
+	out := make([]string, len(idx))
+	for j, i := range idx {
+		out[j] = record[i]
+	}
+	return out
+}
+
+// BalanceSheetColumns are the fields of an exported balance sheet row: one
+// row per account type and a TOTAL row per ledger.
+var BalanceSheetColumns = []string{
+	"ledger", "ledger_symbol", "code", "type", "accounts",
+	"debits_posted", "debits_posted_formatted",
+	"credits_posted", "credits_posted_formatted",
+	"debits_pending", "debits_pending_formatted",
+	"credits_pending", "credits_pending_formatted",
+	"verdict", "posted_discrepancy", "pending_discrepancy",
+}
+
+// BalanceSheetRecords returns a summary's rows in BalanceSheetColumns order.
+func BalanceSheetRecords(s bsdomain.Summary) [][]string {
+	var rows [][]string
+	for _, l := range s.Ledgers {
+		for _, tt := range l.Types {
+			rows = append(rows, append(
+				totalsRecord(l.Ledger, u16(tt.Code), accounts.AccountTypeName(tt.Code), tt.Accounts, tt.Totals),
+				"", "", ""))
+		}
+		rows = append(rows, append(
+			totalsRecord(l.Ledger, "", "TOTAL", l.Accounts, l.Total),
+			l.Verdict.String(), l.PostedDiscrepancy.String(), l.PendingDiscrepancy.String()))
+	}
+	return rows
+}
+
+func totalsRecord(ledger uint32, code, name string, n int, t bsdomain.Totals) []string {
+	return []string{
+		u32(ledger), accounts.LedgerSymbol(ledger), code, name, strconv.Itoa(n),
+		t.DebitsPosted.String(), accounts.FormatBigAmount(t.DebitsPosted, ledger),
+		t.CreditsPosted.String(), accounts.FormatBigAmount(t.CreditsPosted, ledger),
+		t.DebitsPending.String(), accounts.FormatBigAmount(t.DebitsPending, ledger),
+		t.CreditsPending.String(), accounts.FormatBigAmount(t.CreditsPending, ledger),
+	}
+}
+
+// signedAmount formats a possibly negative minor-unit value.
+func signedAmount(n *big.Int, ledger uint32) string {
+	if n.Sign() < 0 {
+		return "-" + accounts.FormatBigAmount(new(big.Int).Neg(n), ledger)
+	}
+	return accounts.FormatBigAmount(n, ledger)
+}
+
+// timestamp renders a TigerBeetle timestamp as RFC 3339 in UTC, or "" for
+// zero.
+func timestamp(ts uint64) string {
+	if ts == 0 {
+		return ""
+	}
+	return time.Unix(0, int64(ts)).UTC().Format(time.RFC3339Nano)
+}
+
+func u16(v uint16) string { return strconv.FormatUint(uint64(v), 10) }
+func u32(v uint32) string { return strconv.FormatUint(uint64(v), 10) }
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLayout(t *testing.T) {
+	tests := []struct {
+		columns []string
+		shown   []string
+		want    []string
+	}{
+		{TransferColumns, []string{"amount", "id", "ledger"},
+			[]string{"amount", "amount_formatted", "id", "ledger", "ledger_symbol"}},
+		{TransferColumns, []string{"status", "debit_account", "venue"},
+			[]string{"debit_account_id", "user_data_32", "venue"}},
+		{AccountColumns, []string{"balance", "user_data_128"},
+			[]string{"balance", "balance_formatted", "user_data_128"}},
+		{HoldColumns, []string{"age", "expires", "timestamp"},
+			[]string{"expires_at", "remaining_seconds", "timestamp", "time"}},
+		{TransferColumns, []string{"status"}, TransferColumns},
+	}
+	for _, tt := range tests {
+		names, idx := Layout(tt.columns, tt.shown)
+		if !slices.Equal(names, tt.want) {
+			t.Errorf("Layout(%v) = %v, want %v", tt.shown, names, tt.want)
+		}
+		if got := Pick(tt.columns, idx); !slices.Equal(got, names) {
+			t.Errorf("Pick(columns, %v) = %v, want %v", idx, got, names)
+		}
+	}
+}
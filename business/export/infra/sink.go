@@ -0,0 +1,177 @@
+// Package infra provides the sinks exports are streamed into: a file, or any
+// writer such as stdout.
+package infra
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/fd1az/tiger-tui/business/export/domain"
+	"github.com/fd1az/tiger-tui/internal/apperror"
+)
+
+// StreamSink encodes records as CSV, JSON or NDJSON onto a writer.
+type StreamSink struct {
+	name   string // for errors
+	format domain.Format
+	keys   [][]byte // JSON-encoded column names, with the colon
+	w      *bufio.Writer
+	csv    *csv.Writer
+	rows   int
+}
+
+// NewStreamSink writes the CSV header or opening bracket to w. name
+// identifies the destination in errors.
+func NewStreamSink(w io.Writer, name string, format domain.Format, columns []string) (*StreamSink, error) {
+	s := &StreamSink{name: name, format: format, w: bufio.NewWriterSize(w, 256*1024)}
+	for _, c := range columns {
+		k, _ := json.Marshal(c)
+		s.keys = append(s.keys, append(k, ':'))
+	}
+
+	var err error
+	switch format {
+	case domain.CSV:
+		s.csv = csv.NewWriter(s.w)
+		err = s.csv.Write(columns)
+	case domain.JSON:
+		_, err = s.w.WriteString("[")
+	case domain.NDJSON:
+	default:
+		err = fmt.Errorf("unsupported export format %q", format)
+	}
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.CodeExportWriteFailed, name)
+	}
+	return s, nil
+}
+
+// Write appends records, each with one value per column, and flushes them.
+func (s *StreamSink) Write(records [][]string) error {
+	for _, r := range records {
+		var err error
+		switch s.format {
+		case domain.CSV:
+			err = s.csv.Write(r)
+		case domain.JSON:
+			if s.rows > 0 {
+				s.w.WriteByte(',')
+			}
+			s.w.WriteString("\n  ")
+			err = s.writeObject(r)
+		case domain.NDJSON:
+			if err = s.writeObject(r); err == nil {
+				err = s.w.WriteByte('\n')
+			}
+		}
+		if err != nil {
+			return apperror.Wrap(err, apperror.CodeExportWriteFailed, s.name)
+		}
+		s.rows++
+	}
+	if s.csv != nil {
+		s.csv.Flush()
+		if err := s.csv.Error(); err != nil {
+			return apperror.Wrap(err, apperror.CodeExportWriteFailed, s.name)
+		}
+	}
+	if err := s.w.Flush(); err != nil {
+		return apperror.Wrap(err, apperror.CodeExportWriteFailed, s.name)
+	}
+	return nil
+}
+
+// writeObject writes one record as a JSON object with keys in column order.
+func (s *StreamSink) writeObject(r []string) error {
+	s.w.WriteByte('{')
+	for i, v := range r {
+		if i > 0 {
+			s.w.WriteByte(',')
+		}
+		s.w.Write(s.keys[i])
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		s.w.Write(b)
+	}
+	return s.w.WriteByte('}')
+}
+
+// Close writes the closing bracket of a JSON array and flushes.
+func (s *StreamSink) Close() error {
+	if s.format == domain.JSON {
+		if s.rows > 0 {
+			s.w.WriteByte('\n')
+		}
+		s.w.WriteString("]\n")
+	}
+	if err := s.w.Flush(); err != nil {
+		return apperror.Wrap(err, apperror.CodeExportWriteFailed, s.name)
+	}
+	return nil
+}
+
+// Abort stops without completing the output. What was already flushed to
+// the writer stays there.
+func (s *StreamSink) Abort() error {
+	return nil
+}
+
+// FileSink streams records into a CSV, JSON or NDJSON file. Records are
+// written to a temporary file next to the target, which replaces the target
+// only on Close, so an interrupted export never looks complete.
+type FileSink struct {
+	*StreamSink
+	path string
+	tmp  *os.File
+}
+
+// NewFileSink creates the temporary file for an export to path in format
+// and writes the CSV header or opening bracket.
+func NewFileSink(path string, format domain.Format, columns []string) (*FileSink, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.CodeExportWriteFailed, path)
+	}
+	stream, err := NewStreamSink(tmp, path, format, columns)
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return nil, err
+	}
+	return &FileSink{StreamSink: stream, path: path, tmp: tmp}, nil
+}
+
+// Close finishes the file and moves it into place.
+func (s *FileSink) Close() error {
+	err := s.StreamSink.Close()
+	if err == nil {
+		err = s.tmp.Sync()
+	}
+	if cerr := s.tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(s.tmp.Name(), s.path)
+	}
+	if err != nil {
+		os.Remove(s.tmp.Name())
+		return apperror.Wrap(err, apperror.CodeExportWriteFailed, s.path)
+	}
+	return nil
+}
+
+// Abort discards the partial file.
+func (s *FileSink) Abort() error {
+	s.tmp.Close()
+	if err := os.Remove(s.tmp.Name()); err != nil && !os.IsNotExist(err) {
+		return apperror.Wrap(err, apperror.CodeExportWriteFailed, s.path)
+	}
+	return nil
+}
@@ -0,0 +1,354 @@
+// Package domain parses filter chips such as "ledger=BTC" or "amount>=10"
+// and splits them between the TigerBeetle query and client-side matching.
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	accounts "github.com/fd1az/tiger-tui/business/accounts/domain"
+	transfers "github.com/fd1az/tiger-tui/business/transfers/domain"
+)
+
+// Keys lists the chip keys in the order the filter builder suggests them.
+// "type" is accepted as an alias of "code".
+var Keys = []string{
+	"ledger", "code", "venue", "since", "until",
+	"user_data_128", "user_data_64", "user_data_32", "amount", "flags",
+}
+
+// Chip is one filter condition, e.g. ledger=BTC or amount>=1,000.
+type Chip struct {
+	Key   string
+	Op    string // "=", "<", "<=", ">" or ">="
+	Value string
+}
+
+// String renders the chip as typed.
+func (c Chip) String() string {
+	return c.Key + c.Op + c.Value
+}
+
+// ParseChip parses "key<op>value". Only amount takes an operator other
+// than "=".
+func ParseChip(s string) (Chip, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexAny(s, "<>=")
+	if i <= 0 {
+		return Chip{}, fmt.Errorf("%q: want key=value, e.g. ledger=USD", s)
+	}
+	c := Chip{Key: strings.ToLower(strings.TrimSpace(s[:i])), Op: s[i : i+1]}
+	if c.Op != "=" && i+1 < len(s) && s[i+1] == '=' {
+		c.Op += "="
+	}
+	c.Value = strings.TrimSpace(s[i+len(c.Op):])
+	if c.Key == "type" {
+		c.Key = "code"
+	}
+
+	known := false
+	for _, k := range Keys {
+		known = known || k == c.Key
+	}
+	switch {
+	case !known:
+		return Chip{}, fmt.Errorf("unknown filter %q, want one of %s", c.Key, strings.Join(Keys, ", "))
+	case c.Value == "":
+		return Chip{}, fmt.Errorf("%s: missing value", c.Key)
+	case c.Op != "=" && c.Key != "amount":
+		return Chip{}, fmt.Errorf("%s: only amount compares with %s", c.Key, c.Op)
+	}
+	return c, nil
+}
+
+// Target is the query a set of chips is compiled for.
+type Target int
+
+const (
+	// Accounts is QueryAccounts.
+	Accounts Target = iota
+	// Transfers is QueryTransfers over the whole cluster.
+	Transfers
+	// AccountTransfers is GetAccountTransfers, whose filter has no ledger.
+	AccountTransfers
+)
+
+// event is what client-side chips look at.
+type event struct {
+	ledger      uint32
+	flags       uint16
+	amount      types.Uint128
+	userData128 types.Uint128
+	userData64  uint64
+	userData32  uint32
+}
+
+// Plan is a set of chips compiled for one target: the fields pushed down to
+// TigerBeetle and the conditions checked on each returned event.
+type Plan struct {
+	// Server reports, per chip, whether TigerBeetle evaluates it.
+	Server []bool
+
+	ledger       uint32
+	code         uint16
+	userData128  types.Uint128
+	userData64   uint64
+	userData32   uint32
+	timestampMin uint64
+	timestampMax uint64
+	match        []func(event) bool
+}
+
+// Compile validates chips against the active chart and splits them between
+// the query and client-side matching. now anchors relative times such as
+// since=2h. A server-side field may be filtered only once.
+func Compile(chips []Chip, target Target, now time.Time) (Plan, error) {
+	p := Plan{Server: make([]bool, len(chips))}
+	set := map[string]string{} // server field -> chip that set it
+
+	for i, c := range chips {
+		server, field, err := p.add(c, target, now)
+		if err != nil {
+			return Plan{}, fmt.Errorf("%s: %w", c, err)
+		}
+		if server {
+			if prev, ok := set[field]; ok {
+				return Plan{}, fmt.Errorf("%s: %s is already filtered by %s", c, field, prev)
+			}
+			set[field] = c.String()
+		}
+		p.Server[i] = server
+	}
+	if p.timestampMax != 0 && p.timestampMin > p.timestampMax {
+		return Plan{}, fmt.Errorf("since is after until")
+	}
+	return p, nil
+}
+
+// add applies one chip, returning whether it is evaluated server-side and
+// the query field it sets. TigerBeetle reads a zero filter field as no
+// filter, so chips asking for a zero user_data value (venue=Internal is
+// user_data_32=0) are matched client-side.
+func (p *Plan) add(c Chip, target Target, now time.Time) (bool, string, error) {
+	switch c.Key {
+	case "ledger":
+		ledger, ok := accounts.LedgerBySymbol(c.Value)
+		if !ok {
+			return false, "", fmt.Errorf("%q is not a ledger in the %s chart", c.Value, accounts.ActiveChart().Name)
+		}
+		if target == AccountTransfers {
+			p.match = append(p.match, func(e event) bool { return e.ledger == ledger })
+			return false, "", nil
+		}
+		p.ledger = ledger
+		return true, "ledger", nil
+
+	case "code":
+		code, ok := accounts.AccountCodeByName(c.Value)
+		if target != Accounts {
+			code, ok = accounts.TransferCodeByName(c.Value)
+		}
+		if !ok {
+			return false, "", fmt.Errorf("%q is not a type in the %s chart", c.Value, accounts.ActiveChart().Name)
+		}
+		p.code = code
+		return true, "code", nil
+
+	case "venue":
+		venue, ok := accounts.VenueByName(c.Value)
+		if !ok {
+			return false, "", fmt.Errorf("%q is not a venue in the %s chart", c.Value, accounts.ActiveChart().Name)
+		}
+		if venue == 0 {
+			p.match = append(p.match, func(e event) bool { return e.userData32 == 0 })
+			return false, "", nil
+		}
+		p.userData32 = venue
+		return true, "user_data_32", nil
+
+	case "user_data_128":
+		v, err := accounts.ParseID(c.Value)
+		if err != nil {
+			return false, "", err
+		}
+		if v == (types.Uint128{}) {
+			p.match = append(p.match, func(e event) bool { return e.userData128 == types.Uint128{} })
+			return false, "", nil
+		}
+		p.userData128 = v
+		return true, c.Key, nil
+
+	case "user_data_64":
+		v, err := strconv.ParseUint(c.Value, 10, 64)
+		if err != nil {
+			return false, "", fmt.Errorf("%q is not a 64-bit number", c.Value)
+		}
+		if v == 0 {
+			p.match = append(p.match, func(e event) bool { return e.userData64 == 0 })
+			return false, "", nil
+		}
+		p.userData64 = v
+		return true, c.Key, nil
+
+	case "user_data_32":
+		v, err := strconv.ParseUint(c.Value, 10, 32)
+		if err != nil {
+			return false, "", fmt.Errorf("%q is not a 32-bit number", c.Value)
+		}
+		if v == 0 {
+			p.match = append(p.match, func(e event) bool { return e.userData32 == 0 })
+			return false, "", nil
+		}
+		p.userData32 = uint32(v)
+		return true, c.Key, nil
+
+	case "since", "until":
+		t, err := ParseTime(c.Value, now)
+		if err != nil {
+			return false, "", err
+		}
+		if c.Key == "since" {
+			p.timestampMin = uint64(t.UnixNano())
+		} else {
+			p.timestampMax = uint64(t.UnixNano())
+		}
+		return true, c.Key, nil
+
+	case "amount":
+		if target == Accounts {
+			return false, "", fmt.Errorf("amount applies to transfers")
+		}
+		m, err := amountMatcher(c)
+		if err != nil {
+			return false, "", err
+		}
+		p.match = append(p.match, m)
+		return false, "", nil
+
+	case "flags":
+		var mask uint16
+		if target == Accounts {
+			f, err := accounts.ParseAccountFlags(c.Value)
+			if err != nil {
+				return false, "", err
+			}
+			mask = f.ToUint16()
+		} else {
+			f, err := transfers.ParseTransferFlags(c.Value)
+			if err != nil {
+				return false, "", err
+			}
+			mask = f.ToUint16()
+		}
+		p.match = append(p.match, func(e event) bool { return e.flags&mask == mask })
+		return false, "", nil
+	}
+	return false, "", fmt.Errorf("unknown filter")
+}
+
+// amountMatcher compares transfer amounts in ledger units. Ledgers the
+// value cannot be expressed in (too many decimals, another symbol) never
+// match.
+func amountMatcher(c Chip) (func(event) bool, error) {
+	valid := false
+	for _, ledger := range accounts.LedgerIDs() {
+		if _, err := accounts.ParseMoney(c.Value, ledger); err == nil {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return nil, fmt.Errorf("%q is not an amount in any ledger", c.Value)
+	}
+
+	return func(e event) bool {
+		limit, err := accounts.ParseMoney(c.Value, e.ledger)
+		if err != nil {
+			return false
+		}
+		cmp := accounts.NewMoney(e.amount, e.ledger).Cmp(limit)
+		switch c.Op {
+		case "<":
+			return cmp < 0
+		case "<=":
+			return cmp <= 0
+		case ">":
+			return cmp > 0
+		case ">=":
+			return cmp >= 0
+		}
+		return cmp == 0
+	}, nil
+}
+
+// ParseTime parses a point in time: a duration before now ("90m", "2h",
+// "7d"), a date ("2006-01-02", local time) or an RFC 3339 timestamp.
+func ParseTime(s string, now time.Time) (time.Time, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("%q is not a duration (2h, 7d), date or RFC 3339 time", s)
+}
+
+// Accounts sets the plan's server-side fields and client-side match on q.
+func (p Plan) Accounts(q accounts.AccountQuery) accounts.AccountQuery {
+	q.Ledger, q.Code = p.ledger, p.code
+	q.UserData128, q.UserData64, q.UserData32 = p.userData128, p.userData64, p.userData32
+	q.TimestampMin, q.TimestampMax = p.timestampMin, p.timestampMax
+	if len(p.match) > 0 {
+		q.Match = func(a types.Account) bool {
+			return p.matches(event{
+				ledger:      a.Ledger,
+				flags:       a.Flags,
+				userData128: a.UserData128,
+				userData64:  a.UserData64,
+				userData32:  a.UserData32,
+			})
+		}
+	}
+	return q
+}
+
+// Transfers sets the plan's server-side fields and client-side match on q.
+func (p Plan) Transfers(q transfers.TransferQuery) transfers.TransferQuery {
+	q.Ledger, q.Code = p.ledger, p.code
+	q.UserData128, q.UserData64, q.UserData32 = p.userData128, p.userData64, p.userData32
+	q.TimestampMin, q.TimestampMax = p.timestampMin, p.timestampMax
+	if len(p.match) > 0 {
+		q.Match = func(t types.Transfer) bool {
+			return p.matches(event{
+				ledger:      t.Ledger,
+				flags:       t.Flags,
+				amount:      t.Amount,
+				userData128: t.UserData128,
+				userData64:  t.UserData64,
+				userData32:  t.UserData32,
+			})
+		}
+	}
+	return q
+}
+
+func (p Plan) matches(e event) bool {
+	for _, m := range p.match {
+		if !m(e) {
+			return false
+		}
+	}
+	return true
+}
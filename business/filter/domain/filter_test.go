@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	accounts "github.com/fd1az/tiger-tui/business/accounts/domain"
+	transfers "github.com/fd1az/tiger-tui/business/transfers/domain"
+)
+
+func TestCompileUserData(t *testing.T) {
+	tests := []struct {
+		chip   string
+		server bool
+		match  types.Transfer // a transfer the chip accepts
+		reject types.Transfer // and one it does not
+	}{
+		{"venue=Binance", true, types.Transfer{UserData32: 1}, types.Transfer{UserData32: 2}},
+		{"venue=Internal", false, types.Transfer{UserData32: 0}, types.Transfer{UserData32: 1}},
+		{"user_data_32=7", true, types.Transfer{UserData32: 7}, types.Transfer{UserData32: 8}},
+		{"user_data_32=0", false, types.Transfer{UserData32: 0}, types.Transfer{UserData32: 7}},
+		{"user_data_64=7", true, types.Transfer{UserData64: 7}, types.Transfer{UserData64: 8}},
+		{"user_data_64=0", false, types.Transfer{UserData64: 0}, types.Transfer{UserData64: 7}},
+		{"user_data_128=0x7", true, types.Transfer{UserData128: types.ToUint128(7)}, types.Transfer{UserData128: types.ToUint128(8)}},
+		{"user_data_128=0", false, types.Transfer{}, types.Transfer{UserData128: types.ToUint128(7)}},
+	}
+	for _, tt := range tests {
+		c, err := ParseChip(tt.chip)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, target := range []Target{Transfers, AccountTransfers} {
+			p, err := Compile([]Chip{c}, target, time.Now())
+			if err != nil {
+				t.Fatalf("Compile(%s): %v", c, err)
+			}
+			if p.Server[0] != tt.server {
+				t.Errorf("Compile(%s).Server = %v, want %v", c, p.Server[0], tt.server)
+			}
+
+			q := p.Transfers(transfers.TransferQuery{})
+			if tt.server {
+				// The filter field carries the value and TigerBeetle applies it.
+				if q.Match != nil || (q.UserData32 == 0 && q.UserData64 == 0 && q.UserData128 == types.Uint128{}) {
+					t.Errorf("%s: query %+v, want a nonzero filter field and no Match", c, q)
+				}
+				continue
+			}
+			// A zero filter field would match every transfer, so the chip
+			// must be checked on each loaded transfer instead.
+			if q.UserData32 != 0 || q.UserData64 != 0 || q.UserData128 != (types.Uint128{}) || q.Match == nil {
+				t.Fatalf("%s: query %+v, want no filter field and a Match", c, q)
+			}
+			if !q.Match(tt.match) || q.Match(tt.reject) {
+				t.Errorf("%s: Match(%+v) = %v, Match(%+v) = %v, want true and false",
+					c, tt.match, q.Match(tt.match), tt.reject, q.Match(tt.reject))
+			}
+		}
+	}
+}
+
+func TestCompileZeroVenueOnAccounts(t *testing.T) {
+	p, err := Compile([]Chip{{Key: "venue", Op: "=", Value: "internal"}}, Accounts, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := p.Accounts(accounts.AccountQuery{})
+	if p.Server[0] || q.UserData32 != 0 || q.Match == nil {
+		t.Fatalf("venue=internal compiled to %+v, want a client-side match", q)
+	}
+	if !q.Match(types.Account{UserData32: 0}) || q.Match(types.Account{UserData32: 1}) {
+		t.Error("venue=internal does not match exactly the venue 0 accounts")
+	}
+}
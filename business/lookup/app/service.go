@@ -0,0 +1,71 @@
+// Package app provides the lookup-by-ID application service.
+package app
+
+import (
+	"errors"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	accountsdomain "github.com/fd1az/tiger-tui/business/accounts/domain"
+	"github.com/fd1az/tiger-tui/business/lookup/domain"
+	"github.com/fd1az/tiger-tui/internal/apperror"
+)
+
+// AccountSource looks up accounts; the accounts app.Service satisfies it.
+type AccountSource interface {
+	Lookup(id types.Uint128) (types.Account, bool, error)
+}
+
+// TransferSource looks up transfers; the transfers app.Service satisfies it.
+type TransferSource interface {
+	Lookup(id types.Uint128) (types.Transfer, bool, error)
+}
+
+// Service finds an ID among both accounts and transfers.
+type Service struct {
+	accounts  AccountSource
+	transfers TransferSource
+}
+
+// NewService creates a new lookup service.
+func NewService(accounts AccountSource, transfers TransferSource) *Service {
+	return &Service{accounts: accounts, transfers: transfers}
+}
+
+// Lookup runs LookupAccounts and LookupTransfers in parallel. When neither
+// matches, the error carries both CodeAccountNotFound and
+// CodeTransferNotFound.
+func (s *Service) Lookup(id types.Uint128) (domain.Result, error) {
+	type transferLookup struct {
+		transfer types.Transfer
+		found    bool
+		err      error
+	}
+	ch := make(chan transferLookup, 1)
+	go func() {
+		t, found, err := s.transfers.Lookup(id)
+		ch <- transferLookup{t, found, err}
+	}()
+
+	acc, accFound, accErr := s.accounts.Lookup(id)
+	tr := <-ch
+	if err := errors.Join(accErr, tr.err); err != nil {
+		return domain.Result{}, err
+	}
+
+	res := domain.Result{ID: id}
+	if accFound {
+		res.Account = &acc
+	}
+	if tr.found {
+		res.Transfer = &tr.transfer
+	}
+	if !res.Found() {
+		context := "id " + accountsdomain.FormatID(id)
+		return res, errors.Join(
+			apperror.New(apperror.CodeAccountNotFound, apperror.WithContext(context)),
+			apperror.New(apperror.CodeTransferNotFound, apperror.WithContext(context)),
+		)
+	}
+	return res, nil
+}
@@ -0,0 +1,17 @@
+// Package domain holds lookup-by-ID results.
+package domain
+
+import "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+// Result is what a lookup found for one ID. Accounts and transfers have
+// separate ID spaces, so both may be set.
+type Result struct {
+	ID       types.Uint128
+	Account  *types.Account
+	Transfer *types.Transfer
+}
+
+// Found reports whether anything matched.
+func (r Result) Found() bool {
+	return r.Account != nil || r.Transfer != nil
+}
@@ -0,0 +1,199 @@
+// Package app provides the write outbox application service.
+package app
+
+import (
+	"sync"
+	"time"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	accountsapp "github.com/fd1az/tiger-tui/business/accounts/app"
+	"github.com/fd1az/tiger-tui/business/outbox/domain"
+	transfersapp "github.com/fd1az/tiger-tui/business/transfers/app"
+)
+
+// Store is the port entries are journaled through.
+type Store interface {
+	Save(e domain.Entry) error
+	Remove(e domain.Entry) error
+	List(cluster string) ([]domain.Entry, error)
+}
+
+// AccountWriter sends CreateAccounts batches.
+type AccountWriter interface {
+	CreateAccounts(accounts []types.Account) ([]types.AccountEventResult, error)
+}
+
+// TransferWriter sends CreateTransfers batches.
+type TransferWriter interface {
+	CreateTransfers(transfers []types.Transfer) ([]types.TransferEventResult, error)
+}
+
+// Service journals every create batch before sending it and removes the
+// entry once TigerBeetle has answered. Entries left behind by a failed
+// request or a crash are resubmitted with the same IDs; TigerBeetle answers
+// `exists` for events that had already landed.
+type Service struct {
+	store     Store
+	cluster   string
+	accounts  AccountWriter
+	transfers TransferWriter
+
+	mu      sync.Mutex
+	pending map[string]bool // IDs of journaled entries without a reply
+}
+
+// NewService creates an outbox for one cluster and counts its pending
+// entries.
+func NewService(store Store, cluster string, accounts AccountWriter, transfers TransferWriter) (*Service, error) {
+	entries, err := store.List(cluster)
+	if err != nil {
+		return nil, err
+	}
+	pending := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		pending[e.ID] = true
+	}
+	return &Service{
+		store:     store,
+		cluster:   cluster,
+		accounts:  accounts,
+		transfers: transfers,
+		pending:   pending,
+	}, nil
+}
+
+// Pending returns the number of journaled batches without a reply yet.
+func (s *Service) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.pending)
+}
+
+// CreateAccounts journals and sends a CreateAccounts batch.
+func (s *Service) CreateAccounts(accounts []types.Account) ([]types.AccountEventResult, error) {
+	e := domain.NewAccountsEntry(s.cluster, accounts, time.Now())
+	if err := s.journal(e); err != nil {
+		return nil, err
+	}
+	results, err := s.accounts.CreateAccounts(accounts)
+	if err != nil {
+		return nil, err
+	}
+	// A failed removal only leaves the entry for a harmless resubmission.
+	s.settle(e)
+	return results, nil
+}
+
+// CreateTransfers journals and sends a CreateTransfers batch.
+func (s *Service) CreateTransfers(transfers []types.Transfer) ([]types.TransferEventResult, error) {
+	e := domain.NewTransfersEntry(s.cluster, transfers, time.Now())
+	if err := s.journal(e); err != nil {
+		return nil, err
+	}
+	results, err := s.transfers.CreateTransfers(transfers)
+	if err != nil {
+		return nil, err
+	}
+	// A failed removal only leaves the entry for a harmless resubmission.
+	s.settle(e)
+	return results, nil
+}
+
+// Resubmit sends every pending entry again, oldest first, counting `exists`
+// results as success. It stops at the first request error, leaving the rest
+// pending.
+func (s *Service) Resubmit() (domain.Report, error) {
+	var report domain.Report
+	entries, err := s.store.List(s.cluster)
+	if err != nil {
+		return report, err
+	}
+
+	for _, e := range entries {
+		switch e.Kind {
+		case domain.KindAccounts:
+			results, err := s.accounts.CreateAccounts(e.Accounts)
+			if err != nil {
+				return report, err
+			}
+			for _, r := range results {
+				if r.Result == types.AccountExists {
+					report.Existed++
+				} else {
+					report.Failed++
+				}
+			}
+			report.Created += len(e.Accounts) - len(results)
+		case domain.KindTransfers:
+			results, err := s.transfers.CreateTransfers(e.Transfers)
+			if err != nil {
+				return report, err
+			}
+			for _, r := range results {
+				if r.Result == types.TransferExists {
+					report.Existed++
+				} else {
+					report.Failed++
+				}
+			}
+			report.Created += len(e.Transfers) - len(results)
+		}
+		report.Entries++
+		if err := s.settle(e); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// JournalAccounts returns repo with CreateAccounts routed through the outbox.
+func (s *Service) JournalAccounts(repo accountsapp.Repository) accountsapp.Repository {
+	return journaledAccounts{Repository: repo, outbox: s}
+}
+
+// JournalTransfers returns repo with CreateTransfers routed through the
+// outbox.
+func (s *Service) JournalTransfers(repo transfersapp.Repository) transfersapp.Repository {
+	return journaledTransfers{Repository: repo, outbox: s}
+}
+
+// journal saves the entry. Journaling a batch again, as a retry does,
+// replaces its entry rather than adding one.
+func (s *Service) journal(e domain.Entry) error {
+	if err := s.store.Save(e); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.pending[e.ID] = true
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *Service) settle(e domain.Entry) error {
+	if err := s.store.Remove(e); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	delete(s.pending, e.ID)
+	s.mu.Unlock()
+	return nil
+}
+
+type journaledAccounts struct {
+	accountsapp.Repository
+	outbox *Service
+}
+
+func (r journaledAccounts) CreateAccounts(accounts []types.Account) ([]types.AccountEventResult, error) {
+	return r.outbox.CreateAccounts(accounts)
+}
+
+type journaledTransfers struct {
+	transfersapp.Repository
+	outbox *Service
+}
+
+func (r journaledTransfers) CreateTransfers(transfers []types.Transfer) ([]types.TransferEventResult, error) {
+	return r.outbox.CreateTransfers(transfers)
+}
@@ -0,0 +1,155 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	"github.com/fd1az/tiger-tui/business/outbox/infra"
+)
+
+// fakeWriter answers create requests with err, or with `exists` for the
+// events it has already seen.
+type fakeWriter struct {
+	err      error
+	accounts map[types.Uint128]bool
+	requests int
+	// onRequest, when set, runs before each request is answered.
+	onRequest func()
+}
+
+func newFakeWriter() *fakeWriter {
+	return &fakeWriter{accounts: map[types.Uint128]bool{}}
+}
+
+func (w *fakeWriter) CreateAccounts(accounts []types.Account) ([]types.AccountEventResult, error) {
+	w.requests++
+	if w.onRequest != nil {
+		w.onRequest()
+	}
+	if w.err != nil {
+		return nil, w.err
+	}
+	var results []types.AccountEventResult
+	for i, a := range accounts {
+		if w.accounts[a.ID] {
+			results = append(results, types.AccountEventResult{Index: uint32(i), Result: types.AccountExists})
+		}
+		w.accounts[a.ID] = true
+	}
+	return results, nil
+}
+
+func (w *fakeWriter) CreateTransfers(transfers []types.Transfer) ([]types.TransferEventResult, error) {
+	w.requests++
+	if w.err != nil {
+		return nil, w.err
+	}
+	return nil, nil
+}
+
+func accounts(ids ...uint64) []types.Account {
+	var as []types.Account
+	for _, id := range ids {
+		as = append(as, types.Account{ID: types.ToUint128(id), Ledger: 1, Code: 1})
+	}
+	return as
+}
+
+func TestServiceJournalsBeforeSending(t *testing.T) {
+	store := infra.NewFileStore(t.TempDir())
+	w := newFakeWriter()
+	s, err := NewService(store, "1", w, w)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w.onRequest = func() {
+		if entries, _ := store.List("1"); len(entries) != 1 || s.Pending() != 1 {
+			t.Errorf("in flight: %d entries on disk, %d pending, want 1 and 1", len(entries), s.Pending())
+		}
+	}
+	if _, err := s.CreateAccounts(accounts(1, 2)); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := store.List("1"); len(entries) != 0 || s.Pending() != 0 {
+		t.Errorf("settled: %d entries on disk, %d pending, want none", len(entries), s.Pending())
+	}
+}
+
+func TestServiceRetryAndRestart(t *testing.T) {
+	dir := t.TempDir()
+	w := newFakeWriter()
+	w.err = errors.New("connection refused")
+	s, err := NewService(infra.NewFileStore(dir), "1", w, w)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A failed request leaves its batch journaled; retrying the same batch
+	// replaces the entry rather than adding one.
+	batch := accounts(1, 2)
+	for range 3 {
+		if _, err := s.CreateAccounts(batch); err == nil {
+			t.Fatal("CreateAccounts succeeded against a failing writer")
+		}
+	}
+	if _, err := s.CreateTransfers([]types.Transfer{{ID: types.ToUint128(9)}}); err == nil {
+		t.Fatal("CreateTransfers succeeded against a failing writer")
+	}
+	if got := s.Pending(); got != 2 {
+		t.Errorf("Pending = %d after three tries of one batch and one of another, want 2", got)
+	}
+
+	// A new service over the same directory, as after a restart, finds the
+	// pending entries and resubmits them with the same IDs.
+	w.err = nil
+	w.accounts[types.ToUint128(1)] = true // landed before the connection dropped
+	restarted, err := NewService(infra.NewFileStore(dir), "1", w, w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := restarted.Pending(); got != 2 {
+		t.Fatalf("Pending after restart = %d, want 2", got)
+	}
+	if other, _ := NewService(infra.NewFileStore(dir), "2", w, w); other.Pending() != 0 {
+		t.Errorf("Pending for another cluster = %d, want 0", other.Pending())
+	}
+
+	report, err := restarted.Resubmit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Entries != 2 || report.Created != 2 || report.Existed != 1 || report.Failed != 0 {
+		t.Errorf("Resubmit = %+v, want 2 entries, 2 created, 1 existed", report)
+	}
+	if !w.accounts[types.ToUint128(2)] {
+		t.Error("account 2 was not resubmitted")
+	}
+	if got := restarted.Pending(); got != 0 {
+		t.Errorf("Pending after Resubmit = %d, want 0", got)
+	}
+	if entries, _ := infra.NewFileStore(dir).List("1"); len(entries) != 0 {
+		t.Errorf("%d entries left on disk after Resubmit, want none", len(entries))
+	}
+}
+
+func TestServiceResubmitStopsAtRequestError(t *testing.T) {
+	w := newFakeWriter()
+	w.err = errors.New("timeout")
+	s, err := NewService(infra.NewFileStore(t.TempDir()), "1", w, w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.CreateAccounts(accounts(1))
+	s.CreateAccounts(accounts(2))
+
+	w.requests = 0
+	if _, err := s.Resubmit(); err == nil {
+		t.Fatal("Resubmit succeeded against a failing writer")
+	}
+	if w.requests != 1 || s.Pending() != 2 {
+		t.Errorf("Resubmit sent %d requests and left %d pending, want 1 and 2", w.requests, s.Pending())
+	}
+}
@@ -0,0 +1,67 @@
+// Package domain provides write outbox entries: create batches journaled to
+// disk before they are sent so they can be resubmitted after a failure.
+package domain
+
+import (
+	"time"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	accountsdomain "github.com/fd1az/tiger-tui/business/accounts/domain"
+)
+
+// Kind is the operation an entry submits.
+type Kind string
+
+const (
+	KindAccounts  Kind = "accounts"
+	KindTransfers Kind = "transfers"
+)
+
+// Entry is one journaled CreateAccounts or CreateTransfers batch, with the
+// client-generated IDs that make resubmission idempotent.
+type Entry struct {
+	ID        string           `json:"id"`
+	Cluster   string           `json:"cluster"`
+	Kind      Kind             `json:"kind"`
+	CreatedAt time.Time        `json:"created_at"`
+	Accounts  []types.Account  `json:"accounts,omitempty"`
+	Transfers []types.Transfer `json:"transfers,omitempty"`
+}
+
+// NewAccountsEntry journals a CreateAccounts batch. The entry ID is derived
+// from the first account's ID.
+func NewAccountsEntry(cluster string, accounts []types.Account, now time.Time) Entry {
+	e := Entry{Cluster: cluster, Kind: KindAccounts, CreatedAt: now, Accounts: accounts}
+	if len(accounts) > 0 {
+		e.ID = entryID(KindAccounts, accounts[0].ID)
+	}
+	return e
+}
+
+// NewTransfersEntry journals a CreateTransfers batch. The entry ID is derived
+// from the first transfer's ID.
+func NewTransfersEntry(cluster string, transfers []types.Transfer, now time.Time) Entry {
+	e := Entry{Cluster: cluster, Kind: KindTransfers, CreatedAt: now, Transfers: transfers}
+	if len(transfers) > 0 {
+		e.ID = entryID(KindTransfers, transfers[0].ID)
+	}
+	return e
+}
+
+// Len returns the number of events in the batch.
+func (e Entry) Len() int {
+	return len(e.Accounts) + len(e.Transfers)
+}
+
+func entryID(kind Kind, first types.Uint128) string {
+	return string(kind) + "-" + accountsdomain.FormatID(first)
+}
+
+// Report summarizes a resubmission of pending entries.
+type Report struct {
+	Entries int // batches resubmitted
+	Created int // events created now
+	Existed int // events that had already landed (exists)
+	Failed  int // events rejected with any other result
+}
@@ -0,0 +1,119 @@
+// Package infra provides the file-backed write outbox store.
+package infra
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fd1az/tiger-tui/business/outbox/domain"
+	"github.com/fd1az/tiger-tui/internal/apperror"
+)
+
+// FileStore keeps one JSON file per entry under dir/<cluster>/.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore creates a store rooted at dir. The directory is created on
+// first write.
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+// Save durably writes the entry: the file is written under a temporary name,
+// synced, and renamed into place.
+func (s *FileStore) Save(e domain.Entry) error {
+	dir := s.clusterDir(e.Cluster)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return apperror.Wrap(err, apperror.CodeOutboxWriteFailed, "create outbox dir")
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return apperror.Wrap(err, apperror.CodeOutboxWriteFailed, "encode entry")
+	}
+
+	tmp, err := os.CreateTemp(dir, ".entry-*")
+	if err != nil {
+		return apperror.Wrap(err, apperror.CodeOutboxWriteFailed, "create entry")
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return apperror.Wrap(err, apperror.CodeOutboxWriteFailed, "write entry")
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return apperror.Wrap(err, apperror.CodeOutboxWriteFailed, "sync entry")
+	}
+	if err := tmp.Close(); err != nil {
+		return apperror.Wrap(err, apperror.CodeOutboxWriteFailed, "close entry")
+	}
+	if err := os.Rename(tmp.Name(), s.entryPath(e)); err != nil {
+		return apperror.Wrap(err, apperror.CodeOutboxWriteFailed, "rename entry")
+	}
+	return syncDir(dir)
+}
+
+// Remove deletes a settled entry. Removing a missing entry is not an error.
+func (s *FileStore) Remove(e domain.Entry) error {
+	if err := os.Remove(s.entryPath(e)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return apperror.Wrap(err, apperror.CodeOutboxWriteFailed, "remove entry")
+	}
+	return nil
+}
+
+// List returns the cluster's pending entries, oldest first.
+func (s *FileStore) List(cluster string) ([]domain.Entry, error) {
+	dir := s.clusterDir(cluster)
+	files, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.CodeOutboxReadFailed, "list outbox")
+	}
+
+	var entries []domain.Entry
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, apperror.Wrap(err, apperror.CodeOutboxReadFailed, "read entry "+f.Name())
+		}
+		var e domain.Entry
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil, apperror.Wrap(err, apperror.CodeOutboxReadFailed, "decode entry "+f.Name())
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].CreatedAt.Before(entries[j].CreatedAt) })
+	return entries, nil
+}
+
+func (s *FileStore) clusterDir(cluster string) string {
+	return filepath.Join(s.dir, "cluster-"+cluster)
+}
+
+func (s *FileStore) entryPath(e domain.Entry) string {
+	return filepath.Join(s.clusterDir(e.Cluster), e.ID+".json")
+}
+
+// syncDir makes a rename in dir durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return apperror.Wrap(err, apperror.CodeOutboxWriteFailed, "open outbox dir")
+	}
+	defer d.Close()
+	if err := d.Sync(); err != nil {
+		return apperror.Wrap(err, apperror.CodeOutboxWriteFailed, "sync outbox dir")
+	}
+	return nil
+}
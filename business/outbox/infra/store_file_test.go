@@ -0,0 +1,107 @@
+package infra
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	"github.com/fd1az/tiger-tui/business/outbox/domain"
+)
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	s := NewFileStore(dir)
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if entries, err := s.List("1"); err != nil || len(entries) != 0 {
+		t.Fatalf("List before any write = %v, %v, want none", entries, err)
+	}
+
+	later := domain.NewTransfersEntry("1", []types.Transfer{{ID: types.ToUint128(20), Amount: types.ToUint128(500), Ledger: 1, Code: 1}}, now.Add(time.Second))
+	earlier := domain.NewAccountsEntry("1", []types.Account{{ID: types.ToUint128(10), Ledger: 1, Code: 1}, {ID: types.ToUint128(11), Ledger: 1, Code: 1}}, now)
+	other := domain.NewAccountsEntry("2", []types.Account{{ID: types.ToUint128(30), Ledger: 1, Code: 1}}, now)
+	for _, e := range []domain.Entry{later, earlier, other} {
+		if err := s.Save(e); err != nil {
+			t.Fatalf("Save(%s): %v", e.ID, err)
+		}
+	}
+
+	// Each entry is one file named by its ID, with no temporary file left.
+	files, err := os.ReadDir(filepath.Join(dir, "cluster-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+	if len(names) != 2 || names[0] != "accounts-10.json" || names[1] != "transfers-20.json" {
+		t.Errorf("cluster-1 holds %q, want the two entry files", names)
+	}
+
+	// Entries come back whole and oldest first, for their cluster only.
+	entries, err := s.List("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].ID != earlier.ID || entries[1].ID != later.ID {
+		t.Fatalf("List(1) = %v, want %s then %s", entries, earlier.ID, later.ID)
+	}
+	if got := entries[0]; got.Kind != domain.KindAccounts || got.Len() != 2 || got.Accounts[1].ID != types.ToUint128(11) || !got.CreatedAt.Equal(now) {
+		t.Errorf("List(1)[0] = %+v, want %+v", got, earlier)
+	}
+	if got := entries[1]; got.Kind != domain.KindTransfers || got.Transfers[0].Amount != types.ToUint128(500) {
+		t.Errorf("List(1)[1] = %+v, want %+v", got, later)
+	}
+
+	// Saving an entry again replaces its file.
+	earlier.CreatedAt = now.Add(2 * time.Second)
+	if err := s.Save(earlier); err != nil {
+		t.Fatal(err)
+	}
+	entries, _ = s.List("1")
+	if len(entries) != 2 || entries[1].ID != earlier.ID {
+		t.Errorf("List(1) after saving %s again = %v, want it replaced and last", earlier.ID, entries)
+	}
+
+	// Removing is idempotent.
+	for range 2 {
+		if err := s.Remove(earlier); err != nil {
+			t.Fatalf("Remove(%s): %v", earlier.ID, err)
+		}
+	}
+	entries, _ = s.List("1")
+	if len(entries) != 1 || entries[0].ID != later.ID {
+		t.Errorf("List(1) after Remove = %v, want only %s", entries, later.ID)
+	}
+	if entries, _ := s.List("2"); len(entries) != 1 || entries[0].ID != other.ID {
+		t.Errorf("List(2) = %v, want only %s", entries, other.ID)
+	}
+}
+
+func TestFileStoreListSkipsForeignFiles(t *testing.T) {
+	dir := t.TempDir()
+	s := NewFileStore(dir)
+	e := domain.NewAccountsEntry("1", []types.Account{{ID: types.ToUint128(1)}}, time.Now())
+	if err := s.Save(e); err != nil {
+		t.Fatal(err)
+	}
+	clusterDir := filepath.Join(dir, "cluster-1")
+	// A temporary file left by a crash before the rename is not an entry.
+	if err := os.WriteFile(filepath.Join(clusterDir, ".entry-123"), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if entries, err := s.List("1"); err != nil || len(entries) != 1 {
+		t.Errorf("List = %v, %v, want the one entry", entries, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(clusterDir, "broken.json"), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.List("1"); err == nil {
+		t.Error("List with a corrupt entry succeeded")
+	}
+}
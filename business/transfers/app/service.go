@@ -0,0 +1,138 @@
+// Package app provides the transfers application service.
+package app
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	accountsdomain "github.com/fd1az/tiger-tui/business/accounts/domain"
+	"github.com/fd1az/tiger-tui/business/transfers/domain"
+)
+
+// Repository is the port the service reads transfers through.
+type Repository interface {
+	QueryTransfers(q domain.TransferQuery) ([]types.Transfer, error)
+	GetAccountTransfers(q domain.TransferQuery) ([]types.Transfer, error)
+	LookupTransfers(ids []types.Uint128) ([]types.Transfer, error)
+	CreateTransfers(transfers []types.Transfer) ([]types.TransferEventResult, error)
+}
+
+// Service pages through transfers for the UI.
+type Service struct {
+	repo Repository
+
+	mu        sync.Mutex
+	holds     *domain.HoldScanner  // open holds among the transfers scanned so far
+	holdsNext domain.TransferQuery // resumes the hold scan after the last transfer seen
+}
+
+// NewService creates a new transfers service.
+func NewService(repo Repository) *Service {
+	return &Service{
+		repo:      repo,
+		holds:     domain.NewHoldScanner(),
+		holdsNext: domain.TransferQuery{Limit: accountsdomain.MaxBatchSize},
+	}
+}
+
+// LoadPage fetches one page of transfers, using GetAccountTransfers when the
+// query is scoped to an account and QueryTransfers otherwise. The query's
+// Match, and the Ledger of a scoped query, are applied after the cursor is
+// taken, so a page may come back empty with more to follow.
+func (s *Service) LoadPage(q domain.TransferQuery) (domain.TransferPage, error) {
+	var (
+		transfers []types.Transfer
+		err       error
+	)
+	if q.Scoped() {
+		transfers, err = s.repo.GetAccountTransfers(q)
+	} else {
+		transfers, err = s.repo.QueryTransfers(q)
+	}
+	if err != nil {
+		return domain.TransferPage{}, err
+	}
+
+	page := domain.TransferPage{Transfers: transfers, Scanned: len(transfers), Next: q}
+	if n := len(transfers); n > 0 && uint32(n) == q.PageSize() {
+		page.Next = q.NextQuery(transfers[n-1].Timestamp)
+		page.HasMore = true
+	}
+	keep := q.Match
+	if q.Scoped() && q.Ledger != 0 {
+		// AccountFilter has no ledger field; match it on the page instead.
+		match, ledger := q.Match, q.Ledger
+		keep = func(t types.Transfer) bool { return t.Ledger == ledger && (match == nil || match(t)) }
+	}
+	if keep != nil {
+		page.Transfers = slices.DeleteFunc(transfers, func(t types.Transfer) bool { return !keep(t) })
+	}
+	return page, nil
+}
+
+// Lookup fetches a single transfer by ID. The bool is false when no transfer
+// has that ID.
+func (s *Service) Lookup(id types.Uint128) (types.Transfer, bool, error) {
+	transfers, err := s.repo.LookupTransfers([]types.Uint128{id})
+	if err != nil || len(transfers) == 0 {
+		return types.Transfer{}, false, err
+	}
+	return transfers[0], true, nil
+}
+
+// LoadHolds returns the pending transfers that have not been posted, voided
+// or expired, oldest first. The first call scans every transfer; later calls
+// resume after the last transfer seen and update the open holds with the
+// transfers created since.
+func (s *Service) LoadHolds() ([]domain.Hold, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q := s.holdsNext
+	for {
+		page, err := s.LoadPage(q)
+		if err != nil {
+			return nil, err
+		}
+		s.holds.Add(page.Transfers)
+		if n := len(page.Transfers); n > 0 {
+			s.holdsNext = q.NextQuery(page.Transfers[n-1].Timestamp)
+		}
+		if !page.HasMore {
+			break
+		}
+		q = page.Next
+	}
+
+	s.holds.Expire(time.Now())
+	return s.holds.Holds(), nil
+}
+
+// Create submits transfers in batches of at most MaxBatchSize, keeping linked
+// chains within one batch, and returns one outcome per transfer, in order.
+func (s *Service) Create(transfers []types.Transfer) ([]domain.CreateOutcome, error) {
+	outcomes := make([]domain.CreateOutcome, 0, len(transfers))
+	for _, batch := range domain.Batches(transfers, accountsdomain.MaxBatchSize) {
+		results, err := s.repo.CreateTransfers(batch)
+		if err != nil {
+			return outcomes, err
+		}
+		outcomes = append(outcomes, domain.Outcomes(batch, results)...)
+	}
+	return outcomes, nil
+}
+
+// Import submits a validated import plan (see domain.PrepareImport) when
+// every row is valid; a dry run stops after validation. The report has one
+// result per row; if a batch fails to send, rows from that batch on are
+// reported as not submitted alongside the error.
+func (s *Service) Import(plan domain.ImportPlan, dryRun bool) (domain.ImportReport, error) {
+	if plan.Invalid > 0 || dryRun {
+		return plan.Validated(), nil
+	}
+	outcomes, err := s.Create(plan.Transfers)
+	return plan.Report(outcomes), err
+}
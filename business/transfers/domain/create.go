@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	accounts "github.com/fd1az/tiger-tui/business/accounts/domain"
+)
+
+// CreateOutcome pairs a submitted transfer with its CreateTransferResult.
+type CreateOutcome struct {
+	Transfer types.Transfer
+	Result   types.CreateTransferResult
+}
+
+// OK reports whether the transfer was created.
+func (o CreateOutcome) OK() bool {
+	return o.Result == types.TransferOK
+}
+
+// Settled reports whether the transfer is in the cluster: created now, or by
+// an earlier submit of the same ID.
+func (o CreateOutcome) Settled() bool {
+	return o.OK() || o.Result == types.TransferExists
+}
+
+// Outcomes expands TigerBeetle's sparse results (failures only) into one
+// outcome per submitted transfer.
+func Outcomes(transfers []types.Transfer, results []types.TransferEventResult) []CreateOutcome {
+	out := make([]CreateOutcome, len(transfers))
+	for i, t := range transfers {
+		out[i] = CreateOutcome{Transfer: t, Result: types.TransferOK}
+	}
+	for _, r := range results {
+		if int(r.Index) < len(out) {
+			out[r.Index].Result = r.Result
+		}
+	}
+	return out
+}
+
+// TransferResultName returns the TigerBeetle snake_case name of a result,
+// e.g. "exceeds_credits".
+func TransferResultName(r types.CreateTransferResult) string {
+	return accounts.ResultName(r.String(), "Transfer")
+}
+
+// TransferFlagNames returns the names of the flags set on a transfer, in bit
+// order, using TigerBeetle's snake_case names.
+func TransferFlagNames(flags uint16) []string {
+	f := types.Transfer{Flags: flags}.TransferFlags()
+	var names []string
+	for _, fl := range []struct {
+		set  bool
+		name string
+	}{
+		{f.Linked, "linked"},
+		{f.Pending, "pending"},
+		{f.PostPendingTransfer, "post_pending_transfer"},
+		{f.VoidPendingTransfer, "void_pending_transfer"},
+		{f.BalancingDebit, "balancing_debit"},
+		{f.BalancingCredit, "balancing_credit"},
+		{f.ClosingDebit, "closing_debit"},
+		{f.ClosingCredit, "closing_credit"},
+		{f.Imported, "imported"},
+	} {
+		if fl.set {
+			names = append(names, fl.name)
+		}
+	}
+	return names
+}
+
+// ParseTransferFlags parses flag names as produced by TransferFlagNames,
+// separated by "|", "," or spaces, e.g. "linked|pending".
+func ParseTransferFlags(s string) (types.TransferFlags, error) {
+	var f types.TransferFlags
+	names := strings.FieldsFunc(s, func(r rune) bool {
+		return r == '|' || r == ',' || r == ' '
+	})
+	for _, name := range names {
+		switch strings.ToLower(name) {
+		case "linked":
+			f.Linked = true
+		case "pending":
+			f.Pending = true
+		case "post_pending_transfer":
+			f.PostPendingTransfer = true
+		case "void_pending_transfer":
+			f.VoidPendingTransfer = true
+		case "balancing_debit":
+			f.BalancingDebit = true
+		case "balancing_credit":
+			f.BalancingCredit = true
+		case "closing_debit":
+			f.ClosingDebit = true
+		case "closing_credit":
+			f.ClosingCredit = true
+		case "imported":
+			f.Imported = true
+		default:
+			return f, fmt.Errorf("unknown flag %q", name)
+		}
+	}
+	return f, nil
+}
+
+// Batches splits transfers into batches of at most max events without
+// splitting linked chains where possible (see accounts.Batches).
+func Batches(transfers []types.Transfer, max int) [][]types.Transfer {
+	return accounts.Batches(transfers, max, func(t types.Transfer) bool {
+		return t.TransferFlags().Linked
+	})
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+func TestTransferResultName(t *testing.T) {
+	tests := []struct {
+		result types.CreateTransferResult
+		want   string
+	}{
+		{types.TransferOK, "ok"},
+		{types.TransferLinkedEventFailed, "linked_event_failed"},
+		{types.TransferLinkedEventChainOpen, "linked_event_chain_open"},
+		{types.TransferImportedEventExpected, "imported_event_expected"},
+		{types.TransferImportedEventNotExpected, "imported_event_not_expected"},
+		{types.TransferTimestampMustBeZero, "timestamp_must_be_zero"},
+		{types.TransferImportedEventTimestampOutOfRange, "imported_event_timestamp_out_of_range"},
+		{types.TransferImportedEventTimestampMustNotAdvance, "imported_event_timestamp_must_not_advance"},
+		{types.TransferReservedFlag, "reserved_flag"},
+		{types.TransferIDMustNotBeZero, "id_must_not_be_zero"},
+		{types.TransferIDMustNotBeIntMax, "id_must_not_be_int_max"},
+		{types.TransferExistsWithDifferentFlags, "exists_with_different_flags"},
+		{types.TransferExistsWithDifferentPendingID, "exists_with_different_pending_id"},
+		{types.TransferExistsWithDifferentTimeout, "exists_with_different_timeout"},
+		{types.TransferExistsWithDifferentDebitAccountID, "exists_with_different_debit_account_id"},
+		{types.TransferExistsWithDifferentCreditAccountID, "exists_with_different_credit_account_id"},
+		{types.TransferExistsWithDifferentAmount, "exists_with_different_amount"},
+		{types.TransferExistsWithDifferentUserData128, "exists_with_different_user_data_128"},
+		{types.TransferExistsWithDifferentUserData64, "exists_with_different_user_data_64"},
+		{types.TransferExistsWithDifferentUserData32, "exists_with_different_user_data_32"},
+		{types.TransferExistsWithDifferentLedger, "exists_with_different_ledger"},
+		{types.TransferExistsWithDifferentCode, "exists_with_different_code"},
+		{types.TransferExists, "exists"},
+		{types.TransferIDAlreadyFailed, "id_already_failed"},
+		{types.TransferFlagsAreMutuallyExclusive, "flags_are_mutually_exclusive"},
+		{types.TransferDebitAccountIDMustNotBeZero, "debit_account_id_must_not_be_zero"},
+		{types.TransferDebitAccountIDMustNotBeIntMax, "debit_account_id_must_not_be_int_max"},
+		{types.TransferCreditAccountIDMustNotBeZero, "credit_account_id_must_not_be_zero"},
+		{types.TransferCreditAccountIDMustNotBeIntMax, "credit_account_id_must_not_be_int_max"},
+		{types.TransferAccountsMustBeDifferent, "accounts_must_be_different"},
+		{types.TransferPendingIDMustBeZero, "pending_id_must_be_zero"},
+		{types.TransferPendingIDMustNotBeZero, "pending_id_must_not_be_zero"},
+		{types.TransferPendingIDMustNotBeIntMax, "pending_id_must_not_be_int_max"},
+		{types.TransferPendingIDMustBeDifferent, "pending_id_must_be_different"},
+		{types.TransferTimeoutReservedForPendingTransfer, "timeout_reserved_for_pending_transfer"},
+		{types.TransferClosingTransferMustBePending, "closing_transfer_must_be_pending"},
+		{types.TransferLedgerMustNotBeZero, "ledger_must_not_be_zero"},
+		{types.TransferCodeMustNotBeZero, "code_must_not_be_zero"},
+		{types.TransferDebitAccountNotFound, "debit_account_not_found"},
+		{types.TransferCreditAccountNotFound, "credit_account_not_found"},
+		{types.TransferAccountsMustHaveTheSameLedger, "accounts_must_have_the_same_ledger"},
+		{types.TransferTransferMustHaveTheSameLedgerAsAccounts, "transfer_must_have_the_same_ledger_as_accounts"},
+		{types.TransferPendingTransferNotFound, "pending_transfer_not_found"},
+		{types.TransferPendingTransferNotPending, "pending_transfer_not_pending"},
+		{types.TransferPendingTransferHasDifferentDebitAccountID, "pending_transfer_has_different_debit_account_id"},
+		{types.TransferPendingTransferHasDifferentCreditAccountID, "pending_transfer_has_different_credit_account_id"},
+		{types.TransferPendingTransferHasDifferentLedger, "pending_transfer_has_different_ledger"},
+		{types.TransferPendingTransferHasDifferentCode, "pending_transfer_has_different_code"},
+		{types.TransferExceedsPendingTransferAmount, "exceeds_pending_transfer_amount"},
+		{types.TransferPendingTransferHasDifferentAmount, "pending_transfer_has_different_amount"},
+		{types.TransferPendingTransferAlreadyPosted, "pending_transfer_already_posted"},
+		{types.TransferPendingTransferAlreadyVoided, "pending_transfer_already_voided"},
+		{types.TransferPendingTransferExpired, "pending_transfer_expired"},
+		{types.TransferImportedEventTimestampMustNotRegress, "imported_event_timestamp_must_not_regress"},
+		{types.TransferImportedEventTimestampMustPostdateDebitAccount, "imported_event_timestamp_must_postdate_debit_account"},
+		{types.TransferImportedEventTimestampMustPostdateCreditAccount, "imported_event_timestamp_must_postdate_credit_account"},
+		{types.TransferImportedEventTimeoutMustBeZero, "imported_event_timeout_must_be_zero"},
+		{types.TransferDebitAccountAlreadyClosed, "debit_account_already_closed"},
+		{types.TransferCreditAccountAlreadyClosed, "credit_account_already_closed"},
+		{types.TransferOverflowsDebitsPending, "overflows_debits_pending"},
+		{types.TransferOverflowsCreditsPending, "overflows_credits_pending"},
+		{types.TransferOverflowsDebitsPosted, "overflows_debits_posted"},
+		{types.TransferOverflowsCreditsPosted, "overflows_credits_posted"},
+		{types.TransferOverflowsDebits, "overflows_debits"},
+		{types.TransferOverflowsCredits, "overflows_credits"},
+		{types.TransferOverflowsTimeout, "overflows_timeout"},
+		{types.TransferExceedsCredits, "exceeds_credits"},
+		{types.TransferExceedsDebits, "exceeds_debits"},
+	}
+
+	seen := map[types.CreateTransferResult]bool{}
+	for _, tt := range tests {
+		seen[tt.result] = true
+		if got := TransferResultName(tt.result); got != tt.want {
+			t.Errorf("TransferResultName(%s) = %q, want %q", tt.result, got, tt.want)
+		}
+	}
+
+	// Every result the client defines is in the table.
+	for r := types.CreateTransferResult(0); r < 256; r++ {
+		if !strings.HasPrefix(r.String(), "CreateTransferResult(") && !seen[r] {
+			t.Errorf("%s is missing from the table", r)
+		}
+	}
+}
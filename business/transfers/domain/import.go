@@ -0,0 +1,279 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tb "github.com/tigerbeetle/tigerbeetle-go"
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	accounts "github.com/fd1az/tiger-tui/business/accounts/domain"
+)
+
+// ImportColumns are the column names (CSV header) or keys (NDJSON) of an
+// import file, in the order results and templates use.
+var ImportColumns = []string{
+	"id", "debit_account", "credit_account", "amount", "ledger", "type",
+	"venue", "pending_id", "timeout", "flags", "user_data_128", "user_data_64",
+}
+
+// Import result names besides TigerBeetle's CreateTransferResult names.
+const (
+	ImportInvalid      = accounts.ImportInvalid
+	ImportValid        = accounts.ImportValid
+	ImportNotSubmitted = accounts.ImportNotSubmitted
+)
+
+// ImportResult and ImportReport are shared with account imports.
+type (
+	ImportResult = accounts.ImportResult
+	ImportReport = accounts.ImportReport
+)
+
+// ImportRow is one transfer as written in an import file. Values are text
+// using chart names (e.g. ledger "USD", type "DEPOSIT", venue "Binance");
+// Transfer resolves them against the active chart.
+type ImportRow struct {
+	Line          int // 1-based line in the source file
+	ID            string
+	DebitAccount  string
+	CreditAccount string
+	Amount        string
+	Ledger        string
+	Type          string
+	Venue         string
+	PendingID     string
+	Timeout       string
+	Flags         string
+	UserData128   string
+	UserData64    string
+}
+
+// Set assigns the value of an import column. It reports false for a column
+// name that is not in ImportColumns.
+func (r *ImportRow) Set(column, value string) bool {
+	value = strings.TrimSpace(value)
+	switch strings.ToLower(strings.TrimSpace(column)) {
+	case "id":
+		r.ID = value
+	case "debit_account":
+		r.DebitAccount = value
+	case "credit_account":
+		r.CreditAccount = value
+	case "amount":
+		r.Amount = value
+	case "ledger":
+		r.Ledger = value
+	case "type":
+		r.Type = value
+	case "venue":
+		r.Venue = value
+	case "pending_id":
+		r.PendingID = value
+	case "timeout":
+		r.Timeout = value
+	case "flags":
+		r.Flags = value
+	case "user_data_128":
+		r.UserData128 = value
+	case "user_data_64":
+		r.UserData64 = value
+	default:
+		return false
+	}
+	return true
+}
+
+// Transfer validates the row offline and returns the transfer it describes.
+// An empty ID gets a fresh time-based ID.
+//
+// Post/void rows may leave accounts, ledger and type empty (they inherit from
+// the pending transfer). An empty or "max" amount means AMOUNT_MAX for
+// post-pending and balancing transfers, and zero for void-pending.
+func (r ImportRow) Transfer() (types.Transfer, error) {
+	t := types.Transfer{}
+	flags, err := ParseTransferFlags(r.Flags)
+	if err != nil {
+		return t, fmt.Errorf("flags: %w", err)
+	}
+	resolving := flags.PostPendingTransfer || flags.VoidPendingTransfer
+	t.Flags = flags.ToUint16()
+
+	if r.ID != "" {
+		if t.ID, err = accounts.ParseID(r.ID); err != nil {
+			return t, fmt.Errorf("id: %w", err)
+		}
+	} else {
+		t.ID = types.ID()
+	}
+
+	switch {
+	case r.DebitAccount == "" && !resolving:
+		return t, fmt.Errorf("debit_account is required")
+	case r.CreditAccount == "" && !resolving:
+		return t, fmt.Errorf("credit_account is required")
+	}
+	if r.DebitAccount != "" {
+		if t.DebitAccountID, err = accounts.ParseID(r.DebitAccount); err != nil {
+			return t, fmt.Errorf("debit_account: %w", err)
+		}
+	}
+	if r.CreditAccount != "" {
+		if t.CreditAccountID, err = accounts.ParseID(r.CreditAccount); err != nil {
+			return t, fmt.Errorf("credit_account: %w", err)
+		}
+	}
+	if r.DebitAccount != "" && t.DebitAccountID == t.CreditAccountID {
+		return t, fmt.Errorf("debit and credit accounts must be different")
+	}
+
+	var ok bool
+	switch {
+	case r.Ledger != "":
+		if t.Ledger, ok = accounts.LedgerBySymbol(r.Ledger); !ok {
+			return t, fmt.Errorf("ledger: %q is not in the %s chart", r.Ledger, accounts.ActiveChart().Name)
+		}
+	case !resolving:
+		return t, fmt.Errorf("ledger is required")
+	}
+	switch {
+	case r.Type != "":
+		if t.Code, ok = accounts.TransferCodeByName(r.Type); !ok {
+			return t, fmt.Errorf("type: %q is not in the %s chart", r.Type, accounts.ActiveChart().Name)
+		}
+	case !resolving:
+		return t, fmt.Errorf("type is required")
+	}
+	if r.Venue != "" {
+		if t.UserData32, ok = accounts.VenueByName(r.Venue); !ok {
+			return t, fmt.Errorf("venue: %q is not in the %s chart", r.Venue, accounts.ActiveChart().Name)
+		}
+	}
+
+	switch {
+	case strings.EqualFold(r.Amount, "max"):
+		t.Amount = tb.AmountMax
+	case r.Amount == "" && (flags.PostPendingTransfer || flags.BalancingDebit || flags.BalancingCredit):
+		t.Amount = tb.AmountMax
+	case r.Amount == "" && flags.VoidPendingTransfer:
+		// Zero voids the full pending amount.
+	case r.Amount == "":
+		return t, fmt.Errorf("amount is required")
+	case t.Ledger == 0:
+		return t, fmt.Errorf("ledger is required to scale the amount")
+	default:
+		m, err := accounts.ParseMoney(r.Amount, t.Ledger)
+		if err != nil {
+			return t, fmt.Errorf("amount: %w", err)
+		}
+		t.Amount = m.Amount
+	}
+
+	if r.PendingID != "" {
+		if t.PendingID, err = accounts.ParseID(r.PendingID); err != nil {
+			return t, fmt.Errorf("pending_id: %w", err)
+		}
+	} else if resolving {
+		return t, fmt.Errorf("pending_id is required to post or void a pending transfer")
+	}
+
+	if r.Timeout != "" {
+		if !flags.Pending {
+			return t, fmt.Errorf("timeout only applies to pending transfers")
+		}
+		v, err := strconv.ParseUint(r.Timeout, 10, 32)
+		if err != nil {
+			return t, fmt.Errorf("timeout: %q is not a number of seconds", r.Timeout)
+		}
+		t.Timeout = uint32(v)
+	}
+
+	if r.UserData128 != "" {
+		if t.UserData128, err = accounts.ParseID(r.UserData128); err != nil {
+			return t, fmt.Errorf("user_data_128: %w", err)
+		}
+	}
+	if r.UserData64 != "" {
+		if t.UserData64, err = strconv.ParseUint(r.UserData64, 10, 64); err != nil {
+			return t, fmt.Errorf("user_data_64: %q is not an unsigned 64-bit integer", r.UserData64)
+		}
+	}
+	return t, nil
+}
+
+// ImportPlan is the offline validation of an import file.
+type ImportPlan struct {
+	Rows      []ImportRow
+	Transfers []types.Transfer // one per valid row, in order
+	Errors    []error          // one per row; nil for valid rows
+	Invalid   int
+}
+
+// PrepareImport validates every row. Besides each row's own checks, IDs must
+// be unique within the file and the last linked chain must be closed.
+func PrepareImport(rows []ImportRow) ImportPlan {
+	p := ImportPlan{Rows: rows, Errors: make([]error, len(rows))}
+	seen := make(map[types.Uint128]int, len(rows))
+	for i, row := range rows {
+		t, err := row.Transfer()
+		if err == nil {
+			if line, dup := seen[t.ID]; dup {
+				err = fmt.Errorf("id %s already used on line %d", accounts.FormatID(t.ID), line)
+			}
+		}
+		if err == nil && i == len(rows)-1 && t.TransferFlags().Linked {
+			err = fmt.Errorf("linked chain is not closed: the last row cannot be linked")
+		}
+		if err != nil {
+			p.Errors[i] = err
+			p.Invalid++
+			continue
+		}
+		seen[t.ID] = row.Line
+		p.Transfers = append(p.Transfers, t)
+	}
+	return p
+}
+
+// Link chains every transfer of the plan, so they are applied all together
+// or not at all. A chain must fit in one request.
+func (p *ImportPlan) Link() error {
+	if len(p.Transfers) > accounts.MaxBatchSize {
+		return fmt.Errorf("a linked chain holds at most %d events, got %d", accounts.MaxBatchSize, len(p.Transfers))
+	}
+	linked := types.TransferFlags{Linked: true}.ToUint16()
+	for i := 0; i < len(p.Transfers)-1; i++ {
+		p.Transfers[i].Flags |= linked
+	}
+	return nil
+}
+
+// Validated builds the report of a plan that was not submitted: valid rows
+// are reported as ImportValid.
+func (p ImportPlan) Validated() ImportReport {
+	return p.report(nil, false)
+}
+
+// Report builds the per-row report of a submitted plan. outcomes are the
+// results of submitting p.Transfers, possibly cut short by a failed request;
+// rows without an outcome are reported as not submitted.
+func (p ImportPlan) Report(outcomes []CreateOutcome) ImportReport {
+	return p.report(outcomes, true)
+}
+
+func (p ImportPlan) report(outcomes []CreateOutcome, submitted bool) ImportReport {
+	lines := make([]int, len(p.Rows))
+	for i, row := range p.Rows {
+		lines[i] = row.Line
+	}
+	ids := make([]types.Uint128, len(p.Transfers))
+	for i, t := range p.Transfers {
+		ids[i] = t.ID
+	}
+	results := make([]string, len(outcomes))
+	for i, o := range outcomes {
+		results[i] = TransferResultName(o.Result)
+	}
+	return accounts.NewImportReport(lines, p.Errors, ids, results, submitted)
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// Hold is a pending transfer that has not been posted or voided.
+type Hold struct {
+	Transfer types.Transfer
+}
+
+// HasTimeout reports whether the hold expires on its own.
+func (h Hold) HasTimeout() bool {
+	return h.Transfer.Timeout > 0
+}
+
+// ExpiresAt returns when the cluster voids the hold. Only meaningful when
+// HasTimeout is true.
+func (h Hold) ExpiresAt() time.Time {
+	created := time.Unix(0, int64(h.Transfer.Timestamp))
+	return created.Add(time.Duration(h.Transfer.Timeout) * time.Second)
+}
+
+// Remaining returns the time left before the hold expires at now.
+func (h Hold) Remaining(now time.Time) time.Duration {
+	return h.ExpiresAt().Sub(now)
+}
+
+// Expired reports whether the hold has timed out at now.
+func (h Hold) Expired(now time.Time) bool {
+	return h.HasTimeout() && h.Remaining(now) <= 0
+}
+
+// Age returns how long the hold has been open at now.
+func (h Hold) Age(now time.Time) time.Duration {
+	return now.Sub(time.Unix(0, int64(h.Transfer.Timestamp)))
+}
+
+// HoldScanner finds unresolved pending transfers while scanning transfers in
+// any order. A pending transfer and its resolving transfer are dropped once
+// both have been seen, so a scanner fed page after page keeps only the open
+// holds.
+type HoldScanner struct {
+	pending     map[types.Uint128]types.Transfer
+	resolutions Resolutions
+}
+
+// NewHoldScanner creates an empty scanner.
+func NewHoldScanner() *HoldScanner {
+	return &HoldScanner{
+		pending:     map[types.Uint128]types.Transfer{},
+		resolutions: Resolutions{},
+	}
+}
+
+// Add records a page of transfers.
+func (s *HoldScanner) Add(transfers []types.Transfer) {
+	for _, t := range transfers {
+		if t.TransferFlags().Pending {
+			if _, resolved := s.resolutions[t.ID]; resolved {
+				delete(s.resolutions, t.ID)
+				continue
+			}
+			s.pending[t.ID] = t
+		}
+		if id, ok := s.resolutions.Observe(t); ok {
+			if _, seen := s.pending[id]; seen {
+				delete(s.pending, id)
+				delete(s.resolutions, id)
+			}
+		}
+	}
+}
+
+// Expire drops the holds that have timed out at now; the cluster voids them
+// without a resolving transfer.
+func (s *HoldScanner) Expire(now time.Time) {
+	for id, t := range s.pending {
+		if (Hold{Transfer: t}).Expired(now) {
+			delete(s.pending, id)
+		}
+	}
+}
+
+// Holds returns the pending transfers without a resolving transfer, oldest
+// first.
+func (s *HoldScanner) Holds() []Hold {
+	holds := make([]Hold, 0, len(s.pending))
+	for _, t := range s.pending {
+		holds = append(holds, Hold{Transfer: t})
+	}
+	sort.Slice(holds, func(i, j int) bool {
+		return holds[i].Transfer.Timestamp < holds[j].Transfer.Timestamp
+	})
+	return holds
+}
+
+// PostPending returns a transfer posting amount of the pending transfer p
+// under a fresh ID. Accounts, ledger and code repeat the pending transfer's.
+func PostPending(p types.Transfer, amount types.Uint128) types.Transfer {
+	return types.Transfer{
+		ID:              types.ID(),
+		DebitAccountID:  p.DebitAccountID,
+		CreditAccountID: p.CreditAccountID,
+		Amount:          amount,
+		PendingID:       p.ID,
+		Ledger:          p.Ledger,
+		Code:            p.Code,
+		Flags:           types.TransferFlags{PostPendingTransfer: true}.ToUint16(),
+	}
+}
+
+// VoidPending returns a transfer voiding the pending transfer p under a
+// fresh ID.
+func VoidPending(p types.Transfer) types.Transfer {
+	return types.Transfer{
+		ID:              types.ID(),
+		DebitAccountID:  p.DebitAccountID,
+		CreditAccountID: p.CreditAccountID,
+		Amount:          p.Amount,
+		PendingID:       p.ID,
+		Ledger:          p.Ledger,
+		Code:            p.Code,
+		Flags:           types.TransferFlags{VoidPendingTransfer: true}.ToUint16(),
+	}
+}
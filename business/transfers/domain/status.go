@@ -0,0 +1,72 @@
+package domain
+
+import "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+// Status is the settlement state of a transfer.
+type Status int
+
+const (
+	StatusPosted Status = iota
+	StatusPending
+	StatusVoided
+)
+
+// String returns the status name.
+func (s Status) String() string {
+	switch s {
+	case StatusPending:
+		return "pending"
+	case StatusVoided:
+		return "voided"
+	default:
+		return "posted"
+	}
+}
+
+// Marker returns the ledger status marker from design.md.
+func (s Status) Marker() string {
+	switch s {
+	case StatusPending:
+		return "~"
+	case StatusVoided:
+		return "ERR"
+	default:
+		return "OK"
+	}
+}
+
+// Resolutions maps pending transfer IDs to the outcome of the transfer that
+// resolved them, as far as has been observed.
+type Resolutions map[types.Uint128]Status
+
+// Observe records t if it posts or voids a pending transfer. It returns the
+// resolved pending ID and true when t is a resolving transfer.
+func (r Resolutions) Observe(t types.Transfer) (types.Uint128, bool) {
+	f := t.TransferFlags()
+	switch {
+	case f.PostPendingTransfer:
+		r[t.PendingID] = StatusPosted
+	case f.VoidPendingTransfer:
+		r[t.PendingID] = StatusVoided
+	default:
+		return types.Uint128{}, false
+	}
+	return t.PendingID, true
+}
+
+// StatusOf returns the status of t. Pending transfers are reported with the
+// outcome of their resolving transfer when it has been observed.
+func (r Resolutions) StatusOf(t types.Transfer) Status {
+	f := t.TransferFlags()
+	switch {
+	case f.VoidPendingTransfer:
+		return StatusVoided
+	case f.Pending:
+		if s, ok := r[t.ID]; ok {
+			return s
+		}
+		return StatusPending
+	default:
+		return StatusPosted
+	}
+}
@@ -0,0 +1,102 @@
+// Package domain provides transfer domain types and status decoding.
+package domain
+
+import (
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	accounts "github.com/fd1az/tiger-tui/business/accounts/domain"
+)
+
+// TransferQuery describes one page request over transfers. When AccountID is
+// set the query is scoped to that account (GetAccountTransfers); otherwise it
+// covers the whole cluster (QueryTransfers). TimestampMin/TimestampMax act as
+// the pagination cursor.
+type TransferQuery struct {
+	AccountID    types.Uint128
+	Ledger       uint32
+	Code         uint16
+	UserData128  types.Uint128
+	UserData64   uint64
+	UserData32   uint32
+	TimestampMin uint64
+	TimestampMax uint64
+	Limit        uint32
+	Reversed     bool
+
+	// Match, when set, keeps only the transfers it accepts from each page,
+	// for conditions the TigerBeetle filters cannot express.
+	Match func(types.Transfer) bool
+}
+
+// Scoped reports whether the query is limited to a single account.
+func (q TransferQuery) Scoped() bool {
+	return q.AccountID != types.Uint128{}
+}
+
+// QueryFilter converts a cluster-wide query to a TigerBeetle QueryFilter.
+func (q TransferQuery) QueryFilter() types.QueryFilter {
+	return types.QueryFilter{
+		UserData128:  q.UserData128,
+		UserData64:   q.UserData64,
+		UserData32:   q.UserData32,
+		Ledger:       q.Ledger,
+		Code:         q.Code,
+		TimestampMin: q.TimestampMin,
+		TimestampMax: q.TimestampMax,
+		Limit:        q.PageSize(),
+		Flags:        types.QueryFilterFlags{Reversed: q.Reversed}.ToUint32(),
+	}
+}
+
+// AccountFilter converts an account-scoped query to a TigerBeetle
+// AccountFilter matching both debits and credits. AccountFilter has no
+// ledger, so Ledger is left for the caller to match on each page.
+func (q TransferQuery) AccountFilter() types.AccountFilter {
+	return types.AccountFilter{
+		AccountID:    q.AccountID,
+		UserData128:  q.UserData128,
+		UserData64:   q.UserData64,
+		UserData32:   q.UserData32,
+		Code:         q.Code,
+		TimestampMin: q.TimestampMin,
+		TimestampMax: q.TimestampMax,
+		Limit:        q.PageSize(),
+		Flags: types.AccountFilterFlags{
+			Debits:   true,
+			Credits:  true,
+			Reversed: q.Reversed,
+		}.ToUint32(),
+	}
+}
+
+// PageSize returns the effective page size, clamped to the batch limit.
+func (q TransferQuery) PageSize() uint32 {
+	switch {
+	case q.Limit == 0:
+		return accounts.DefaultPageSize
+	case q.Limit > accounts.MaxBatchSize:
+		return accounts.MaxBatchSize
+	default:
+		return q.Limit
+	}
+}
+
+// NextQuery returns the query that continues after the last timestamp seen.
+func (q TransferQuery) NextQuery(lastTimestamp uint64) TransferQuery {
+	next := q
+	if q.Reversed {
+		next.TimestampMax = lastTimestamp - 1
+	} else {
+		next.TimestampMin = lastTimestamp + 1
+	}
+	return next
+}
+
+// TransferPage is one page of transfers plus the query for the page after it.
+// Scanned counts the transfers fetched before the query's Match dropped any.
+type TransferPage struct {
+	Transfers []types.Transfer
+	Scanned   int
+	Next      TransferQuery
+	HasMore   bool
+}
@@ -0,0 +1,158 @@
+package infra
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	accounts "github.com/fd1az/tiger-tui/business/accounts/domain"
+	accountsinfra "github.com/fd1az/tiger-tui/business/accounts/infra"
+	"github.com/fd1az/tiger-tui/business/transfers/domain"
+	"github.com/fd1az/tiger-tui/internal/apperror"
+)
+
+// ReadImportNDJSON reads transfer rows from NDJSON, one object per line, such
+// as stdin. name identifies the input in errors.
+func ReadImportNDJSON(r io.Reader, name string) ([]domain.ImportRow, error) {
+	rows, err := readImportNDJSON(r)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeImportReadFailed,
+			apperror.WithMessage(fmt.Sprintf("failed to read transfers: %v", err)),
+			apperror.WithContext(name),
+			apperror.WithCause(err))
+	}
+	return rows, nil
+}
+
+// ReadImportFile reads transfer rows from a CSV file with a header row
+// (.csv) or from NDJSON, one object per line (.ndjson, .jsonl, .json). Column
+// names and keys are domain.ImportColumns; blank lines and CSV lines
+// starting with "#" are skipped.
+func ReadImportFile(path string) ([]domain.ImportRow, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.CodeImportReadFailed, path)
+	}
+	defer f.Close()
+
+	var rows []domain.ImportRow
+	switch importFormat(path) {
+	case "csv":
+		rows, err = readImportCSV(f)
+	case "ndjson":
+		rows, err = readImportNDJSON(f)
+	default:
+		err = fmt.Errorf("unsupported file type %q, want .csv, .ndjson, .jsonl or .json", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, apperror.New(apperror.CodeImportReadFailed,
+			apperror.WithMessage(fmt.Sprintf("failed to read import file: %v", err)),
+			apperror.WithContext(path),
+			apperror.WithCause(err))
+	}
+	return rows, nil
+}
+
+// ImportResultsPath returns where results for an import file are written by
+// default: "transfers.csv" gives "transfers.results.csv".
+func ImportResultsPath(path string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + ".results" + ext
+}
+
+// WriteImportResults writes one result per row as CSV or NDJSON, chosen by
+// the extension of path like ReadImportFile.
+func WriteImportResults(path string, results []domain.ImportResult) error {
+	var buf bytes.Buffer
+	if importFormat(path) == "csv" {
+		w := csv.NewWriter(&buf)
+		w.Write([]string{"line", "id", "result", "error"})
+		for _, r := range results {
+			w.Write([]string{strconv.Itoa(r.Line), resultID(r), r.Result, r.Error})
+		}
+		w.Flush()
+	} else {
+		accountsinfra.WriteImportNDJSON(&buf, results)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return apperror.Wrap(err, apperror.CodeImportWriteFailed, path)
+	}
+	return nil
+}
+
+// importFormat returns "csv" or "ndjson" for a path, or "" if unsupported.
+func importFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return "csv"
+	case ".ndjson", ".jsonl", ".json":
+		return "ndjson"
+	}
+	return ""
+}
+
+// resultID formats the row's transfer ID; invalid rows have none.
+func resultID(r domain.ImportResult) string {
+	if r.Result == domain.ImportInvalid {
+		return ""
+	}
+	return accounts.FormatID(r.ID)
+}
+
+func readImportCSV(r io.Reader) ([]domain.ImportRow, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("missing header row")
+	}
+	if err != nil {
+		return nil, err
+	}
+	var probe domain.ImportRow
+	for _, col := range header {
+		if !probe.Set(col, "") {
+			return nil, fmt.Errorf("unknown column %q", col)
+		}
+	}
+
+	var rows []domain.ImportRow
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		row := domain.ImportRow{Line: line}
+		for i, value := range record {
+			row.Set(header[i], value)
+		}
+		rows = append(rows, row)
+	}
+}
+
+func readImportNDJSON(r io.Reader) ([]domain.ImportRow, error) {
+	var rows []domain.ImportRow
+	err := accountsinfra.ReadNDJSON(r, func(line int, fields map[string]string) error {
+		row := domain.ImportRow{Line: line}
+		for k, v := range fields {
+			if !row.Set(k, v) {
+				return fmt.Errorf("unknown key %q", k)
+			}
+		}
+		rows = append(rows, row)
+		return nil
+	})
+	return rows, err
+}
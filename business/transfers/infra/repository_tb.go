@@ -0,0 +1,56 @@
+// Package infra provides the TigerBeetle-backed transfers repository.
+package infra
+
+import (
+	tb "github.com/tigerbeetle/tigerbeetle-go"
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	"github.com/fd1az/tiger-tui/business/transfers/domain"
+	"github.com/fd1az/tiger-tui/internal/apperror"
+)
+
+// Repository reads transfers from TigerBeetle.
+type Repository struct {
+	client tb.Client
+}
+
+// NewRepository creates a repository over a connected TigerBeetle client.
+func NewRepository(client tb.Client) *Repository {
+	return &Repository{client: client}
+}
+
+// QueryTransfers runs a cluster-wide QueryTransfers request.
+func (r *Repository) QueryTransfers(q domain.TransferQuery) ([]types.Transfer, error) {
+	transfers, err := r.client.QueryTransfers(q.QueryFilter())
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.CodeTBRequestFailed, "query transfers")
+	}
+	return transfers, nil
+}
+
+// GetAccountTransfers runs a GetAccountTransfers request for q.AccountID.
+func (r *Repository) GetAccountTransfers(q domain.TransferQuery) ([]types.Transfer, error) {
+	transfers, err := r.client.GetAccountTransfers(q.AccountFilter())
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.CodeTBRequestFailed, "get account transfers")
+	}
+	return transfers, nil
+}
+
+// LookupTransfers fetches transfers by ID. Unknown IDs are omitted.
+func (r *Repository) LookupTransfers(ids []types.Uint128) ([]types.Transfer, error) {
+	transfers, err := r.client.LookupTransfers(ids)
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.CodeTBRequestFailed, "lookup transfers")
+	}
+	return transfers, nil
+}
+
+// CreateTransfers submits a batch of transfers and returns the failed results.
+func (r *Repository) CreateTransfers(transfers []types.Transfer) ([]types.TransferEventResult, error) {
+	results, err := r.client.CreateTransfers(transfers)
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.CodeTransferCreateFailed, "create transfers")
+	}
+	return results, nil
+}
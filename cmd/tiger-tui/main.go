@@ -6,11 +6,54 @@ import (
 	"os"
 	"syscall"
 
+	"github.com/fd1az/tiger-tui/business/accounts/domain"
+	accountsinfra "github.com/fd1az/tiger-tui/business/accounts/infra"
+	"github.com/fd1az/tiger-tui/internal/config"
 	"github.com/fd1az/tiger-tui/internal/logger"
+	"github.com/fd1az/tiger-tui/pkg/cli"
 	"github.com/fd1az/tiger-tui/pkg/ui"
 )
 
 func main() {
+	// Configuration errors go to the terminal, before stderr is redirected.
+	global, args, err := cli.ParseGlobal(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(cli.ExitUsage)
+	}
+	cfg, err := config.Load("")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	if global.Profile != "" {
+		if err := cfg.SelectProfile(global.Profile); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(cli.ExitUsage)
+		}
+	}
+	if file := cfg.ChartFile(); file != "" {
+		chart, err := accountsinfra.LoadChart(file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error loading chart of accounts: %v\n", err)
+			os.Exit(1)
+		}
+		domain.UseChart(chart)
+	}
+	if err := ui.ApplyTheme(cfg.Theme); err != nil {
+		fmt.Fprintf(os.Stderr, "error loading theme: %v\n", err)
+		os.Exit(1)
+	}
+	if _, err := ui.LoadKeyMap(cfg.Keys); err != nil {
+		fmt.Fprintf(os.Stderr, "error loading key bindings: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Headless subcommands write to the terminal and never start the TUI.
+	if cli.IsCommand(args) {
+		os.Exit(cli.Run(&cli.Env{Config: cfg, Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr}, args))
+	}
+
 	// Logger to file (TUI owns stdout)
 	logFile, err := os.OpenFile("tiger-tui.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
 	if err != nil {
@@ -25,9 +68,9 @@ func main() {
 	syscall.Dup2(int(logFile.Fd()), 2)
 
 	log := logger.New(logFile, logger.LevelInfo, "tiger-tui", nil)
-	log.Info(context.Background(), "starting tiger-tui")
+	log.Info(context.Background(), "starting tiger-tui", "chart", domain.ActiveChart().Name, "profile", cfg.Profile)
 
-	if err := ui.Run(); err != nil {
+	if err := ui.Run(cfg); err != nil {
 		log.Error(context.Background(), "tui error", "error", err)
 		os.Exit(1)
 	}
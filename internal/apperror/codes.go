@@ -38,6 +38,29 @@ const (
 	CodeInsufficientBalance   Code = "INSUFFICIENT_BALANCE"
 )
 
+// Outbox error codes.
+const (
+	CodeOutboxWriteFailed Code = "OUTBOX_WRITE_FAILED"
+	CodeOutboxReadFailed  Code = "OUTBOX_READ_FAILED"
+)
+
+// Import error codes.
+const (
+	CodeImportReadFailed  Code = "IMPORT_READ_FAILED"
+	CodeImportWriteFailed Code = "IMPORT_WRITE_FAILED"
+)
+
+// Export error codes.
+const (
+	CodeExportWriteFailed Code = "EXPORT_WRITE_FAILED"
+)
+
+// Local state error codes.
+const (
+	CodeStateReadFailed  Code = "STATE_READ_FAILED"
+	CodeStateWriteFailed Code = "STATE_WRITE_FAILED"
+)
+
 // Circuit breaker error codes.
 const (
 	CodeCircuitOpen     Code = "CIRCUIT_OPEN"
@@ -30,6 +30,21 @@ var messages = map[Code]string{
 	CodeInvalidLedger:        "Invalid ledger ID",
 	CodeInsufficientBalance:  "Insufficient balance",
 
+	// Outbox
+	CodeOutboxWriteFailed: "Failed to journal write to the outbox",
+	CodeOutboxReadFailed:  "Failed to read the outbox",
+
+	// Import
+	CodeImportReadFailed:  "Failed to read the import file",
+	CodeImportWriteFailed: "Failed to write the import results",
+
+	// Export
+	CodeExportWriteFailed: "Failed to write the export file",
+
+	// Local state
+	CodeStateReadFailed:  "Failed to read local state",
+	CodeStateWriteFailed: "Failed to save local state",
+
 	// Circuit breaker
 	CodeCircuitOpen:     "Circuit breaker is open",
 	CodeCircuitHalfOpen: "Circuit breaker is half-open",
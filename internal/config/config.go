@@ -3,6 +3,12 @@ package config
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -12,12 +18,21 @@ import (
 type Config struct {
 	App         AppConfig         `mapstructure:"app"`
 	TigerBeetle TigerBeetleConfig `mapstructure:"tigerbeetle"`
+	Chart       ChartConfig       `mapstructure:"chart"`
+	Keys        KeysConfig        `mapstructure:"keys"`
+	Theme       ThemeConfig       `mapstructure:"theme"`
+
+	// Profiles are saved connections by name; Profile selects one to
+	// connect with on startup.
+	Profiles map[string]ProfileConfig `mapstructure:"profiles"`
+	Profile  string                   `mapstructure:"profile"`
 }
 
 // AppConfig holds general application settings.
 type AppConfig struct {
 	Name     string `mapstructure:"name"`
 	LogLevel string `mapstructure:"log_level"`
+	StateDir string `mapstructure:"state_dir"` // local state such as the write outbox
 }
 
 // TigerBeetleConfig holds TigerBeetle connection settings.
@@ -28,6 +43,35 @@ type TigerBeetleConfig struct {
 	ConnectTimeout time.Duration `mapstructure:"connect_timeout"`
 }
 
+// ChartConfig selects the chart of accounts. An empty File uses the built-in
+// Terrace ledger-v2 chart.
+type ChartConfig struct {
+	File string `mapstructure:"file"`
+}
+
+// KeysConfig changes the TUI's key bindings. Preset "vim" adds gg, G,
+// Ctrl-d/Ctrl-u and counts such as 5j; Bindings then replaces the keys of
+// single actions, e.g. refresh: [R, f5].
+type KeysConfig struct {
+	Preset   string              `mapstructure:"preset"`
+	Bindings map[string][]string `mapstructure:"bindings"`
+}
+
+// ThemeConfig selects the TUI's colors and symbols.
+type ThemeConfig struct {
+	Name  string `mapstructure:"name"`  // dark or light
+	ASCII bool   `mapstructure:"ascii"` // ASCII-only symbols for constrained terminals
+}
+
+// ProfileConfig is a saved connection, such as local, staging or prod.
+type ProfileConfig struct {
+	ClusterID uint128String `mapstructure:"cluster_id"`
+	Addresses []string      `mapstructure:"addresses"`
+	ReadOnly  bool          `mapstructure:"read_only"`  // refuse creates, imports and posting holds
+	Color     string        `mapstructure:"color"`      // environment color: "#RRGGBB" or an ANSI number
+	ChartFile string        `mapstructure:"chart_file"` // chart of accounts; empty uses chart.file
+}
+
 // uint128String is a string representation of a uint128 cluster ID.
 type uint128String = string
 
@@ -60,10 +104,21 @@ func Load(configPath string) (*Config, error) {
 	if err := v.Unmarshal(&cfg); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
+	for name, p := range cfg.Profiles {
+		if p.ClusterID == "" {
+			p.ClusterID = "0"
+			cfg.Profiles[name] = p
+		}
+	}
 
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
+	if cfg.Profile != "" {
+		if err := cfg.SelectProfile(cfg.Profile); err != nil {
+			return nil, fmt.Errorf("invalid config: %w", err)
+		}
+	}
 
 	return &cfg, nil
 }
@@ -71,25 +126,106 @@ func Load(configPath string) (*Config, error) {
 func bindEnvVars(v *viper.Viper) {
 	v.BindEnv("app.name", "TIGER_APP_NAME")
 	v.BindEnv("app.log_level", "TIGER_LOG_LEVEL", "LOG_LEVEL")
+	v.BindEnv("app.state_dir", "TIGER_STATE_DIR")
 	v.BindEnv("tigerbeetle.cluster_id", "TIGER_TB_CLUSTER_ID", "TB_CLUSTER_ID")
 	v.BindEnv("tigerbeetle.addresses", "TIGER_TB_ADDRESSES", "TB_ADDRESSES")
 	v.BindEnv("tigerbeetle.max_concurrency", "TIGER_TB_MAX_CONCURRENCY")
 	v.BindEnv("tigerbeetle.connect_timeout", "TIGER_TB_CONNECT_TIMEOUT")
+	v.BindEnv("chart.file", "TIGER_CHART_FILE")
+	v.BindEnv("keys.preset", "TIGER_KEYS_PRESET")
+	v.BindEnv("theme.name", "TIGER_THEME")
+	v.BindEnv("theme.ascii", "TIGER_ASCII")
+	v.BindEnv("profile", "TIGER_PROFILE")
 }
 
 func setDefaults(v *viper.Viper) {
 	v.SetDefault("app.name", "tiger-tui")
 	v.SetDefault("app.log_level", "info")
+	v.SetDefault("app.state_dir", defaultStateDir())
 	v.SetDefault("tigerbeetle.cluster_id", "0")
 	v.SetDefault("tigerbeetle.addresses", []string{"3000"})
 	v.SetDefault("tigerbeetle.max_concurrency", 32)
 	v.SetDefault("tigerbeetle.connect_timeout", "5s")
+	v.SetDefault("theme.name", "dark")
 }
 
+// defaultStateDir returns tiger-tui's directory under the user config
+// directory, falling back to a dot directory in the working directory.
+func defaultStateDir() string {
+	if dir, err := os.UserConfigDir(); err == nil {
+		return filepath.Join(dir, "tiger-tui")
+	}
+	return ".tiger-tui"
+}
+
+// OutboxDir returns the directory the write outbox journals to.
+func (c *Config) OutboxDir() string {
+	return filepath.Join(c.App.StateDir, "outbox")
+}
+
+// ProfileNames returns the saved profiles' names in order.
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// SelectProfile makes the profile called name the selected one: its cluster
+// and addresses replace the tigerbeetle section's.
+func (c *Config) SelectProfile(name string) error {
+	p, ok := c.Profiles[strings.ToLower(name)]
+	if !ok {
+		if len(c.Profiles) == 0 {
+			return fmt.Errorf("unknown profile %q: no profiles are configured", name)
+		}
+		return fmt.Errorf("unknown profile %q (have %s)", name, strings.Join(c.ProfileNames(), ", "))
+	}
+	c.Profile = strings.ToLower(name)
+	c.TigerBeetle.ClusterID = p.ClusterID
+	c.TigerBeetle.Addresses = p.Addresses
+	return nil
+}
+
+// SelectedProfile returns the selected profile, and false when connections
+// use the tigerbeetle section.
+func (c *Config) SelectedProfile() (ProfileConfig, bool) {
+	if c.Profile == "" {
+		return ProfileConfig{}, false
+	}
+	p, ok := c.Profiles[c.Profile]
+	return p, ok
+}
+
+// ChartFile returns the chart of accounts file to use: the selected
+// profile's, or chart.file. Empty means the built-in chart.
+func (c *Config) ChartFile() string {
+	if p, ok := c.SelectedProfile(); ok && p.ChartFile != "" {
+		return p.ChartFile
+	}
+	return c.Chart.File
+}
+
+// hexColor matches "#RGB" and "#RRGGBB" colors.
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
 // Validate validates the configuration.
 func (c *Config) Validate() error {
 	if len(c.TigerBeetle.Addresses) == 0 {
 		return fmt.Errorf("tigerbeetle.addresses cannot be empty")
 	}
+	for _, name := range c.ProfileNames() {
+		p := c.Profiles[name]
+		if len(p.Addresses) == 0 {
+			return fmt.Errorf("profiles.%s.addresses cannot be empty", name)
+		}
+		if p.Color != "" && !hexColor.MatchString(p.Color) {
+			if n, err := strconv.Atoi(p.Color); err != nil || n < 0 || n > 255 {
+				return fmt.Errorf("profiles.%s.color %q is not a #RRGGBB color or an ANSI number 0-255", name, p.Color)
+			}
+		}
+	}
 	return nil
 }
@@ -0,0 +1,73 @@
+// Package state persists small JSON documents, such as table layouts, under
+// the application state directory.
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/fd1az/tiger-tui/internal/apperror"
+)
+
+// File is one JSON document in the state directory.
+type File struct {
+	path string
+}
+
+// New returns the document name (e.g. "layout.json") under dir. The
+// directory is created on first save.
+func New(dir, name string) File {
+	return File{path: filepath.Join(dir, name)}
+}
+
+// Path returns the document's location.
+func (f File) Path() string {
+	return f.path
+}
+
+// Load decodes the document into v. A missing document leaves v untouched.
+func (f File) Load(v any) error {
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return apperror.Wrap(err, apperror.CodeStateReadFailed, f.path)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return apperror.Wrap(err, apperror.CodeStateReadFailed, f.path)
+	}
+	return nil
+}
+
+// Save encodes v and replaces the document: it is written under a temporary
+// name and renamed into place, so a crash never leaves it half-written.
+func (f File) Save(v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return apperror.Wrap(err, apperror.CodeStateWriteFailed, f.path)
+	}
+
+	dir := filepath.Dir(f.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return apperror.Wrap(err, apperror.CodeStateWriteFailed, f.path)
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(f.path)+".*")
+	if err != nil {
+		return apperror.Wrap(err, apperror.CodeStateWriteFailed, f.path)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return apperror.Wrap(err, apperror.CodeStateWriteFailed, f.path)
+	}
+	if err := tmp.Close(); err != nil {
+		return apperror.Wrap(err, apperror.CodeStateWriteFailed, f.path)
+	}
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		return apperror.Wrap(err, apperror.CodeStateWriteFailed, f.path)
+	}
+	return nil
+}
@@ -0,0 +1,255 @@
+// Package cli implements tiger-tui's headless subcommands. They share the
+// business services and the internal/config connection settings with the
+// TUI.
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	accountsapp "github.com/fd1az/tiger-tui/business/accounts/app"
+	accountsinfra "github.com/fd1az/tiger-tui/business/accounts/infra"
+	balancesheetapp "github.com/fd1az/tiger-tui/business/balancesheet/app"
+	"github.com/fd1az/tiger-tui/business/connection/infra"
+	lookupapp "github.com/fd1az/tiger-tui/business/lookup/app"
+	outboxapp "github.com/fd1az/tiger-tui/business/outbox/app"
+	outboxinfra "github.com/fd1az/tiger-tui/business/outbox/infra"
+	transfersapp "github.com/fd1az/tiger-tui/business/transfers/app"
+	transfersinfra "github.com/fd1az/tiger-tui/business/transfers/infra"
+	"github.com/fd1az/tiger-tui/internal/config"
+)
+
+// Exit codes.
+const (
+	ExitOK      = 0
+	ExitFailure = 1 // the command ran but something failed
+	ExitUsage   = 2 // bad arguments
+)
+
+// errUsage marks an argument error; the command's usage is printed.
+var errUsage = errors.New("usage")
+
+// errFailed marks a command that reported its own failures and only needs a
+// non-zero exit.
+var errFailed = errors.New("failed")
+
+// Env is what a command runs against.
+type Env struct {
+	Config *config.Config
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// command is one subcommand, named by one or more words.
+type command struct {
+	name    string // e.g. "import transfers"
+	args    string // positional arguments for usage
+	summary string
+	// setup registers the command's flags and returns its run function,
+	// which gets the positional arguments.
+	setup func(fs *flag.FlagSet) func(env *Env, args []string) error
+}
+
+// commands lists the subcommands in help order.
+var commands = []command{
+	accountsListCommand,
+	transfersListCommand,
+	lookupCommand,
+	balanceSheetCommand,
+	createAccountsCommand,
+	createTransfersCommand,
+	importTransfersCommand,
+}
+
+// Global holds the flags that come before a command, or alone for the TUI.
+type Global struct {
+	Profile string // saved connection profile to use
+}
+
+// ParseGlobal parses the global flags at the start of args and returns the
+// arguments after them. Other flags, such as -h, are left for Run.
+func ParseGlobal(args []string) (Global, []string, error) {
+	var g Global
+	for len(args) > 0 && strings.HasPrefix(args[0], "-") {
+		name, value, ok := strings.Cut(strings.TrimLeft(args[0], "-"), "=")
+		if name != "profile" {
+			break
+		}
+		if !ok {
+			if len(args) < 2 {
+				return g, nil, fmt.Errorf("flag needs an argument: -profile")
+			}
+			value, args = args[1], args[1:]
+		}
+		g.Profile = value
+		args = args[1:]
+	}
+	return g, args, nil
+}
+
+// IsCommand reports whether args name a subcommand (or ask for help), so the
+// caller can skip starting the TUI.
+func IsCommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		return true
+	}
+	for _, c := range commands {
+		if strings.Fields(c.name)[0] == args[0] {
+			return true
+		}
+	}
+	return false
+}
+
+// Run runs the subcommand named by args and returns the process exit code.
+func Run(env *Env, args []string) int {
+	if len(args) == 0 || args[0] == "help" || strings.HasPrefix(args[0], "-") {
+		usage(env.Stdout)
+		return ExitOK
+	}
+
+	c, rest, ok := find(args)
+	if !ok {
+		fmt.Fprintf(env.Stderr, "unknown command %q\n\n", strings.Join(args, " "))
+		usage(env.Stderr)
+		return ExitUsage
+	}
+
+	fs := flag.NewFlagSet("tiger-tui "+c.name, flag.ContinueOnError)
+	fs.SetOutput(env.Stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(env.Stderr, "usage: tiger-tui %s [flags] %s\n\n%s\n", c.name, c.args, c.summary)
+		fs.PrintDefaults()
+	}
+	run := c.setup(fs)
+	positional, err := parseInterspersed(fs, rest)
+	if errors.Is(err, flag.ErrHelp) {
+		return ExitOK
+	}
+	if err != nil {
+		return ExitUsage
+	}
+
+	switch err := run(env, positional); {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, errUsage):
+		fs.Usage()
+		return ExitUsage
+	case errors.Is(err, errFailed):
+		return ExitFailure
+	default:
+		fmt.Fprintf(env.Stderr, "error: %v\n", err)
+		return ExitFailure
+	}
+}
+
+// find returns the command whose name words prefix args, and the remaining
+// arguments.
+func find(args []string) (command, []string, bool) {
+	for _, c := range commands {
+		words := strings.Fields(c.name)
+		if len(args) < len(words) {
+			continue
+		}
+		match := true
+		for i, w := range words {
+			if args[i] != w {
+				match = false
+				break
+			}
+		}
+		if match {
+			return c, args[len(words):], true
+		}
+	}
+	return command{}, nil, false
+}
+
+// parseInterspersed parses flags that may appear before, between or after
+// positional arguments, which flag.FlagSet alone stops at.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: tiger-tui [--profile name] [command]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Without a command, tiger-tui starts the terminal UI. --profile uses a")
+	fmt.Fprintln(w, "saved connection profile; the TUI connects with it on startup.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-36s %s\n", c.name+" "+c.args, c.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'tiger-tui <command> -h' for a command's flags.")
+}
+
+// session is a connection with the services commands use. Writes are
+// journaled through the outbox like the TUI's.
+type session struct {
+	client       *infra.Client
+	accounts     *accountsapp.Service
+	transfers    *transfersapp.Service
+	lookup       *lookupapp.Service
+	balanceSheet *balancesheetapp.Service
+}
+
+// connectForWrites is connect for commands that submit writes, which a
+// read-only profile refuses.
+func connectForWrites(cfg *config.Config) (*session, error) {
+	if p, ok := cfg.SelectedProfile(); ok && p.ReadOnly {
+		return nil, fmt.Errorf("profile %s is read-only; writes are disabled", cfg.Profile)
+	}
+	return connect(cfg)
+}
+
+// connect opens a session using the configured cluster and addresses, or
+// those of the selected profile.
+func connect(cfg *config.Config) (*session, error) {
+	client, err := infra.Connect(cfg.TigerBeetle.ClusterID, cfg.TigerBeetle.Addresses)
+	if err != nil {
+		return nil, err
+	}
+	accountsRepo := accountsinfra.NewRepository(client.Raw())
+	transfersRepo := transfersinfra.NewRepository(client.Raw())
+	ob, err := outboxapp.NewService(outboxinfra.NewFileStore(cfg.OutboxDir()), client.ClusterID(), accountsRepo, transfersRepo)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	accounts := accountsapp.NewService(ob.JournalAccounts(accountsRepo))
+	transfers := transfersapp.NewService(ob.JournalTransfers(transfersRepo))
+	return &session{
+		client:       client,
+		accounts:     accounts,
+		transfers:    transfers,
+		lookup:       lookupapp.NewService(accounts, transfers),
+		balanceSheet: balancesheetapp.NewService(accounts),
+	}, nil
+}
+
+// Close closes the connection.
+func (s *session) Close() {
+	s.client.Close()
+}
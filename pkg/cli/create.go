@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+
+	accountsdomain "github.com/fd1az/tiger-tui/business/accounts/domain"
+	accountsinfra "github.com/fd1az/tiger-tui/business/accounts/infra"
+	transfersdomain "github.com/fd1az/tiger-tui/business/transfers/domain"
+	transfersinfra "github.com/fd1az/tiger-tui/business/transfers/infra"
+)
+
+var createTransfersCommand = command{
+	name:    "create transfers",
+	summary: "Create transfers from NDJSON on stdin, printing one result per line",
+	setup: func(fs *flag.FlagSet) func(*Env, []string) error {
+		dryRun := fs.Bool("dry-run", false, "validate against the chart without submitting")
+		linked := fs.Bool("linked", false, "chain the whole input so it succeeds or fails atomically")
+		return func(env *Env, args []string) error {
+			if len(args) != 0 {
+				return errUsage
+			}
+			rows, err := transfersinfra.ReadImportNDJSON(env.Stdin, "stdin")
+			if err != nil {
+				return err
+			}
+			plan := transfersdomain.PrepareImport(rows)
+			if *linked {
+				if err := plan.Link(); err != nil {
+					return err
+				}
+			}
+			return create(env, plan.Validated(), func(s *session) (transfersdomain.ImportReport, error) {
+				return s.transfers.Import(plan, false)
+			}, plan.Invalid == 0 && !*dryRun)
+		}
+	},
+}
+
+var createAccountsCommand = command{
+	name:    "create accounts",
+	summary: "Create accounts from NDJSON on stdin, printing one result per line",
+	setup: func(fs *flag.FlagSet) func(*Env, []string) error {
+		dryRun := fs.Bool("dry-run", false, "validate against the chart without submitting")
+		linked := fs.Bool("linked", false, "chain the whole input so it succeeds or fails atomically")
+		return func(env *Env, args []string) error {
+			if len(args) != 0 {
+				return errUsage
+			}
+			rows, err := accountsinfra.ReadImportNDJSON(env.Stdin, "stdin")
+			if err != nil {
+				return err
+			}
+			plan := accountsdomain.PrepareImport(rows)
+			if *linked {
+				if err := plan.Link(); err != nil {
+					return err
+				}
+			}
+			return create(env, plan.Validated(), func(s *session) (accountsdomain.ImportReport, error) {
+				return s.accounts.Import(plan, false)
+			}, plan.Invalid == 0 && !*dryRun)
+		}
+	},
+}
+
+// create submits validated input when submit is set (every row valid and
+// not a dry run), prints one NDJSON result per input line to stdout and the
+// summary to stderr. It fails if any event was not created or already
+// present.
+func create(env *Env, report accountsdomain.ImportReport, run func(*session) (accountsdomain.ImportReport, error), submit bool) error {
+	var err error
+	if submit {
+		s, cerr := connectForWrites(env.Config)
+		if cerr != nil {
+			return cerr
+		}
+		defer s.Close()
+		report, err = run(s)
+	}
+
+	if werr := accountsinfra.WriteImportNDJSON(env.Stdout, report.Results); werr != nil {
+		return werr
+	}
+	fmt.Fprintln(env.Stderr, report.Summary())
+
+	if err != nil {
+		return err
+	}
+	if report.Failures() > 0 {
+		return errFailed
+	}
+	return nil
+}
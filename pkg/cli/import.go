@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/fd1az/tiger-tui/business/transfers/domain"
+	transfersinfra "github.com/fd1az/tiger-tui/business/transfers/infra"
+)
+
+var importTransfersCommand = command{
+	name:    "import transfers",
+	args:    "<file>",
+	summary: "Validate and create transfers from a CSV or NDJSON file",
+	setup: func(fs *flag.FlagSet) func(*Env, []string) error {
+		resultsPath := fs.String("results", "", "results file (default: <file>.results.<ext>)")
+		dryRun := fs.Bool("dry-run", false, "validate every row without submitting")
+		return func(env *Env, args []string) error {
+			if len(args) != 1 {
+				return errUsage
+			}
+			return importTransfers(env, args[0], *resultsPath, *dryRun)
+		}
+	},
+}
+
+// importTransfers validates every row offline, submits them when all are
+// valid, and writes one result per row. It fails if any row was not created
+// or already present.
+func importTransfers(env *Env, path, resultsPath string, dryRun bool) error {
+	if resultsPath == "" {
+		resultsPath = transfersinfra.ImportResultsPath(path)
+	}
+
+	rows, err := transfersinfra.ReadImportFile(path)
+	if err != nil {
+		return err
+	}
+
+	// Invalid files and dry runs never need a connection.
+	plan := domain.PrepareImport(rows)
+	report := plan.Validated()
+	if plan.Invalid == 0 && !dryRun {
+		s, cerr := connectForWrites(env.Config)
+		if cerr != nil {
+			return cerr
+		}
+		defer s.Close()
+		report, err = s.transfers.Import(plan, false)
+	}
+	if werr := transfersinfra.WriteImportResults(resultsPath, report.Results); werr != nil {
+		fmt.Fprintf(env.Stderr, "error: %v\n", werr)
+		resultsPath = ""
+	}
+
+	for _, r := range report.Results {
+		switch {
+		case r.Error != "":
+			fmt.Fprintf(env.Stderr, "line %d: %s\n", r.Line, r.Error)
+		case !r.OK() && r.Result != "exists" && r.Result != domain.ImportValid && r.Result != domain.ImportNotSubmitted:
+			fmt.Fprintf(env.Stderr, "line %d: %s\n", r.Line, r.Result)
+		}
+	}
+	fmt.Fprintln(env.Stdout, report.Summary())
+	if resultsPath != "" {
+		fmt.Fprintf(env.Stdout, "results written to %s\n", resultsPath)
+	}
+
+	if err != nil {
+		return err
+	}
+	if report.Failures() > 0 {
+		return errFailed
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	exportdomain "github.com/fd1az/tiger-tui/business/export/domain"
+	exportinfra "github.com/fd1az/tiger-tui/business/export/infra"
+)
+
+// output receives a command's rows a page at a time.
+type output interface {
+	Write(rows [][]string) error
+	Close() error
+}
+
+// column is a table column.
+type column struct {
+	title string
+	right bool // right-align, for amounts and counts
+}
+
+// newOutput returns a JSON array writer over jsonColumns when asJSON is set,
+// using the same fields as exports, or a table with the given columns.
+func newOutput(w io.Writer, asJSON bool, jsonColumns []string, columns []column) (output, error) {
+	if asJSON {
+		return exportinfra.NewStreamSink(w, "stdout", exportdomain.JSON, jsonColumns)
+	}
+	return &table{w: w, columns: columns}, nil
+}
+
+// table buffers rows and prints them with aligned columns on Close.
+type table struct {
+	w       io.Writer
+	columns []column
+	rows    [][]string
+}
+
+func (t *table) Write(rows [][]string) error {
+	t.rows = append(t.rows, rows...)
+	return nil
+}
+
+func (t *table) Close() error {
+	widths := make([]int, len(t.columns))
+	for i, c := range t.columns {
+		widths[i] = utf8.RuneCountInString(c.title)
+	}
+	for _, r := range t.rows {
+		for i, v := range r {
+			widths[i] = max(widths[i], utf8.RuneCountInString(v))
+		}
+	}
+
+	titles := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		titles[i] = strings.ToUpper(c.title)
+	}
+	var sb strings.Builder
+	t.line(&sb, titles, widths)
+	for _, r := range t.rows {
+		t.line(&sb, r, widths)
+	}
+	_, err := io.WriteString(t.w, sb.String())
+	return err
+}
+
+func (t *table) line(sb *strings.Builder, values []string, widths []int) {
+	var cells []string
+	for i, v := range values {
+		pad := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(v))
+		if t.columns[i].right {
+			cells = append(cells, pad+v)
+		} else {
+			cells = append(cells, v+pad)
+		}
+	}
+	fmt.Fprintln(sb, strings.TrimRight(strings.Join(cells, "  "), " "))
+}
@@ -0,0 +1,442 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	accountsapp "github.com/fd1az/tiger-tui/business/accounts/app"
+	"github.com/fd1az/tiger-tui/business/accounts/domain"
+	bsdomain "github.com/fd1az/tiger-tui/business/balancesheet/domain"
+	exportdomain "github.com/fd1az/tiger-tui/business/export/domain"
+	exportinfra "github.com/fd1az/tiger-tui/business/export/infra"
+	filterdomain "github.com/fd1az/tiger-tui/business/filter/domain"
+	transfersapp "github.com/fd1az/tiger-tui/business/transfers/app"
+	transfersdomain "github.com/fd1az/tiger-tui/business/transfers/domain"
+)
+
+// filters are the query flags shared by the list commands. Values are chart
+// names or numbers.
+type filters struct {
+	ledger, code, venue *string
+	limit               *int
+	newest              *bool
+}
+
+func addFilters(fs *flag.FlagSet, codeName string) filters {
+	return filters{
+		ledger: fs.String("ledger", "", "only this ledger (symbol or ID)"),
+		code:   fs.String("type", "", "only this "+codeName+" type (name or code)"),
+		venue:  fs.String("venue", "", "only this venue (name or ID)"),
+		limit:  fs.Int("limit", 0, "stop after this many rows (0: all)"),
+		newest: fs.Bool("newest", false, "newest first"),
+	}
+}
+
+// plan compiles the filter values for target the way the filter builder
+// compiles its chips: what TigerBeetle's filter can express is pushed down,
+// and the rest (a ledger on one account's transfers, venue 0, which the
+// filter reads as no venue) is matched on each page.
+func (f filters) plan(target filterdomain.Target) (filterdomain.Plan, error) {
+	if *f.limit < 0 {
+		return filterdomain.Plan{}, fmt.Errorf("-limit must not be negative")
+	}
+	var chips []filterdomain.Chip
+	for _, c := range []filterdomain.Chip{
+		{Key: "ledger", Op: "=", Value: *f.ledger},
+		{Key: "code", Op: "=", Value: *f.code},
+		{Key: "venue", Op: "=", Value: *f.venue},
+	} {
+		if c.Value != "" {
+			chips = append(chips, c)
+		}
+	}
+	return filterdomain.Compile(chips, target, time.Now())
+}
+
+// pageLimit is the page size for a listing that stops after limit rows.
+func pageLimit(limit int) uint32 {
+	if limit > 0 && limit < domain.MaxBatchSize {
+		return uint32(limit)
+	}
+	return domain.MaxBatchSize
+}
+
+var accountsListCommand = command{
+	name:    "accounts list",
+	summary: "List accounts, oldest first",
+	setup: func(fs *flag.FlagSet) func(*Env, []string) error {
+		f := addFilters(fs, "account")
+		asJSON := fs.Bool("json", false, "print a JSON array with the export fields")
+		return func(env *Env, args []string) error {
+			if len(args) != 0 {
+				return errUsage
+			}
+			plan, err := f.plan(filterdomain.Accounts)
+			if err != nil {
+				return err
+			}
+			q := plan.Accounts(domain.AccountQuery{Reversed: *f.newest})
+			return listAccounts(env, q, *f.limit, *asJSON)
+		}
+	},
+}
+
+var accountColumns = []column{
+	{title: "ID"}, {title: "Type"}, {title: "Ledger"},
+	{title: "Debits Posted", right: true}, {title: "Credits Posted", right: true},
+	{title: "Debits Pending", right: true}, {title: "Credits Pending", right: true},
+	{title: "Balance", right: true},
+}
+
+func accountRow(a types.Account) []string {
+	balance := domain.NetBalance(a.Flags, a.DebitsPosted, a.CreditsPosted)
+	return []string{
+		domain.FormatID(a.ID),
+		domain.AccountTypeName(a.Code),
+		domain.LedgerLabel(a.Ledger),
+		domain.FormatAmount(a.DebitsPosted, a.Ledger),
+		domain.FormatAmount(a.CreditsPosted, a.Ledger),
+		domain.FormatAmount(a.DebitsPending, a.Ledger),
+		domain.FormatAmount(a.CreditsPending, a.Ledger),
+		signed(balance, a.Ledger),
+	}
+}
+
+func listAccounts(env *Env, q domain.AccountQuery, limit int, asJSON bool) error {
+	row := accountRow
+	if asJSON {
+		row = exportdomain.AccountRecord
+	}
+	out, err := newOutput(env.Stdout, asJSON, exportdomain.AccountColumns, accountColumns)
+	if err != nil {
+		return err
+	}
+
+	s, err := connect(env.Config)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	err = pageAccounts(s.accounts, q, limit, func(page []types.Account) error {
+		rows := make([][]string, len(page))
+		for i, a := range page {
+			rows[i] = row(a)
+		}
+		return out.Write(rows)
+	})
+	if err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// pageAccounts calls fn with each page of accounts matching q until limit
+// accounts (0: all) have been seen.
+func pageAccounts(svc *accountsapp.Service, q domain.AccountQuery, limit int, fn func([]types.Account) error) error {
+	q.Limit = pageLimit(limit)
+	for n := 0; ; {
+		page, err := svc.LoadPage(q)
+		if err != nil {
+			return err
+		}
+		accounts := page.Accounts
+		if limit > 0 && n+len(accounts) > limit {
+			accounts = accounts[:limit-n]
+		}
+		if err := fn(accounts); err != nil {
+			return err
+		}
+		n += len(accounts)
+		if !page.HasMore || (limit > 0 && n >= limit) {
+			return nil
+		}
+		q = page.Next
+	}
+}
+
+var transfersListCommand = command{
+	name:    "transfers list",
+	summary: "List transfers, oldest first, for the cluster or one account",
+	setup: func(fs *flag.FlagSet) func(*Env, []string) error {
+		account := fs.String("account", "", "only transfers of this account (decimal, 0x hex or UUID)")
+		f := addFilters(fs, "transfer")
+		asJSON := fs.Bool("json", false, "print a JSON array with the export fields")
+		return func(env *Env, args []string) error {
+			if len(args) != 0 {
+				return errUsage
+			}
+			var accountID types.Uint128
+			target := filterdomain.Transfers
+			if *account != "" {
+				var err error
+				if accountID, err = domain.ParseID(*account); err != nil {
+					return fmt.Errorf("-account: %w", err)
+				}
+				target = filterdomain.AccountTransfers
+			}
+			plan, err := f.plan(target)
+			if err != nil {
+				return err
+			}
+			q := plan.Transfers(transfersdomain.TransferQuery{AccountID: accountID, Reversed: *f.newest})
+			return listTransfers(env, q, *f.limit, *asJSON)
+		}
+	},
+}
+
+var transferColumns = []column{
+	{title: "ID"}, {title: "Debit Account"}, {title: "Credit Account"},
+	{title: "Amount", right: true}, {title: "Ledger"}, {title: "Type"},
+	{title: "Venue"}, {title: "Flags"}, {title: "Time"},
+}
+
+func transferRow(t types.Transfer) []string {
+	return []string{
+		domain.FormatID(t.ID),
+		domain.FormatID(t.DebitAccountID),
+		domain.FormatID(t.CreditAccountID),
+		domain.FormatAmount(t.Amount, t.Ledger),
+		domain.LedgerLabel(t.Ledger),
+		domain.TransferTypeName(t.Code),
+		domain.VenueName(t.UserData32),
+		strings.Join(transfersdomain.TransferFlagNames(t.Flags), "|"),
+		domain.FormatTimestamp(t.Timestamp),
+	}
+}
+
+func listTransfers(env *Env, q transfersdomain.TransferQuery, limit int, asJSON bool) error {
+	row := transferRow
+	if asJSON {
+		row = exportdomain.TransferRecord
+	}
+	out, err := newOutput(env.Stdout, asJSON, exportdomain.TransferColumns, transferColumns)
+	if err != nil {
+		return err
+	}
+
+	s, err := connect(env.Config)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	err = pageTransfers(s.transfers, q, limit, func(page []types.Transfer) error {
+		rows := make([][]string, len(page))
+		for i, t := range page {
+			rows[i] = row(t)
+		}
+		return out.Write(rows)
+	})
+	if err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// pageTransfers calls fn with each page of transfers matching q until limit
+// transfers (0: all) have been seen.
+func pageTransfers(svc *transfersapp.Service, q transfersdomain.TransferQuery, limit int, fn func([]types.Transfer) error) error {
+	q.Limit = pageLimit(limit)
+	for n := 0; ; {
+		page, err := svc.LoadPage(q)
+		if err != nil {
+			return err
+		}
+		transfers := page.Transfers
+		if limit > 0 && n+len(transfers) > limit {
+			transfers = transfers[:limit-n]
+		}
+		if err := fn(transfers); err != nil {
+			return err
+		}
+		n += len(transfers)
+		if !page.HasMore || (limit > 0 && n >= limit) {
+			return nil
+		}
+		q = page.Next
+	}
+}
+
+var lookupCommand = command{
+	name:    "lookup",
+	args:    "<id>",
+	summary: "Show the account and/or transfer with an ID",
+	setup: func(fs *flag.FlagSet) func(*Env, []string) error {
+		asJSON := fs.Bool("json", false, "print a JSON array of matches with the export fields")
+		return func(env *Env, args []string) error {
+			if len(args) != 1 {
+				return errUsage
+			}
+			id, err := domain.ParseID(args[0])
+			if err != nil {
+				return err
+			}
+			return lookup(env, id, *asJSON)
+		}
+	},
+}
+
+// lookup prints every field of each match for id; accounts and transfers
+// have separate ID spaces, so both may match. With asJSON each match is one
+// object on its own line, its first key "kind". Finding nothing is an error.
+func lookup(env *Env, id types.Uint128, asJSON bool) error {
+	s, err := connect(env.Config)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	res, err := s.lookup.Lookup(id)
+	if err != nil {
+		return err
+	}
+
+	type match struct {
+		kind    string
+		columns []string
+		record  []string
+	}
+	var matches []match
+	if a := res.Account; a != nil {
+		matches = append(matches, match{"account", exportdomain.AccountColumns, exportdomain.AccountRecord(*a)})
+	}
+	if t := res.Transfer; t != nil {
+		matches = append(matches, match{"transfer", exportdomain.TransferColumns, exportdomain.TransferRecord(*t)})
+	}
+
+	for i, m := range matches {
+		if asJSON {
+			out, err := exportinfra.NewStreamSink(env.Stdout, "stdout", exportdomain.NDJSON, append([]string{"kind"}, m.columns...))
+			if err == nil {
+				err = out.Write([][]string{append([]string{m.kind}, m.record...)})
+			}
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if i > 0 {
+			fmt.Fprintln(env.Stdout)
+		}
+		out := &table{w: env.Stdout, columns: []column{{title: m.kind}, {title: "Value"}}}
+		for j, c := range m.columns {
+			out.rows = append(out.rows, []string{c, m.record[j]})
+		}
+		if err := out.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var balanceSheetCommand = command{
+	name:    "balance-sheet",
+	summary: "Print per-ledger totals by account type and the trial balance",
+	setup: func(fs *flag.FlagSet) func(*Env, []string) error {
+		asJSON := fs.Bool("json", false, "print a JSON array with the export fields")
+		return func(env *Env, args []string) error {
+			if len(args) != 0 {
+				return errUsage
+			}
+			return balanceSheet(env, *asJSON)
+		}
+	},
+}
+
+var balanceSheetColumns = []column{
+	{title: "Ledger"}, {title: "Type"}, {title: "Accounts", right: true},
+	{title: "Debits Posted", right: true}, {title: "Credits Posted", right: true},
+	{title: "Debits Pending", right: true}, {title: "Credits Pending", right: true},
+	{title: "Verdict"},
+}
+
+// balanceSheet prints the balance sheet. It fails if any ledger does not
+// balance, so a cron job can alert on the exit code.
+func balanceSheet(env *Env, asJSON bool) error {
+	s, err := connect(env.Config)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	summary, err := s.balanceSheet.Load()
+	if err != nil {
+		return err
+	}
+
+	out, err := newOutput(env.Stdout, asJSON, exportdomain.BalanceSheetColumns, balanceSheetColumns)
+	if err != nil {
+		return err
+	}
+	rows := exportdomain.BalanceSheetRecords(summary)
+	if !asJSON {
+		rows = balanceSheetRows(summary)
+	}
+	if err := out.Write(rows); err != nil {
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	if n := summary.Unbalanced(); n > 0 {
+		fmt.Fprintf(env.Stderr, "%d of %d ledgers unbalanced\n", n, len(summary.Ledgers))
+		return errFailed
+	}
+	return nil
+}
+
+func balanceSheetRows(summary bsdomain.Summary) [][]string {
+	var rows [][]string
+	for _, l := range summary.Ledgers {
+		ledger := domain.LedgerLabel(l.Ledger)
+		for _, tt := range l.Types {
+			rows = append(rows, totalsRow(ledger, domain.AccountTypeName(tt.Code), tt.Accounts, tt.Totals, l.Ledger, ""))
+		}
+		rows = append(rows, totalsRow(ledger, "TOTAL", l.Accounts, l.Total, l.Ledger, verdictText(l)))
+	}
+	return rows
+}
+
+func totalsRow(ledger, name string, n int, t bsdomain.Totals, id uint32, verdict string) []string {
+	return []string{
+		ledger, name, strconv.Itoa(n),
+		domain.FormatBigAmount(t.DebitsPosted, id),
+		domain.FormatBigAmount(t.CreditsPosted, id),
+		domain.FormatBigAmount(t.DebitsPending, id),
+		domain.FormatBigAmount(t.CreditsPending, id),
+		verdict,
+	}
+}
+
+// verdictText describes a ledger's trial balance, including the exact
+// discrepancy when it does not balance.
+func verdictText(l bsdomain.LedgerSummary) string {
+	if l.Verdict == bsdomain.Balanced {
+		return "balanced"
+	}
+	text := "unbalanced:"
+	if l.PostedDiscrepancy.Sign() != 0 {
+		text += " posted Dr-Cr " + signed(l.PostedDiscrepancy, l.Ledger)
+	}
+	if l.PendingDiscrepancy.Sign() != 0 {
+		text += " pending Dr-Cr " + signed(l.PendingDiscrepancy, l.Ledger)
+	}
+	return text
+}
+
+// signed formats a possibly negative minor-unit value.
+func signed(n *big.Int, ledger uint32) string {
+	if n.Sign() < 0 {
+		return "-" + domain.FormatBigAmount(new(big.Int).Neg(n), ledger)
+	}
+	return domain.FormatBigAmount(n, ledger)
+}
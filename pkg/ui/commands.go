@@ -1,18 +1,223 @@
 package ui
 
 import (
+	"time"
+
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
 
+	accountsapp "github.com/fd1az/tiger-tui/business/accounts/app"
+	"github.com/fd1az/tiger-tui/business/accounts/domain"
+	accountsinfra "github.com/fd1az/tiger-tui/business/accounts/infra"
+	balancesheetapp "github.com/fd1az/tiger-tui/business/balancesheet/app"
 	"github.com/fd1az/tiger-tui/business/connection/infra"
+	exportapp "github.com/fd1az/tiger-tui/business/export/app"
+	exportdomain "github.com/fd1az/tiger-tui/business/export/domain"
+	exportinfra "github.com/fd1az/tiger-tui/business/export/infra"
+	lookupapp "github.com/fd1az/tiger-tui/business/lookup/app"
+	outboxapp "github.com/fd1az/tiger-tui/business/outbox/app"
+	transfersapp "github.com/fd1az/tiger-tui/business/transfers/app"
+	transfersdomain "github.com/fd1az/tiger-tui/business/transfers/domain"
+	transfersinfra "github.com/fd1az/tiger-tui/business/transfers/infra"
+	"github.com/fd1az/tiger-tui/internal/state"
+	"github.com/fd1az/tiger-tui/pkg/ui/components"
 )
 
-// ConnectCmd returns a tea.Cmd that connects to TigerBeetle.
-func ConnectCmd(clusterID string, address string) tea.Cmd {
+// ConnectCmd returns a tea.Cmd that loads the connection's chart of
+// accounts, the built-in one for an empty chartFile, and connects to
+// TigerBeetle.
+func ConnectCmd(clusterID string, addresses []string, chartFile string) tea.Cmd {
 	return func() tea.Msg {
-		client, err := infra.Connect(clusterID, []string{address})
+		chart := domain.DefaultChart()
+		if chartFile != "" {
+			var err error
+			if chart, err = accountsinfra.LoadChart(chartFile); err != nil {
+				return ConnectionFailedMsg{Err: err}
+			}
+		}
+		client, err := infra.Connect(clusterID, addresses)
 		if err != nil {
 			return ConnectionFailedMsg{Err: err}
 		}
-		return ConnectedMsg{Client: client}
+		return ConnectedMsg{Client: client, Chart: chart}
+	}
+}
+
+// LoadAccountsCmd returns a tea.Cmd that loads one page of accounts.
+func LoadAccountsCmd(svc *accountsapp.Service, q domain.AccountQuery, appendRows bool) tea.Cmd {
+	return func() tea.Msg {
+		page, err := svc.LoadPage(q)
+		if err != nil {
+			return AccountsLoadFailedMsg{Err: err}
+		}
+		return AccountsLoadedMsg{Page: page, Append: appendRows}
+	}
+}
+
+// LoadAccountHistoryCmd returns a tea.Cmd that loads an account's balance
+// snapshots via GetAccountBalances.
+func LoadAccountHistoryCmd(svc *accountsapp.Service, accountID types.Uint128) tea.Cmd {
+	return func() tea.Msg {
+		balances, err := svc.LoadHistory(accountID)
+		if err != nil {
+			return AccountHistoryLoadFailedMsg{AccountID: accountID, Err: err}
+		}
+		return AccountHistoryLoadedMsg{AccountID: accountID, Balances: balances}
+	}
+}
+
+// CreateAccountsCmd returns a tea.Cmd that submits accounts via CreateAccounts.
+func CreateAccountsCmd(svc *accountsapp.Service, accounts []types.Account) tea.Cmd {
+	return func() tea.Msg {
+		outcomes, err := svc.Create(accounts)
+		if err != nil {
+			return AccountsCreateFailedMsg{Err: err}
+		}
+		return AccountsCreatedMsg{Outcomes: outcomes}
+	}
+}
+
+// LoadTransfersCmd returns a tea.Cmd that loads one page of transfers.
+func LoadTransfersCmd(svc *transfersapp.Service, q transfersdomain.TransferQuery, appendRows bool) tea.Cmd {
+	return func() tea.Msg {
+		page, err := svc.LoadPage(q)
+		if err != nil {
+			return TransfersLoadFailedMsg{Err: err}
+		}
+		return TransfersLoadedMsg{Page: page, Append: appendRows}
+	}
+}
+
+// CreateTransfersCmd returns a tea.Cmd that submits transfers via CreateTransfers.
+func CreateTransfersCmd(svc *transfersapp.Service, transfers []types.Transfer) tea.Cmd {
+	return func() tea.Msg {
+		outcomes, err := svc.Create(transfers)
+		if err != nil {
+			return TransfersCreateFailedMsg{Err: err}
+		}
+		return TransfersCreatedMsg{Outcomes: outcomes}
+	}
+}
+
+// ImportTransfersCmd returns a tea.Cmd that reads an import file, validates
+// and submits its rows, and writes the per-row results next to it.
+func ImportTransfersCmd(svc *transfersapp.Service, path string, dryRun bool) tea.Cmd {
+	return func() tea.Msg {
+		rows, err := transfersinfra.ReadImportFile(path)
+		if err != nil {
+			return TransfersImportFailedMsg{Err: err}
+		}
+		report, err := svc.Import(transfersdomain.PrepareImport(rows), dryRun)
+		resultsPath := transfersinfra.ImportResultsPath(path)
+		if werr := transfersinfra.WriteImportResults(resultsPath, report.Results); werr != nil {
+			resultsPath = ""
+			if err == nil {
+				err = werr
+			}
+		}
+		if err != nil {
+			return TransfersImportFailedMsg{Report: report, ResultsPath: resultsPath, Err: err}
+		}
+		return TransfersImportedMsg{Report: report, ResultsPath: resultsPath}
+	}
+}
+
+// NewExportJob creates an export of src to a file; the file is created by
+// the job's first step.
+func NewExportJob(view, path string, format exportdomain.Format, columns []string, src exportapp.Source) *exportapp.Job {
+	return exportapp.NewJob(view, path, src, func() (exportapp.Sink, error) {
+		return exportinfra.NewFileSink(path, format, columns)
+	})
+}
+
+// ExportStepCmd returns a tea.Cmd that writes the next page of an export.
+func ExportStepCmd(job *exportapp.Job) tea.Cmd {
+	return func() tea.Msg {
+		return exportStep(job)
+	}
+}
+
+func exportStep(job *exportapp.Job) tea.Msg {
+	done, err := job.Step()
+	switch {
+	case err != nil:
+		return ExportFailedMsg{Job: job, Err: err}
+	case done:
+		return ExportDoneMsg{Job: job, Rows: job.Rows()}
+	}
+	return ExportProgressMsg{Job: job, Rows: job.Rows()}
+}
+
+// LoadHoldsCmd returns a tea.Cmd that scans for open pending transfers.
+func LoadHoldsCmd(svc *transfersapp.Service) tea.Cmd {
+	return func() tea.Msg {
+		holds, err := svc.LoadHolds()
+		if err != nil {
+			return HoldsLoadFailedMsg{Err: err}
+		}
+		return HoldsLoadedMsg{Holds: holds}
+	}
+}
+
+// ResolvePendingCmd returns a tea.Cmd that submits a post- or void-pending
+// transfer.
+func ResolvePendingCmd(svc *transfersapp.Service, transfer types.Transfer) tea.Cmd {
+	return func() tea.Msg {
+		outcomes, err := svc.Create([]types.Transfer{transfer})
+		if err != nil {
+			return PendingResolveFailedMsg{Err: err}
+		}
+		return PendingResolvedMsg{Outcome: outcomes[0]}
+	}
+}
+
+// PendingTickCmd schedules the next pending transfers countdown refresh.
+func PendingTickCmd() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return PendingTickMsg(t)
+	})
+}
+
+// LookupCmd returns a tea.Cmd that looks an ID up among accounts and transfers.
+func LookupCmd(svc *lookupapp.Service, id types.Uint128) tea.Cmd {
+	return func() tea.Msg {
+		res, err := svc.Lookup(id)
+		if err != nil {
+			return LookupFailedMsg{Err: err}
+		}
+		return LookupDoneMsg{Result: res}
+	}
+}
+
+// ResubmitOutboxCmd returns a tea.Cmd that resends pending outbox entries.
+func ResubmitOutboxCmd(svc *outboxapp.Service) tea.Cmd {
+	return func() tea.Msg {
+		report, err := svc.Resubmit()
+		if err != nil {
+			return OutboxResubmitFailedMsg{Report: report, Err: err}
+		}
+		return OutboxResubmittedMsg{Report: report}
+	}
+}
+
+// LoadBalanceSheetCmd returns a tea.Cmd that aggregates every account into a
+// per-ledger balance sheet.
+func LoadBalanceSheetCmd(svc *balancesheetapp.Service) tea.Cmd {
+	return func() tea.Msg {
+		summary, err := svc.Load()
+		if err != nil {
+			return BalanceSheetLoadFailedMsg{Err: err}
+		}
+		return BalanceSheetLoadedMsg{Summary: summary}
+	}
+}
+
+// SaveLayoutsCmd returns a tea.Cmd that persists the table layouts.
+func SaveLayoutsCmd(f state.File, layouts map[string]components.Layout) tea.Cmd {
+	return func() tea.Msg {
+		if err := f.Save(layouts); err != nil {
+			return ErrorMsg{Err: err}
+		}
+		return nil
 	}
 }
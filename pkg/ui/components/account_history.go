@@ -0,0 +1,157 @@
+package components
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	"github.com/fd1az/tiger-tui/business/accounts/domain"
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
+)
+
+// AccountHistory is the account detail panel showing balance history from
+// GetAccountBalances as a sparkline and a table of snapshots.
+type AccountHistory struct {
+	account  types.Account
+	balances []types.AccountBalance
+	table    Table
+	loading  bool
+	errMsg   string
+	width    int
+	height   int
+}
+
+// NewAccountHistory creates an empty history panel.
+func NewAccountHistory() AccountHistory {
+	return AccountHistory{
+		table: NewTable([]Column{
+			{Title: "Timestamp", Width: 23},
+			{Title: "Debits Posted", Width: 20, Right: true},
+			{Title: "Credits Posted", Width: 20, Right: true},
+			{Title: "Debits Pending", Width: 20, Right: true},
+			{Title: "Credits Pending", Width: 20, Right: true},
+			{Title: "Balance", Width: 20, Right: true},
+		}),
+	}
+}
+
+// SetSize sets the available width and height.
+func (h *AccountHistory) SetSize(w, ht int) {
+	h.width = w
+	h.height = ht
+	// Title, flags, blank, sparkline, blank + table header/rule
+	h.table.SetSize(w, ht-7)
+}
+
+// Open shows the panel for an account and clears previous data.
+// Loading is only marked when the account keeps history.
+func (h *AccountHistory) Open(acc types.Account) {
+	h.account = acc
+	h.balances = nil
+	h.errMsg = ""
+	h.table.SetRows(nil)
+	h.loading = domain.HasHistory(acc)
+}
+
+// Account returns the account shown.
+func (h *AccountHistory) Account() types.Account {
+	return h.account
+}
+
+// AccountID returns the ID of the account shown.
+func (h *AccountHistory) AccountID() types.Uint128 {
+	return h.account.ID
+}
+
+// SetBalances stores the loaded snapshots (oldest first). The table lists
+// them newest first.
+func (h *AccountHistory) SetBalances(balances []types.AccountBalance) {
+	h.balances = balances
+	h.loading = false
+
+	rows := make([][]string, len(balances))
+	for i, b := range balances {
+		ledger := h.account.Ledger
+		rows[len(balances)-1-i] = []string{
+			domain.FormatTimestamp(b.Timestamp),
+			domain.FormatAmount(b.DebitsPosted, ledger),
+			domain.FormatAmount(b.CreditsPosted, ledger),
+			domain.FormatAmount(b.DebitsPending, ledger),
+			domain.FormatAmount(b.CreditsPending, ledger),
+			domain.FormatBigAmount(domain.NetBalance(h.account.Flags, b.DebitsPosted, b.CreditsPosted), ledger),
+		}
+	}
+	h.table.SetRows(rows)
+}
+
+// SetError records a failed load.
+func (h *AccountHistory) SetError(msg string) {
+	h.errMsg = msg
+	h.loading = false
+}
+
+// MoveUp moves the selection up n rows.
+func (h *AccountHistory) MoveUp(n int) {
+	h.table.MoveUp(n)
+}
+
+// MoveDown moves the selection down n rows.
+func (h *AccountHistory) MoveDown(n int) {
+	h.table.MoveDown(n)
+}
+
+// View renders the panel.
+func (h *AccountHistory) View() string {
+	titleStyle := lipgloss.NewStyle().Foreground(theme.Accent).Bold(true)
+	labelStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	textStyle := lipgloss.NewStyle().Foreground(theme.Text)
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+
+	acc := h.account
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render(fmt.Sprintf("  Account %s", domain.FormatID(acc.ID))))
+	sb.WriteString(textStyle.Render(fmt.Sprintf("  %s · %s", domain.AccountTypeName(acc.Code), domain.LedgerLabel(acc.Ledger))))
+	sb.WriteString(dimStyle.Render("  (esc to close)"))
+	sb.WriteString("\n")
+
+	flags := domain.AccountFlagNames(acc.Flags)
+	flagText := "none"
+	if len(flags) > 0 {
+		flagText = strings.Join(flags, ", ")
+	}
+	sb.WriteString(labelStyle.Render("  Flags: "))
+	sb.WriteString(textStyle.Render(flagText))
+	sb.WriteString("\n\n")
+
+	switch {
+	case !domain.HasHistory(acc):
+		sb.WriteString(lipgloss.NewStyle().Foreground(theme.Warning).Render(
+			"  ! History not enabled for this account (created without the history flag)."))
+		return sb.String()
+	case h.errMsg != "":
+		sb.WriteString(lipgloss.NewStyle().Foreground(theme.Error).Render("  ERR " + h.errMsg))
+		return sb.String()
+	case h.loading:
+		sb.WriteString(dimStyle.Render("  Loading balance history..."))
+		return sb.String()
+	case len(h.balances) == 0:
+		sb.WriteString(dimStyle.Render("  No balance snapshots yet."))
+		return sb.String()
+	}
+
+	values := make([]*big.Int, len(h.balances))
+	for i, b := range h.balances {
+		values[i] = domain.NetBalance(acc.Flags, b.DebitsPosted, b.CreditsPosted)
+	}
+	sb.WriteString(labelStyle.Render("  Balance "))
+	sb.WriteString(lipgloss.NewStyle().Foreground(theme.Accent).Render(Sparkline(values, h.width-12)))
+	sb.WriteString("\n")
+	sb.WriteString(dimStyle.Render(fmt.Sprintf("  %d snapshots", len(h.balances))))
+	sb.WriteString("\n\n")
+	sb.WriteString(h.table.View())
+
+	return sb.String()
+}
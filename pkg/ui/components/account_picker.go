@@ -0,0 +1,148 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	"github.com/fd1az/tiger-tui/business/accounts/domain"
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
+)
+
+// pickerMaxCandidates is how many matches an AccountPicker lists.
+const pickerMaxCandidates = 5
+
+// AccountPicker searches loaded accounts by ID, type name or ledger symbol.
+// The highlighted candidate is the picked account.
+type AccountPicker struct {
+	input      textinput.Model
+	accounts   []types.Account
+	labels     []string // lowercase search text per account
+	candidates []int    // indexes into accounts
+	highlight  int
+	keys       ModalKeys
+}
+
+// NewAccountPicker creates a picker over the given accounts.
+func NewAccountPicker(accounts []types.Account, keys ModalKeys) AccountPicker {
+	p := AccountPicker{
+		input:    newFormInput("search id, type or ledger", 39),
+		accounts: accounts,
+		labels:   make([]string, len(accounts)),
+		keys:     keys,
+	}
+	for i, acc := range accounts {
+		p.labels[i] = strings.ToLower(accountLabel(acc))
+	}
+	return p
+}
+
+// Focus focuses the search input.
+func (p *AccountPicker) Focus() {
+	p.input.Focus()
+}
+
+// Blur blurs the search input.
+func (p *AccountPicker) Blur() {
+	p.input.Blur()
+}
+
+// Update handles the up and down bindings to move between candidates and
+// forwards everything else, including their letter keys, to the search input.
+func (p *AccountPicker) Update(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case navKey(msg, p.keys.Up):
+		if p.highlight > 0 {
+			p.highlight--
+		}
+		return nil
+	case navKey(msg, p.keys.Down):
+		if p.highlight < len(p.candidates)-1 {
+			p.highlight++
+		}
+		return nil
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	p.search()
+	return cmd
+}
+
+// search refreshes candidates for the current query. Every term must match.
+func (p *AccountPicker) search() {
+	p.candidates = p.candidates[:0]
+	p.highlight = 0
+	terms := strings.Fields(strings.ToLower(p.input.Value()))
+	if len(terms) == 0 {
+		return
+	}
+	for i, label := range p.labels {
+		match := true
+		for _, t := range terms {
+			if !strings.Contains(label, t) {
+				match = false
+				break
+			}
+		}
+		if match {
+			p.candidates = append(p.candidates, i)
+			if len(p.candidates) == pickerMaxCandidates {
+				return
+			}
+		}
+	}
+}
+
+// Selected returns the highlighted account, if any.
+func (p *AccountPicker) Selected() (types.Account, bool) {
+	if p.highlight >= len(p.candidates) {
+		return types.Account{}, false
+	}
+	return p.accounts[p.candidates[p.highlight]], true
+}
+
+// SetQuery sets the search text, e.g. to preselect an account by ID.
+func (p *AccountPicker) SetQuery(q string) {
+	p.input.SetValue(q)
+	p.search()
+}
+
+// View renders the input and, when focused, the candidate list.
+func (p *AccountPicker) View(focused bool) string {
+	var sb strings.Builder
+	sb.WriteString(p.input.View())
+
+	if !focused {
+		if acc, ok := p.Selected(); ok {
+			sb.WriteString("\n")
+			sb.WriteString(lipgloss.NewStyle().Foreground(theme.Muted).Render("  → " + accountLabel(acc)))
+		}
+		return sb.String()
+	}
+
+	if len(p.candidates) == 0 && p.input.Value() != "" {
+		sb.WriteString("\n")
+		sb.WriteString(lipgloss.NewStyle().Foreground(theme.Dim).Render("  no loaded account matches"))
+	}
+	for i, idx := range p.candidates {
+		style := lipgloss.NewStyle().Foreground(theme.Text)
+		prefix := "   "
+		if i == p.highlight {
+			style = lipgloss.NewStyle().Foreground(theme.Accent).Bold(true)
+			prefix = " › "
+		}
+		sb.WriteString("\n")
+		sb.WriteString(style.Render(prefix + accountLabel(p.accounts[idx])))
+	}
+	return sb.String()
+}
+
+// accountLabel is the one-line description used for searching and display.
+func accountLabel(acc types.Account) string {
+	return fmt.Sprintf("%s  %s  %s", domain.FormatID(acc.ID), domain.AccountTypeName(acc.Code), domain.LedgerLabel(acc.Ledger))
+}
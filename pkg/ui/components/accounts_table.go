@@ -0,0 +1,271 @@
+package components
+
+import (
+	"cmp"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	"github.com/fd1az/tiger-tui/business/accounts/domain"
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
+)
+
+// loadMoreThreshold is how close (in rows) the cursor must get to the end of
+// the loaded accounts before the next page is requested.
+const loadMoreThreshold = 20
+
+// AccountsTable shows loaded accounts and tracks the pagination cursor.
+type AccountsTable struct {
+	table    Table
+	accounts []types.Account
+	balances []*big.Int // per account, for sorting
+	filters  []FilterChip
+	scanned  int // accounts fetched, before client-side filters
+	next     domain.AccountQuery
+	hasMore  bool
+	loading  bool
+	loaded   bool
+	width    int
+	height   int
+}
+
+// NewAccountsTable creates an empty accounts table.
+func NewAccountsTable() AccountsTable {
+	return AccountsTable{
+		table: NewTable([]Column{
+			{Key: "id", Title: "ID", Width: 24, Priority: 9, Searchable: true},
+			{Key: "code", Title: "Type", Width: 20, Priority: 5, Searchable: true},
+			{Key: "ledger", Title: "Ledger", Width: 6, Priority: 8, Searchable: true},
+			{Key: "debits_posted", Title: "Debits Posted", Width: 20, Right: true, Priority: 4},
+			{Key: "credits_posted", Title: "Credits Posted", Width: 20, Right: true, Priority: 4},
+			{Key: "debits_pending", Title: "Debits Pending", Width: 20, Right: true, Priority: 2},
+			{Key: "credits_pending", Title: "Credits Pending", Width: 20, Right: true, Priority: 2},
+			{Key: "balance", Title: "Balance", Width: 20, Right: true, Priority: 6},
+			{Key: "venue", Title: "Venue", Width: 10, Hidden: true, Priority: 3, Searchable: true},
+			{Key: "flags", Title: "Flags", Width: 24, Hidden: true, Priority: 1},
+			{Key: "user_data_128", Title: "User Data 128", Width: 24, Hidden: true, Priority: 1, Searchable: true},
+			{Key: "user_data_64", Title: "User Data 64", Width: 20, Right: true, Hidden: true, Priority: 1, Searchable: true},
+			{Key: "timestamp", Title: "Timestamp", Width: 23, Hidden: true, Priority: 3},
+		}),
+	}
+}
+
+// SetSize sets the available width and height (including header, footer
+// and the filter line).
+func (a *AccountsTable) SetSize(w, h int) {
+	a.width, a.height = w, h
+	if len(a.filters) > 0 {
+		h--
+	}
+	a.table.SetSize(w, h-3)
+}
+
+// SetFilters shows the chips the rows are filtered by.
+func (a *AccountsTable) SetFilters(chips []FilterChip) {
+	a.filters = chips
+	a.SetSize(a.width, a.height)
+}
+
+// SetLoading marks a page request as in flight.
+func (a *AccountsTable) SetLoading(loading bool) {
+	a.loading = loading
+}
+
+// SetPage stores a loaded page, replacing or appending to the current rows.
+func (a *AccountsTable) SetPage(page domain.AccountPage, appendRows bool) {
+	rows := make([][]string, len(page.Accounts))
+	texts := make([]string, len(page.Accounts))
+	balances := make([]*big.Int, len(page.Accounts))
+	for i, acc := range page.Accounts {
+		balances[i] = domain.NetBalance(acc.Flags, acc.DebitsPosted, acc.CreditsPosted)
+		rows[i] = accountRow(acc, balances[i])
+		texts[i] = searchText([]types.Uint128{acc.ID}, domain.AccountTypeName(acc.Code), acc.Ledger, acc.UserData32, acc.UserData128, acc.UserData64)
+	}
+
+	if appendRows {
+		a.accounts = append(a.accounts, page.Accounts...)
+		a.balances = append(a.balances, balances...)
+		a.table.AppendRows(rows)
+		a.scanned += page.Scanned
+	} else {
+		a.accounts = page.Accounts
+		a.balances = balances
+		a.table.SetRows(rows)
+		a.scanned = page.Scanned
+	}
+	a.table.IndexRows(texts)
+	a.Resort()
+	a.next = page.Next
+	a.hasMore = page.HasMore
+	a.loading = false
+	a.loaded = true
+}
+
+// NeedsMore reports whether the cursor is near the end of the loaded rows and
+// another page is available.
+func (a *AccountsTable) NeedsMore() bool {
+	return a.hasMore && !a.loading && a.table.Len()-a.table.Cursor() <= loadMoreThreshold
+}
+
+// Next returns the query for the next page.
+func (a *AccountsTable) Next() domain.AccountQuery {
+	return a.next
+}
+
+// Loaded returns all accounts loaded so far.
+func (a *AccountsTable) Loaded() []types.Account {
+	return a.accounts
+}
+
+// Selected returns the account under the cursor.
+func (a *AccountsTable) Selected() (types.Account, bool) {
+	i := a.table.Row()
+	if i < 0 || i >= len(a.accounts) {
+		return types.Account{}, false
+	}
+	return a.accounts[i], true
+}
+
+// Details renders every field of the selected account, or "" when there
+// is none.
+func (a *AccountsTable) Details() string {
+	acc, ok := a.Selected()
+	if !ok {
+		return ""
+	}
+	return accountDetail(acc)
+}
+
+// Table returns the underlying table, for search and layout changes.
+func (a *AccountsTable) Table() *Table {
+	return &a.table
+}
+
+// Resort orders the rows after the table's sort changed.
+func (a *AccountsTable) Resort() {
+	a.table.Sort(a.compare)
+}
+
+// compare orders two accounts by column key: numbers and IDs by value,
+// types, ledgers and venues in chart order.
+func (a *AccountsTable) compare(key string, i, j int) int {
+	x, y := &a.accounts[i], &a.accounts[j]
+	switch key {
+	case "id":
+		return compareUint128(x.ID, y.ID)
+	case "code":
+		return cmp.Compare(x.Code, y.Code)
+	case "ledger":
+		return cmp.Compare(x.Ledger, y.Ledger)
+	case "debits_posted":
+		return compareUint128(x.DebitsPosted, y.DebitsPosted)
+	case "credits_posted":
+		return compareUint128(x.CreditsPosted, y.CreditsPosted)
+	case "debits_pending":
+		return compareUint128(x.DebitsPending, y.DebitsPending)
+	case "credits_pending":
+		return compareUint128(x.CreditsPending, y.CreditsPending)
+	case "balance":
+		return a.balances[i].Cmp(a.balances[j])
+	case "venue":
+		return cmp.Compare(x.UserData32, y.UserData32)
+	case "flags":
+		return cmp.Compare(x.Flags, y.Flags)
+	case "user_data_128":
+		return compareUint128(x.UserData128, y.UserData128)
+	case "user_data_64":
+		return cmp.Compare(x.UserData64, y.UserData64)
+	case "timestamp":
+		return cmp.Compare(x.Timestamp, y.Timestamp)
+	}
+	return a.table.compareText(key, i, j)
+}
+
+// MoveUp moves the selection up n rows.
+func (a *AccountsTable) MoveUp(n int) {
+	a.table.MoveUp(n)
+}
+
+// MoveDown moves the selection down n rows.
+func (a *AccountsTable) MoveDown(n int) {
+	a.table.MoveDown(n)
+}
+
+// View renders the accounts table.
+func (a *AccountsTable) View() string {
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+
+	filters := filterLine(a.filters, a.width)
+	if filters != "" {
+		filters += "\n"
+	}
+	if !a.loaded {
+		return filters + dimStyle.Render("  Loading accounts...")
+	}
+	if len(a.accounts) == 0 && !a.hasMore {
+		return filters + dimStyle.Render("  No accounts found.")
+	}
+
+	footer := fmt.Sprintf("  %d accounts loaded", len(a.accounts))
+	if a.scanned != len(a.accounts) {
+		footer = fmt.Sprintf("  %d of %d scanned accounts match", len(a.accounts), a.scanned)
+	}
+	switch {
+	case a.loading:
+		footer += " · loading more..."
+	case a.hasMore:
+		footer += " · more available"
+	}
+	if status := a.table.SearchStatus(); status != "" {
+		footer += fmt.Sprintf(" · /%s: %s", a.table.SearchQuery(), status)
+	}
+
+	return filters + a.table.View() + "\n" + dimStyle.Render(footer)
+}
+
+// accountRow renders an account's cells in column definition order.
+func accountRow(acc types.Account, balance *big.Int) []string {
+	return []string{
+		domain.FormatID(acc.ID),
+		domain.AccountTypeName(acc.Code),
+		domain.LedgerLabel(acc.Ledger),
+		domain.FormatAmount(acc.DebitsPosted, acc.Ledger),
+		domain.FormatAmount(acc.CreditsPosted, acc.Ledger),
+		domain.FormatAmount(acc.DebitsPending, acc.Ledger),
+		domain.FormatAmount(acc.CreditsPending, acc.Ledger),
+		domain.FormatBigAmount(balance, acc.Ledger),
+		domain.VenueName(acc.UserData32),
+		flagList(domain.AccountFlagNames(acc.Flags)),
+		optionalID(acc.UserData128),
+		optionalUint(acc.UserData64),
+		domain.FormatTimestamp(acc.Timestamp),
+	}
+}
+
+// flagList renders flag names as a compact list, or "-".
+func flagList(names []string) string {
+	if len(names) == 0 {
+		return "-"
+	}
+	return strings.Join(names, ",")
+}
+
+// optionalID renders an ID, leaving zero (unset) blank.
+func optionalID(id types.Uint128) string {
+	if id == (types.Uint128{}) {
+		return ""
+	}
+	return domain.FormatID(id)
+}
+
+// optionalUint renders a number, leaving zero (unset) blank.
+func optionalUint(n uint64) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.FormatUint(n, 10)
+}
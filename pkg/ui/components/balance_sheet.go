@@ -0,0 +1,145 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fd1az/tiger-tui/business/accounts/domain"
+	bsdomain "github.com/fd1az/tiger-tui/business/balancesheet/domain"
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
+)
+
+// BalanceSheet shows per-ledger totals by account type with a trial balance
+// verdict on each ledger's total row.
+type BalanceSheet struct {
+	table   Table
+	summary bsdomain.Summary
+	loading bool
+	loaded  bool
+}
+
+// NewBalanceSheet creates an empty balance sheet view.
+func NewBalanceSheet() BalanceSheet {
+	return BalanceSheet{
+		table: NewTable([]Column{
+			{Title: "St", Width: 3, Style: statusMarkerStyle},
+			{Title: "Ledger", Width: 6},
+			{Title: "Type", Width: 20},
+			{Title: "Accts", Width: 6, Right: true},
+			{Title: "Debits Posted", Width: 20, Right: true},
+			{Title: "Credits Posted", Width: 20, Right: true},
+			{Title: "Debits Pending", Width: 20, Right: true},
+			{Title: "Credits Pending", Width: 20, Right: true},
+			{Title: "Verdict", Width: 48},
+		}),
+	}
+}
+
+// SetSize sets the available width and height (including header and footer).
+func (b *BalanceSheet) SetSize(w, h int) {
+	b.table.SetSize(w, h-3)
+}
+
+// SetLoading marks a load as in flight.
+func (b *BalanceSheet) SetLoading(loading bool) {
+	b.loading = loading
+}
+
+// SetSummary stores a computed balance sheet.
+func (b *BalanceSheet) SetSummary(s bsdomain.Summary) {
+	var rows [][]string
+	for _, l := range s.Ledgers {
+		ledger := domain.LedgerLabel(l.Ledger)
+		for _, tt := range l.Types {
+			rows = append(rows, []string{
+				"",
+				ledger,
+				domain.AccountTypeName(tt.Code),
+				fmt.Sprintf("%d", tt.Accounts),
+				domain.FormatBigAmount(tt.Totals.DebitsPosted, l.Ledger),
+				domain.FormatBigAmount(tt.Totals.CreditsPosted, l.Ledger),
+				domain.FormatBigAmount(tt.Totals.DebitsPending, l.Ledger),
+				domain.FormatBigAmount(tt.Totals.CreditsPending, l.Ledger),
+				"",
+			})
+		}
+
+		marker := "OK"
+		if l.Verdict == bsdomain.Unbalanced {
+			marker = "ERR"
+		}
+		rows = append(rows, []string{
+			marker,
+			ledger,
+			"TOTAL",
+			fmt.Sprintf("%d", l.Accounts),
+			domain.FormatBigAmount(l.Total.DebitsPosted, l.Ledger),
+			domain.FormatBigAmount(l.Total.CreditsPosted, l.Ledger),
+			domain.FormatBigAmount(l.Total.DebitsPending, l.Ledger),
+			domain.FormatBigAmount(l.Total.CreditsPending, l.Ledger),
+			verdictText(l),
+		})
+	}
+
+	b.summary = s
+	b.table.SetRows(rows)
+	b.loading = false
+	b.loaded = true
+}
+
+// Summary returns the balance sheet shown, and false before one has loaded.
+func (b *BalanceSheet) Summary() (bsdomain.Summary, bool) {
+	return b.summary, b.loaded
+}
+
+// MoveUp moves the selection up n rows.
+func (b *BalanceSheet) MoveUp(n int) {
+	b.table.MoveUp(n)
+}
+
+// MoveDown moves the selection down n rows.
+func (b *BalanceSheet) MoveDown(n int) {
+	b.table.MoveDown(n)
+}
+
+// View renders the balance sheet.
+func (b *BalanceSheet) View() string {
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+
+	if !b.loaded {
+		return dimStyle.Render("  Loading balance sheet...")
+	}
+	if len(b.summary.Ledgers) == 0 {
+		return dimStyle.Render("  No accounts found.")
+	}
+
+	footer := fmt.Sprintf("  %d ledgers · %d accounts · ", len(b.summary.Ledgers), b.summary.Accounts)
+	var verdict string
+	if n := b.summary.Unbalanced(); n > 0 {
+		verdict = lipgloss.NewStyle().Foreground(theme.Error).Render(fmt.Sprintf("ERR %d unbalanced", n))
+	} else {
+		verdict = lipgloss.NewStyle().Foreground(theme.Success).Render("OK all balanced")
+	}
+	if b.loading {
+		verdict += dimStyle.Render(" · refreshing...")
+	}
+
+	return b.table.View() + "\n" + dimStyle.Render(footer) + verdict
+}
+
+// verdictText describes a ledger's trial balance, including the exact
+// discrepancy when it does not balance.
+func verdictText(l bsdomain.LedgerSummary) string {
+	if l.Verdict == bsdomain.Balanced {
+		return "balanced"
+	}
+	text := "unbalanced:"
+	if l.PostedDiscrepancy.Sign() != 0 {
+		text += fmt.Sprintf(" posted Dr-Cr %s", domain.FormatBigAmount(l.PostedDiscrepancy, l.Ledger))
+	}
+	if l.PendingDiscrepancy.Sign() != 0 {
+		text += fmt.Sprintf(" pending Dr-Cr %s", domain.FormatBigAmount(l.PendingDiscrepancy, l.Ledger))
+	}
+	return text
+}
@@ -0,0 +1,120 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
+)
+
+// ColumnChooser shows, hides and reorders the columns of a table. Changes
+// apply when the caller takes the Layout.
+type ColumnChooser struct {
+	title    string
+	columns  []Column // in display order
+	shown    []bool
+	defaults []Column // in definition order
+	cursor   int
+	errMsg   string
+	keys     ModalKeys
+}
+
+// NewColumnChooser opens the chooser on a table's current columns.
+func NewColumnChooser(title string, t *Table, keys ModalKeys) ColumnChooser {
+	cols, shown := t.Columns()
+	return ColumnChooser{title: title, columns: cols, shown: shown, defaults: t.columns, keys: keys}
+}
+
+// Update handles a key: up and down move the focus, toggle shows or hides
+// the focused column, move up and move down reorder it, and reset restores
+// the defaults.
+func (c *ColumnChooser) Update(msg tea.KeyMsg) {
+	c.errMsg = ""
+	switch {
+	case key.Matches(msg, c.keys.Up):
+		c.cursor = max(c.cursor-1, 0)
+	case key.Matches(msg, c.keys.Down):
+		c.cursor = min(c.cursor+1, len(c.columns)-1)
+	case key.Matches(msg, c.keys.Toggle):
+		if c.shown[c.cursor] && c.shownCount() == 1 {
+			c.errMsg = "at least one column must stay visible"
+			return
+		}
+		c.shown[c.cursor] = !c.shown[c.cursor]
+	case key.Matches(msg, c.keys.MoveUp):
+		c.swap(c.cursor - 1)
+	case key.Matches(msg, c.keys.MoveDown):
+		c.swap(c.cursor + 1)
+	case key.Matches(msg, c.keys.Reset):
+		c.columns = append([]Column(nil), c.defaults...)
+		c.shown = make([]bool, len(c.columns))
+		for i, col := range c.columns {
+			c.shown[i] = !col.Hidden
+		}
+	}
+}
+
+// swap moves the focused column to position i.
+func (c *ColumnChooser) swap(i int) {
+	if i < 0 || i >= len(c.columns) {
+		return
+	}
+	j := c.cursor
+	c.columns[i], c.columns[j] = c.columns[j], c.columns[i]
+	c.shown[i], c.shown[j] = c.shown[j], c.shown[i]
+	c.cursor = i
+}
+
+func (c *ColumnChooser) shownCount() int {
+	n := 0
+	for _, s := range c.shown {
+		if s {
+			n++
+		}
+	}
+	return n
+}
+
+// Layout returns the chosen columns with the given sort.
+func (c *ColumnChooser) Layout(sort []SortKey) Layout {
+	l := Layout{Sort: sort}
+	for i, col := range c.columns {
+		l.Columns = append(l.Columns, col.Key)
+		if !c.shown[i] {
+			l.Hidden = append(l.Hidden, col.Key)
+		}
+	}
+	return l
+}
+
+// View renders the chooser.
+func (c *ColumnChooser) View() string {
+	textStyle := lipgloss.NewStyle().Foreground(theme.Text)
+	focusStyle := lipgloss.NewStyle().Foreground(theme.Accent).Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+
+	var sb strings.Builder
+	for i, col := range c.columns {
+		box := "[ ]"
+		if c.shown[i] {
+			box = "[x]"
+		}
+		style, prefix := textStyle, "   "
+		if i == c.cursor {
+			style, prefix = focusStyle, " › "
+		}
+		line := fmt.Sprintf("%s%s %-16s", prefix, box, col.Title)
+		sb.WriteString(style.Render(line) + dimStyle.Render(col.Key) + "\n")
+	}
+	sb.WriteString("\n")
+	sb.WriteString(dimStyle.Render("space show/hide · J/K move down/up · r defaults · enter applies\nColumns that do not fit drop least important first."))
+	if c.errMsg != "" {
+		sb.WriteString("\n\n")
+		sb.WriteString(lipgloss.NewStyle().Foreground(theme.Error).Render(c.errMsg))
+	}
+	return renderModal("Columns: "+c.title, sb.String(), 64)
+}
@@ -0,0 +1,133 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
+)
+
+// maxSuggestions bounds the completion list shown above the command bar.
+const maxSuggestions = 8
+
+// Suggestion is one completion offered by the command bar.
+type Suggestion struct {
+	Text string // the full command line it completes to
+	Hint string // usage or description, shown dimmed
+}
+
+// CommandBar is the vim-like ":" command line shown in place of the status
+// bar, with a list of completions above it.
+type CommandBar struct {
+	input       textinput.Model
+	suggestions []Suggestion
+	selected    int
+	picked      bool // the highlight was moved by the user
+	errMsg      string
+	width       int
+}
+
+// NewCommandBar creates an empty, focused command bar.
+func NewCommandBar(width int) CommandBar {
+	ti := newFormInput("command", 256)
+	ti.Prompt = ":"
+	ti.Focus()
+	b := CommandBar{input: ti, width: width}
+	b.input.Width = max(width-4, 10)
+	return b
+}
+
+// Value returns the typed command line.
+func (b *CommandBar) Value() string {
+	return b.input.Value()
+}
+
+// SetValue replaces the command line and moves the cursor to its end.
+func (b *CommandBar) SetValue(s string) {
+	b.input.SetValue(s)
+	b.input.CursorEnd()
+}
+
+// SetSuggestions sets the completions for the current line and selects the
+// best one.
+func (b *CommandBar) SetSuggestions(s []Suggestion) {
+	b.suggestions = s
+	b.selected = 0
+	b.picked = false
+}
+
+// Selected returns the highlighted completion.
+func (b *CommandBar) Selected() (Suggestion, bool) {
+	if b.selected >= len(b.suggestions) {
+		return Suggestion{}, false
+	}
+	return b.suggestions[b.selected], true
+}
+
+// Picked reports whether the user moved the highlight since the
+// completions last changed.
+func (b *CommandBar) Picked() bool {
+	return b.picked
+}
+
+// SelectNext highlights the next completion, wrapping.
+func (b *CommandBar) SelectNext() {
+	if n := min(len(b.suggestions), maxSuggestions); n > 0 {
+		b.selected = (b.selected + 1) % n
+		b.picked = true
+	}
+}
+
+// SelectPrev highlights the previous completion, wrapping.
+func (b *CommandBar) SelectPrev() {
+	if n := min(len(b.suggestions), maxSuggestions); n > 0 {
+		b.selected = (b.selected + n - 1) % n
+		b.picked = true
+	}
+}
+
+// SetError shows why the last command failed.
+func (b *CommandBar) SetError(msg string) {
+	b.errMsg = msg
+}
+
+// Update forwards keys to the input and clears a shown error.
+func (b *CommandBar) Update(msg tea.KeyMsg) tea.Cmd {
+	b.errMsg = ""
+	var cmd tea.Cmd
+	b.input, cmd = b.input.Update(msg)
+	return cmd
+}
+
+// View renders the completions, the last error and the command line.
+func (b *CommandBar) View() string {
+	textStyle := lipgloss.NewStyle().Foreground(theme.Text)
+	selStyle := lipgloss.NewStyle().Foreground(theme.Accent).Bold(true)
+	hintStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+
+	var lines []string
+	for i, s := range b.suggestions {
+		if i == maxSuggestions {
+			break
+		}
+		marker, style := "  ", textStyle
+		if i == b.selected {
+			marker, style = "> ", selStyle
+		}
+		line := style.Render(marker + s.Text)
+		if s.Hint != "" {
+			line += "  " + hintStyle.Render(s.Hint)
+		}
+		lines = append(lines, lipgloss.NewStyle().MaxWidth(b.width).Render(line))
+	}
+
+	if b.errMsg != "" {
+		errLine := lipgloss.NewStyle().Foreground(theme.Error).Render(b.errMsg)
+		lines = append(lines, lipgloss.NewStyle().MaxWidth(b.width).Render(errLine))
+	}
+	lines = append(lines, lipgloss.NewStyle().MaxWidth(b.width).Render(b.input.View()))
+	return strings.Join(lines, "\n")
+}
@@ -3,63 +3,103 @@ package components
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
 )
 
-// Colors (imported from parent but kept local to avoid circular imports)
-var (
-	colorAccent  = lipgloss.Color("#F4B942")
-	colorText    = lipgloss.Color("#E6EDF3")
-	colorMuted   = lipgloss.Color("#9BA7B4")
-	colorDim     = lipgloss.Color("#6B7785")
-	colorBorder  = lipgloss.Color("#243041")
-	colorSuccess = lipgloss.Color("#2ECC71")
-	colorWarning = lipgloss.Color("#F39C12")
-	colorError   = lipgloss.Color("#E74C3C")
+// ConnectionProfile is a saved connection offered by the connection
+// screen's profile picker.
+type ConnectionProfile struct {
+	Name      string
+	ClusterID string
+	Addresses []string
+	ReadOnly  bool
+	Color     string // environment color; empty uses the accent
+}
+
+// EnvColor returns the profile's environment color, or the theme accent
+// without one.
+func (p ConnectionProfile) EnvColor() lipgloss.TerminalColor {
+	if p.Color == "" || theme.Colorless() {
+		return theme.Accent
+	}
+	return lipgloss.Color(p.Color)
+}
+
+// ProfileBadge renders a profile's name in its environment color, marked
+// when it is read-only.
+func ProfileBadge(p ConnectionProfile) string {
+	badge := theme.Highlight(p.EnvColor()).Bold(true).Render(" " + p.Name + " ")
+	if p.ReadOnly {
+		badge += " " + lipgloss.NewStyle().Foreground(theme.Warning).Render("read-only")
+	}
+	return badge
+}
+
+// Connection form fields, in focus order. The profile picker is skipped
+// without saved profiles.
+const (
+	cfFieldProfile = iota
+	cfFieldCluster
+	cfFieldAddress
+	cfFieldButton
+	cfFieldCount
 )
 
 // ConnectionForm is the connection screen component.
 type ConnectionForm struct {
+	profiles     []ConnectionProfile
+	selected     int // picked profile, or -1
 	clusterInput textinput.Model
 	addressInput textinput.Model
-	focused      int // 0=cluster, 1=address, 2=button
+	focused      int
 	status       int // 0=disconnected, 1=connecting, 2=connected
 	errorMsg     string
 	width        int
 }
 
-// NewConnectionForm creates a new connection form.
-func NewConnectionForm() ConnectionForm {
+// NewConnectionForm creates a new connection form offering the saved
+// profiles; the first one is picked.
+func NewConnectionForm(profiles []ConnectionProfile) ConnectionForm {
 	ci := textinput.New()
-	ci.Placeholder = "0"
+	ci.Placeholder = "0 or 0x hex"
 	ci.SetValue("0")
-	ci.CharLimit = 39 // uint128 max
-	ci.Width = 30
-	ci.Focus()
-	ci.PromptStyle = lipgloss.NewStyle().Foreground(colorAccent)
-	ci.TextStyle = lipgloss.NewStyle().Foreground(colorText)
-	ci.PlaceholderStyle = lipgloss.NewStyle().Foreground(colorDim)
-	ci.Cursor.Style = lipgloss.NewStyle().Foreground(colorAccent)
+	ci.CharLimit = 39 // uint128 max in decimal; 0x hex is shorter
+	ci.Width = 26
+	styleInput(&ci)
 
 	ai := textinput.New()
-	ai.Placeholder = "3000"
+	ai.Placeholder = "3000,10.0.0.2:3000"
 	ai.SetValue("3000")
-	ai.CharLimit = 64
-	ai.Width = 30
-	ai.PromptStyle = lipgloss.NewStyle().Foreground(colorAccent)
-	ai.TextStyle = lipgloss.NewStyle().Foreground(colorText)
-	ai.PlaceholderStyle = lipgloss.NewStyle().Foreground(colorDim)
-	ai.Cursor.Style = lipgloss.NewStyle().Foreground(colorAccent)
-
-	return ConnectionForm{
+	ai.CharLimit = 256
+	ai.Width = 26
+	styleInput(&ai)
+
+	f := ConnectionForm{
+		profiles:     profiles,
+		selected:     -1,
 		clusterInput: ci,
 		addressInput: ai,
-		focused:      0,
+		focused:      cfFieldCluster,
 	}
+	if len(profiles) > 0 {
+		f.focused = cfFieldProfile
+		f.pick(0)
+	}
+	f.updateFocus()
+	return f
+}
+
+// Restyle recolors the inputs after the theme changed.
+func (f *ConnectionForm) Restyle() {
+	styleInput(&f.clusterInput)
+	styleInput(&f.addressInput)
 }
 
 // SetWidth sets the available width.
@@ -79,29 +119,90 @@ func (f *ConnectionForm) SetError(msg string) {
 
 // ClusterID returns the current cluster ID value.
 func (f *ConnectionForm) ClusterID() string {
-	return f.clusterInput.Value()
+	return strings.TrimSpace(f.clusterInput.Value())
 }
 
-// Address returns the current address value.
+// Address returns the current addresses value as typed.
 func (f *ConnectionForm) Address() string {
-	return f.addressInput.Value()
+	return strings.TrimSpace(f.addressInput.Value())
+}
+
+// Addresses returns the comma-separated replica addresses.
+func (f *ConnectionForm) Addresses() []string {
+	var addrs []string
+	for _, a := range strings.Split(f.addressInput.Value(), ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			addrs = append(addrs, a)
+		}
+	}
+	return addrs
+}
+
+// SelectProfile picks the profile called name and fills in its cluster and
+// addresses.
+func (f *ConnectionForm) SelectProfile(name string) bool {
+	for i, p := range f.profiles {
+		if p.Name == name {
+			f.pick(i)
+			return true
+		}
+	}
+	return false
+}
+
+// Profile returns the picked profile, and false when there is none or its
+// cluster or addresses were edited since. An edited read-only profile is
+// still returned with the edited cluster and addresses, so that editing its
+// fields does not lift the restriction.
+func (f *ConnectionForm) Profile() (ConnectionProfile, bool) {
+	if f.selected < 0 {
+		return ConnectionProfile{}, false
+	}
+	p := f.profiles[f.selected]
+	if f.ClusterID() != p.ClusterID || !slices.Equal(f.Addresses(), p.Addresses) {
+		if !p.ReadOnly {
+			return ConnectionProfile{}, false
+		}
+		p.ClusterID, p.Addresses = f.ClusterID(), f.Addresses()
+		return p, false
+	}
+	return p, true
+}
+
+func (f *ConnectionForm) pick(i int) {
+	f.selected = i
+	f.clusterInput.SetValue(f.profiles[i].ClusterID)
+	f.addressInput.SetValue(strings.Join(f.profiles[i].Addresses, ","))
+	f.clusterInput.CursorStart()
+	f.addressInput.CursorStart()
 }
 
 // FocusNext moves focus to the next field.
 func (f *ConnectionForm) FocusNext() {
-	f.focused = (f.focused + 1) % 3
+	f.focused = (f.focused + 1) % cfFieldCount
+	if f.focused == cfFieldProfile && len(f.profiles) == 0 {
+		f.focused = cfFieldCluster
+	}
 	f.updateFocus()
 }
 
 // FocusPrev moves focus to the previous field.
 func (f *ConnectionForm) FocusPrev() {
-	f.focused = (f.focused + 2) % 3
+	f.focused = (f.focused + cfFieldCount - 1) % cfFieldCount
+	if f.focused == cfFieldProfile && len(f.profiles) == 0 {
+		f.focused = cfFieldButton
+	}
 	f.updateFocus()
 }
 
 // IsButtonFocused returns true if the connect button is focused.
 func (f *ConnectionForm) IsButtonFocused() bool {
-	return f.focused == 2
+	return f.focused == cfFieldButton
+}
+
+// IsPickerFocused reports whether the profile picker has focus.
+func (f *ConnectionForm) IsPickerFocused() bool {
+	return f.focused == cfFieldProfile
 }
 
 func (f *ConnectionForm) updateFocus() {
@@ -109,37 +210,79 @@ func (f *ConnectionForm) updateFocus() {
 	f.addressInput.Blur()
 
 	switch f.focused {
-	case 0:
+	case cfFieldCluster:
 		f.clusterInput.Focus()
-	case 1:
+	case cfFieldAddress:
 		f.addressInput.Focus()
 	}
 }
 
+// PickPrev picks the previous profile in the picker.
+func (f *ConnectionForm) PickPrev() {
+	if n := len(f.profiles); n > 0 {
+		f.pick((max(f.selected, 0) + n - 1) % n)
+	}
+}
+
+// PickNext picks the next profile in the picker.
+func (f *ConnectionForm) PickNext() {
+	if n := len(f.profiles); n > 0 {
+		f.pick((f.selected + 1) % n)
+	}
+}
+
 // Update handles input for the connection form.
 func (f *ConnectionForm) Update(msg tea.Msg) tea.Cmd {
-	var cmds []tea.Cmd
-
+	var cmd tea.Cmd
 	switch f.focused {
-	case 0:
-		var cmd tea.Cmd
+	case cfFieldCluster:
 		f.clusterInput, cmd = f.clusterInput.Update(msg)
-		cmds = append(cmds, cmd)
-	case 1:
-		var cmd tea.Cmd
+	case cfFieldAddress:
 		f.addressInput, cmd = f.addressInput.Update(msg)
-		cmds = append(cmds, cmd)
 	}
+	return cmd
+}
 
-	return tea.Batch(cmds...)
+// profilesView renders the profile picker, one profile per line.
+func (f *ConnectionForm) profilesView(width int) string {
+	textStyle := lipgloss.NewStyle().Foreground(theme.Text)
+	focusStyle := lipgloss.NewStyle().Foreground(theme.Accent).Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+	warnStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+
+	var lines []string
+	for i, p := range f.profiles {
+		style, prefix := textStyle, "   "
+		if i == f.selected {
+			style, prefix = dimStyle, " · "
+			if f.focused == cfFieldProfile {
+				style, prefix = focusStyle, " › "
+			}
+		}
+		tag := "   "
+		if p.ReadOnly {
+			tag = "RO "
+		}
+		where := p.ClusterID + " @ " + p.Addresses[0]
+		if len(p.Addresses) > 1 {
+			where += fmt.Sprintf(" +%d", len(p.Addresses)-1)
+		}
+		if room := width - 18; len(where) > room {
+			where = where[:max(room-1, 0)] + "…"
+		}
+		line := style.Render(prefix) + lipgloss.NewStyle().Foreground(p.EnvColor()).Render("●") + " " +
+			style.Render(fmt.Sprintf("%-10s", p.Name)) + " " + warnStyle.Render(tag) + dimStyle.Render(where)
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
 }
 
 // View renders the connection form.
 func (f *ConnectionForm) View() string {
-	labelStyle := lipgloss.NewStyle().Foreground(colorMuted).Width(14)
-	accentBold := lipgloss.NewStyle().Foreground(colorAccent).Bold(true)
-	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
-	errStyle := lipgloss.NewStyle().Foreground(colorError)
+	labelStyle := lipgloss.NewStyle().Foreground(theme.Muted).Width(14)
+	accentBold := lipgloss.NewStyle().Foreground(theme.Accent).Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+	errStyle := lipgloss.NewStyle().Foreground(theme.Error)
 
 	w := f.width
 	if w < 1 {
@@ -165,6 +308,14 @@ func (f *ConnectionForm) View() string {
 	formWidth := 48
 	var form strings.Builder
 
+	// Profile picker
+	if len(f.profiles) > 0 {
+		form.WriteString(labelStyle.Render("Profile:"))
+		form.WriteString("\n")
+		form.WriteString(f.profilesView(formWidth - 4))
+		form.WriteString("\n\n")
+	}
+
 	// Cluster ID field
 	form.WriteString(labelStyle.Render("Cluster ID:"))
 	form.WriteString(" ")
@@ -172,7 +323,7 @@ func (f *ConnectionForm) View() string {
 	form.WriteString("\n\n")
 
 	// Address field
-	form.WriteString(labelStyle.Render("Address:"))
+	form.WriteString(labelStyle.Render("Addresses:"))
 	form.WriteString(" ")
 	form.WriteString(f.addressInput.View())
 	form.WriteString("\n\n")
@@ -181,18 +332,16 @@ func (f *ConnectionForm) View() string {
 	var btnText string
 	switch f.status {
 	case 1:
-		btnText = lipgloss.NewStyle().Foreground(colorWarning).Bold(true).Padding(0, 2).Render("Connecting...")
+		btnText = lipgloss.NewStyle().Foreground(theme.Warning).Bold(true).Padding(0, 2).Render("Connecting...")
 	default:
-		if f.focused == 2 {
-			btnText = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#0B0F14")).
-				Background(colorAccent).
+		if f.focused == cfFieldButton {
+			btnText = theme.Highlight(theme.Accent).
 				Bold(true).
 				Padding(0, 2).
 				Render("● Connect")
 		} else {
 			btnText = lipgloss.NewStyle().
-				Foreground(colorDim).
+				Foreground(theme.Dim).
 				Padding(0, 2).
 				Render("● Connect")
 		}
@@ -215,7 +364,7 @@ func (f *ConnectionForm) View() string {
 	// Render form in a box
 	formBox := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(colorMuted).
+		BorderForeground(theme.Muted).
 		Padding(1, 2).
 		Width(formWidth).
 		Render(form.String())
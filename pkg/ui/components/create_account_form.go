@@ -0,0 +1,356 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	"github.com/fd1az/tiger-tui/business/accounts/domain"
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
+)
+
+// Create Account form fields, in focus order.
+const (
+	caFieldID = iota
+	caFieldLedger
+	caFieldCode
+	caFieldUserData128
+	caFieldUserData64
+	caFieldUserData32
+	caFieldFlags // first of len(accountFlagLabels) flag checkboxes
+)
+
+// accountFlagLabels are the AccountFlags bits in bit order. Imported is left
+// out: the form has no timestamp field, and TigerBeetle rejects an imported
+// account without one.
+var accountFlagLabels = []string{
+	"linked",
+	"debits_must_not_exceed_credits",
+	"credits_must_not_exceed_debits",
+	"history",
+	"closed",
+}
+
+var (
+	caFieldAdd    = caFieldFlags + len(accountFlagLabels)
+	caFieldSubmit = caFieldAdd + 1
+	caFieldCount  = caFieldSubmit + 1
+)
+
+// CreateAccountForm is the Create Account modal. Accounts can be queued into
+// a batch and submitted together; each item's result is shown inline.
+type CreateAccountForm struct {
+	idInput    textinput.Model
+	autoID     autoID // stands in for an empty ID field
+	ud128Input textinput.Model
+	ud64Input  textinput.Model
+	ud32Input  textinput.Model
+	ledgers    []uint32
+	ledgerIdx  int
+	codes      []uint16
+	codeIdx    int
+	flags      []bool
+	focused    int
+	batch      []types.Account
+	results    []resultLine
+	submitting bool
+	errMsg     string
+	keys       ModalKeys
+}
+
+// NewCreateAccountForm creates an empty Create Account form.
+func NewCreateAccountForm(keys ModalKeys) CreateAccountForm {
+	f := CreateAccountForm{
+		keys:       keys,
+		idInput:    newFormInput(autoIDPlaceholder, 39),
+		ud128Input: newFormInput("0", 39),
+		ud64Input:  newFormInput("0", 20),
+		ud32Input:  newFormInput("0", 10),
+		ledgers:    domain.LedgerIDs(),
+		codes:      domain.AccountCodes(),
+		flags:      make([]bool, len(accountFlagLabels)),
+	}
+	f.updateFocus()
+	return f
+}
+
+// FocusNext moves focus to the next field.
+func (f *CreateAccountForm) FocusNext() {
+	f.focused = (f.focused + 1) % caFieldCount
+	f.updateFocus()
+}
+
+// FocusPrev moves focus to the previous field.
+func (f *CreateAccountForm) FocusPrev() {
+	f.focused = (f.focused + caFieldCount - 1) % caFieldCount
+	f.updateFocus()
+}
+
+// IsSubmitFocused returns true if the Create button is focused.
+func (f *CreateAccountForm) IsSubmitFocused() bool {
+	return f.focused == caFieldSubmit
+}
+
+// IsAddFocused returns true if the Add to batch button is focused.
+func (f *CreateAccountForm) IsAddFocused() bool {
+	return f.focused == caFieldAdd
+}
+
+// Submitting reports whether a submit is in flight.
+func (f *CreateAccountForm) Submitting() bool {
+	return f.submitting
+}
+
+// SetSubmitting marks a submit as in flight.
+func (f *CreateAccountForm) SetSubmitting(s bool) {
+	f.submitting = s
+}
+
+// SetError sets the form error message.
+func (f *CreateAccountForm) SetError(msg string) {
+	f.errMsg = msg
+	f.submitting = false
+}
+
+func (f *CreateAccountForm) updateFocus() {
+	f.idInput.Blur()
+	f.ud128Input.Blur()
+	f.ud64Input.Blur()
+	f.ud32Input.Blur()
+
+	switch f.focused {
+	case caFieldID:
+		f.idInput.Focus()
+	case caFieldUserData128:
+		f.ud128Input.Focus()
+	case caFieldUserData64:
+		f.ud64Input.Focus()
+	case caFieldUserData32:
+		f.ud32Input.Focus()
+	}
+}
+
+// Update handles input for the focused field: the left and right bindings
+// cycle pickers, the toggle binding toggles flags, everything else goes to
+// the focused text input.
+func (f *CreateAccountForm) Update(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case f.focused == caFieldLedger:
+		f.ledgerIdx = cycle(f.ledgerIdx, len(f.ledgers), msg, f.keys)
+		return nil
+	case f.focused == caFieldCode:
+		f.codeIdx = cycle(f.codeIdx, len(f.codes), msg, f.keys)
+		return nil
+	case f.focused >= caFieldFlags && f.focused < caFieldAdd:
+		if key.Matches(msg, f.keys.Toggle) {
+			i := f.focused - caFieldFlags
+			f.flags[i] = !f.flags[i]
+		}
+		return nil
+	}
+
+	var cmd tea.Cmd
+	switch f.focused {
+	case caFieldID:
+		f.idInput, cmd = f.idInput.Update(msg)
+	case caFieldUserData128:
+		f.ud128Input, cmd = f.ud128Input.Update(msg)
+	case caFieldUserData64:
+		f.ud64Input, cmd = f.ud64Input.Update(msg)
+	case caFieldUserData32:
+		f.ud32Input, cmd = f.ud32Input.Update(msg)
+	}
+	return cmd
+}
+
+// Build validates the form and returns the account it describes. An empty ID
+// is filled with a TigerBeetle time-based ID, the same one until the account
+// settles.
+func (f *CreateAccountForm) Build() (types.Account, error) {
+	acc := types.Account{}
+
+	if s := strings.TrimSpace(f.idInput.Value()); s != "" {
+		id, err := domain.ParseID(s)
+		if err != nil {
+			return acc, fmt.Errorf("ID: %w", err)
+		}
+		acc.ID = id
+	} else {
+		acc.ID = f.autoID.get(&f.idInput)
+	}
+
+	if len(f.ledgers) == 0 || len(f.codes) == 0 {
+		return acc, fmt.Errorf("chart of accounts has no ledgers or account types")
+	}
+	acc.Ledger = f.ledgers[f.ledgerIdx]
+	acc.Code = f.codes[f.codeIdx]
+
+	if s := strings.TrimSpace(f.ud128Input.Value()); s != "" {
+		v, err := domain.ParseID(s)
+		if err != nil {
+			return acc, fmt.Errorf("user_data_128: %w", err)
+		}
+		acc.UserData128 = v
+	}
+	if s := strings.TrimSpace(f.ud64Input.Value()); s != "" {
+		v, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return acc, fmt.Errorf("user_data_64: %q is not a 64-bit unsigned integer", s)
+		}
+		acc.UserData64 = v
+	}
+	if s := strings.TrimSpace(f.ud32Input.Value()); s != "" {
+		v, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return acc, fmt.Errorf("user_data_32: %q is not a 32-bit unsigned integer", s)
+		}
+		acc.UserData32 = uint32(v)
+	}
+
+	acc.Flags = types.AccountFlags{
+		Linked:                     f.flags[0],
+		DebitsMustNotExceedCredits: f.flags[1],
+		CreditsMustNotExceedDebits: f.flags[2],
+		History:                    f.flags[3],
+		Closed:                     f.flags[4],
+	}.ToUint16()
+
+	return acc, nil
+}
+
+// AddToBatch validates the form and queues the account. The ID field is
+// cleared so the next account gets a fresh ID; other fields are kept.
+func (f *CreateAccountForm) AddToBatch() error {
+	acc, err := f.Build()
+	if err != nil {
+		f.errMsg = err.Error()
+		return err
+	}
+	f.batch = append(f.batch, acc)
+	f.idInput.SetValue("")
+	f.autoID.reset(&f.idInput)
+	f.errMsg = ""
+	return nil
+}
+
+// Pending returns the accounts to submit: the queued batch, or the current
+// form alone when nothing is queued.
+func (f *CreateAccountForm) Pending() ([]types.Account, error) {
+	if len(f.batch) > 0 {
+		return f.batch, nil
+	}
+	acc, err := f.Build()
+	if err != nil {
+		f.errMsg = err.Error()
+		return nil, err
+	}
+	return []types.Account{acc}, nil
+}
+
+// SetResults shows each item's result. Created accounts leave the batch;
+// failed ones stay queued so they can be fixed and resubmitted.
+func (f *CreateAccountForm) SetResults(outcomes []domain.CreateOutcome) {
+	f.submitting = false
+	f.errMsg = ""
+	f.results = f.results[:0]
+	var failed []types.Account
+	for _, o := range outcomes {
+		line := resultLine{ok: o.OK()}
+		label := fmt.Sprintf("%s  %s %s", domain.FormatID(o.Account.ID),
+			domain.LedgerLabel(o.Account.Ledger), domain.AccountTypeName(o.Account.Code))
+		if o.Settled() && o.Account.ID == f.autoID.id {
+			f.autoID.reset(&f.idInput)
+		}
+		if o.OK() {
+			line.text = "OK  " + label
+		} else {
+			line.text = fmt.Sprintf("ERR %s  %s", label, domain.AccountResultName(o.Result))
+			failed = append(failed, o.Account)
+		}
+		f.results = append(f.results, line)
+	}
+	if len(f.batch) > 0 {
+		f.batch = failed
+	}
+}
+
+// View renders the form.
+func (f *CreateAccountForm) View() string {
+	return renderModal("Create Account", f.body(), 72)
+}
+
+func (f *CreateAccountForm) body() string {
+	labelStyle := lipgloss.NewStyle().Foreground(theme.Muted).Width(16)
+	textStyle := lipgloss.NewStyle().Foreground(theme.Text)
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+
+	var sb strings.Builder
+	field := func(label, value string) {
+		sb.WriteString(labelStyle.Render(label))
+		sb.WriteString(" ")
+		sb.WriteString(value)
+		sb.WriteString("\n")
+	}
+
+	field("ID:", f.idInput.View())
+
+	ledger := "-"
+	if len(f.ledgers) > 0 {
+		id := f.ledgers[f.ledgerIdx]
+		ledger = fmt.Sprintf("%s (%d) %s", domain.LedgerLabel(id), id, domain.ActiveChart().Ledgers[id].Name)
+	}
+	field("Ledger:", pickerView(ledger, f.focused == caFieldLedger))
+
+	code := "-"
+	if len(f.codes) > 0 {
+		c := f.codes[f.codeIdx]
+		code = fmt.Sprintf("%s (%d)", domain.AccountTypeName(c), c)
+	}
+	field("Code:", pickerView(code, f.focused == caFieldCode))
+
+	field("User Data 128:", f.ud128Input.View())
+	field("User Data 64:", f.ud64Input.View())
+	field("User Data 32:", f.ud32Input.View())
+
+	for i, name := range accountFlagLabels {
+		label := ""
+		if i == 0 {
+			label = "Flags:"
+		}
+		field(label, checkboxView(name, f.flags[i], f.focused == caFieldFlags+i))
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(buttonView("Add to batch", f.focused == caFieldAdd, false))
+	sb.WriteString("  ")
+	submitLabel := "Create"
+	if len(f.batch) > 0 {
+		submitLabel = fmt.Sprintf("Create %d", len(f.batch))
+	}
+	sb.WriteString(buttonView(submitLabel, f.focused == caFieldSubmit, f.submitting))
+
+	if len(f.batch) > 0 {
+		sb.WriteString("\n\n")
+		sb.WriteString(dimStyle.Render(fmt.Sprintf("Batch (%d):", len(f.batch))))
+		for _, acc := range lastN(f.batch, 5) {
+			sb.WriteString("\n")
+			sb.WriteString(textStyle.Render(fmt.Sprintf("  %s  %s %s", domain.FormatID(acc.ID),
+				domain.LedgerLabel(acc.Ledger), domain.AccountTypeName(acc.Code))))
+		}
+	}
+
+	sb.WriteString(resultsView(f.results))
+
+	if f.errMsg != "" {
+		sb.WriteString("\n\n")
+		sb.WriteString(lipgloss.NewStyle().Foreground(theme.Error).Render(f.errMsg))
+	}
+
+	return sb.String()
+}
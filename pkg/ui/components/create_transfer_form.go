@@ -0,0 +1,452 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	tb "github.com/tigerbeetle/tigerbeetle-go"
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	"github.com/fd1az/tiger-tui/business/accounts/domain"
+	transfersdomain "github.com/fd1az/tiger-tui/business/transfers/domain"
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
+)
+
+// Create Transfer form fields, in focus order.
+const (
+	ctFieldID = iota
+	ctFieldDebit
+	ctFieldCredit
+	ctFieldAmount
+	ctFieldCode
+	ctFieldVenue
+	ctFieldPendingID
+	ctFieldTimeout
+	ctFieldFlags // first of len(transferFlagLabels) flag checkboxes
+)
+
+// transferFlagLabels are the TransferFlags bits in bit order, without
+// imported: the form has no timestamp field to go with it.
+var transferFlagLabels = []string{
+	"linked",
+	"pending",
+	"post_pending_transfer",
+	"void_pending_transfer",
+	"balancing_debit",
+	"balancing_credit",
+	"closing_debit",
+	"closing_credit",
+}
+
+var (
+	ctFieldAdd    = ctFieldFlags + len(transferFlagLabels)
+	ctFieldSubmit = ctFieldAdd + 1
+	ctFieldCount  = ctFieldSubmit + 1
+)
+
+// CreateTransferForm is the Create Transfer modal. Debit and credit accounts
+// are picked from loaded accounts; the amount is typed in human units and
+// scaled with the ledger's Decimals.
+type CreateTransferForm struct {
+	idInput        textinput.Model
+	autoID         autoID // stands in for an empty ID field
+	debit          AccountPicker
+	credit         AccountPicker
+	amountInput    textinput.Model
+	pendingIDInput textinput.Model
+	timeoutInput   textinput.Model
+	codes          []uint16
+	codeIdx        int
+	venues         []uint32
+	venueIdx       int
+	flags          []bool
+	focused        int
+	batch          []types.Transfer
+	results        []resultLine
+	submitting     bool
+	errMsg         string
+	keys           ModalKeys
+}
+
+// NewCreateTransferForm creates an empty Create Transfer form over the
+// accounts currently loaded in the dashboard.
+func NewCreateTransferForm(accounts []types.Account, keys ModalKeys) CreateTransferForm {
+	f := CreateTransferForm{
+		keys:           keys,
+		idInput:        newFormInput(autoIDPlaceholder, 39),
+		debit:          NewAccountPicker(accounts, keys),
+		credit:         NewAccountPicker(accounts, keys),
+		amountInput:    newFormInput("e.g. 1,250.50 (max = full/balancing)", 48),
+		pendingIDInput: newFormInput("for post/void pending", 39),
+		timeoutInput:   newFormInput("seconds, for pending", 10),
+		codes:          domain.TransferCodes(),
+		venues:         domain.VenueIDs(),
+		flags:          make([]bool, len(transferFlagLabels)),
+	}
+	f.updateFocus()
+	return f
+}
+
+// FocusNext moves focus to the next field.
+func (f *CreateTransferForm) FocusNext() {
+	f.focused = (f.focused + 1) % ctFieldCount
+	f.updateFocus()
+}
+
+// FocusPrev moves focus to the previous field.
+func (f *CreateTransferForm) FocusPrev() {
+	f.focused = (f.focused + ctFieldCount - 1) % ctFieldCount
+	f.updateFocus()
+}
+
+// IsSubmitFocused returns true if the Create button is focused.
+func (f *CreateTransferForm) IsSubmitFocused() bool {
+	return f.focused == ctFieldSubmit
+}
+
+// IsAddFocused returns true if the Add to batch button is focused.
+func (f *CreateTransferForm) IsAddFocused() bool {
+	return f.focused == ctFieldAdd
+}
+
+// Submitting reports whether a submit is in flight.
+func (f *CreateTransferForm) Submitting() bool {
+	return f.submitting
+}
+
+// SetSubmitting marks a submit as in flight.
+func (f *CreateTransferForm) SetSubmitting(s bool) {
+	f.submitting = s
+}
+
+// SetError sets the form error message.
+func (f *CreateTransferForm) SetError(msg string) {
+	f.errMsg = msg
+	f.submitting = false
+}
+
+func (f *CreateTransferForm) updateFocus() {
+	f.idInput.Blur()
+	f.debit.Blur()
+	f.credit.Blur()
+	f.amountInput.Blur()
+	f.pendingIDInput.Blur()
+	f.timeoutInput.Blur()
+
+	switch f.focused {
+	case ctFieldID:
+		f.idInput.Focus()
+	case ctFieldDebit:
+		f.debit.Focus()
+	case ctFieldCredit:
+		f.credit.Focus()
+	case ctFieldAmount:
+		f.amountInput.Focus()
+	case ctFieldPendingID:
+		f.pendingIDInput.Focus()
+	case ctFieldTimeout:
+		f.timeoutInput.Focus()
+	}
+}
+
+// Update handles input for the focused field.
+func (f *CreateTransferForm) Update(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case f.focused == ctFieldCode:
+		f.codeIdx = cycle(f.codeIdx, len(f.codes), msg, f.keys)
+		return nil
+	case f.focused == ctFieldVenue:
+		f.venueIdx = cycle(f.venueIdx, len(f.venues), msg, f.keys)
+		return nil
+	case f.focused >= ctFieldFlags && f.focused < ctFieldAdd:
+		if key.Matches(msg, f.keys.Toggle) {
+			i := f.focused - ctFieldFlags
+			f.flags[i] = !f.flags[i]
+		}
+		return nil
+	}
+
+	var cmd tea.Cmd
+	switch f.focused {
+	case ctFieldID:
+		f.idInput, cmd = f.idInput.Update(msg)
+	case ctFieldDebit:
+		cmd = f.debit.Update(msg)
+	case ctFieldCredit:
+		cmd = f.credit.Update(msg)
+	case ctFieldAmount:
+		f.amountInput, cmd = f.amountInput.Update(msg)
+	case ctFieldPendingID:
+		f.pendingIDInput, cmd = f.pendingIDInput.Update(msg)
+	case ctFieldTimeout:
+		f.timeoutInput, cmd = f.timeoutInput.Update(msg)
+	}
+	return cmd
+}
+
+func (f *CreateTransferForm) transferFlags() types.TransferFlags {
+	return types.TransferFlags{
+		Linked:              f.flags[0],
+		Pending:             f.flags[1],
+		PostPendingTransfer: f.flags[2],
+		VoidPendingTransfer: f.flags[3],
+		BalancingDebit:      f.flags[4],
+		BalancingCredit:     f.flags[5],
+		ClosingDebit:        f.flags[6],
+		ClosingCredit:       f.flags[7],
+	}
+}
+
+// Build validates the form and returns the transfer it describes.
+//
+// Post/void transfers may leave accounts empty (they inherit from the pending
+// transfer) and use code 0. An empty or "max" amount means AMOUNT_MAX for
+// post-pending and balancing transfers, and zero for void-pending.
+func (f *CreateTransferForm) Build() (types.Transfer, error) {
+	t := types.Transfer{}
+	flags := f.transferFlags()
+	resolving := flags.PostPendingTransfer || flags.VoidPendingTransfer
+	t.Flags = flags.ToUint16()
+
+	if s := strings.TrimSpace(f.idInput.Value()); s != "" {
+		id, err := domain.ParseID(s)
+		if err != nil {
+			return t, fmt.Errorf("ID: %w", err)
+		}
+		t.ID = id
+	} else {
+		t.ID = f.autoID.get(&f.idInput)
+	}
+
+	debit, hasDebit := f.debit.Selected()
+	credit, hasCredit := f.credit.Selected()
+	switch {
+	case !resolving && !hasDebit:
+		return t, fmt.Errorf("debit account is required")
+	case !resolving && !hasCredit:
+		return t, fmt.Errorf("credit account is required")
+	case hasDebit && hasCredit && debit.ID == credit.ID:
+		return t, fmt.Errorf("debit and credit accounts must be different")
+	case hasDebit && hasCredit && debit.Ledger != credit.Ledger:
+		return t, fmt.Errorf("accounts are on different ledgers (%s vs %s)",
+			domain.LedgerLabel(debit.Ledger), domain.LedgerLabel(credit.Ledger))
+	}
+	if hasDebit {
+		t.DebitAccountID = debit.ID
+		t.Ledger = debit.Ledger
+	}
+	if hasCredit {
+		t.CreditAccountID = credit.ID
+		t.Ledger = credit.Ledger
+	}
+
+	amount := strings.TrimSpace(f.amountInput.Value())
+	switch {
+	case strings.EqualFold(amount, "max"):
+		t.Amount = tb.AmountMax
+	case amount == "" && (flags.PostPendingTransfer || flags.BalancingDebit || flags.BalancingCredit):
+		t.Amount = tb.AmountMax
+	case amount == "" && flags.VoidPendingTransfer:
+		// Zero voids the full pending amount.
+	case amount == "":
+		return t, fmt.Errorf("amount is required")
+	case t.Ledger == 0:
+		return t, fmt.Errorf("pick an account so the amount can be scaled to its ledger")
+	default:
+		v, err := domain.ParseAmount(amount, t.Ledger)
+		if err != nil {
+			return t, fmt.Errorf("amount: %w", err)
+		}
+		t.Amount = v
+	}
+
+	if !resolving && len(f.codes) > 0 {
+		t.Code = f.codes[f.codeIdx]
+	}
+	if len(f.venues) > 0 {
+		t.UserData32 = f.venues[f.venueIdx]
+	}
+
+	if s := strings.TrimSpace(f.pendingIDInput.Value()); s != "" {
+		id, err := domain.ParseID(s)
+		if err != nil {
+			return t, fmt.Errorf("pending ID: %w", err)
+		}
+		t.PendingID = id
+	} else if resolving {
+		return t, fmt.Errorf("pending ID is required to post or void a pending transfer")
+	}
+
+	if s := strings.TrimSpace(f.timeoutInput.Value()); s != "" {
+		if !flags.Pending {
+			return t, fmt.Errorf("timeout only applies to pending transfers")
+		}
+		v, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return t, fmt.Errorf("timeout: %q is not a number of seconds", s)
+		}
+		t.Timeout = uint32(v)
+	}
+
+	return t, nil
+}
+
+// AddToBatch validates the form and queues the transfer. The ID field is
+// cleared so the next transfer gets a fresh ID; other fields are kept.
+func (f *CreateTransferForm) AddToBatch() error {
+	t, err := f.Build()
+	if err != nil {
+		f.errMsg = err.Error()
+		return err
+	}
+	f.batch = append(f.batch, t)
+	f.idInput.SetValue("")
+	f.autoID.reset(&f.idInput)
+	f.errMsg = ""
+	return nil
+}
+
+// Pending returns the transfers to submit: the queued batch, or the current
+// form alone when nothing is queued.
+func (f *CreateTransferForm) Pending() ([]types.Transfer, error) {
+	if len(f.batch) > 0 {
+		return f.batch, nil
+	}
+	t, err := f.Build()
+	if err != nil {
+		f.errMsg = err.Error()
+		return nil, err
+	}
+	return []types.Transfer{t}, nil
+}
+
+// SetResults shows each item's result. Created transfers leave the batch;
+// failed ones stay queued so they can be fixed and resubmitted.
+func (f *CreateTransferForm) SetResults(outcomes []transfersdomain.CreateOutcome) {
+	f.submitting = false
+	f.errMsg = ""
+	f.results = f.results[:0]
+	var failed []types.Transfer
+	for _, o := range outcomes {
+		line := resultLine{ok: o.OK()}
+		label := fmt.Sprintf("%s  %s", domain.FormatID(o.Transfer.ID), transferAmountLabel(o.Transfer))
+		if o.Settled() && o.Transfer.ID == f.autoID.id {
+			f.autoID.reset(&f.idInput)
+		}
+		if o.OK() {
+			line.text = "OK  " + label
+		} else {
+			line.text = fmt.Sprintf("ERR %s  %s", label, transfersdomain.TransferResultName(o.Result))
+			failed = append(failed, o.Transfer)
+		}
+		f.results = append(f.results, line)
+	}
+	if len(f.batch) > 0 {
+		f.batch = failed
+	}
+}
+
+// View renders the form.
+func (f *CreateTransferForm) View() string {
+	return renderModal("Create Transfer", f.body(), 84)
+}
+
+func (f *CreateTransferForm) body() string {
+	labelStyle := lipgloss.NewStyle().Foreground(theme.Muted).Width(16)
+	textStyle := lipgloss.NewStyle().Foreground(theme.Text)
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+
+	var sb strings.Builder
+	field := func(label, value string) {
+		sb.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, labelStyle.Render(label), " ", value))
+		sb.WriteString("\n")
+	}
+
+	flags := f.transferFlags()
+	resolving := flags.PostPendingTransfer || flags.VoidPendingTransfer
+
+	field("ID:", f.idInput.View())
+	field("Debit account:", f.debit.View(f.focused == ctFieldDebit))
+	field("Credit account:", f.credit.View(f.focused == ctFieldCredit))
+
+	amount := f.amountInput.View()
+	if acc, ok := f.debit.Selected(); ok {
+		amount += dimStyle.Render(" " + domain.LedgerLabel(acc.Ledger))
+	}
+	field("Amount:", amount)
+
+	code := "-"
+	if resolving {
+		code = "(from pending transfer)"
+	} else if len(f.codes) > 0 {
+		c := f.codes[f.codeIdx]
+		code = fmt.Sprintf("%s (%d)", domain.TransferTypeName(c), c)
+	}
+	field("Code:", pickerView(code, f.focused == ctFieldCode))
+
+	venue := "-"
+	if len(f.venues) > 0 {
+		v := f.venues[f.venueIdx]
+		venue = fmt.Sprintf("%s (%d)", domain.VenueName(v), v)
+	}
+	field("Venue:", pickerView(venue, f.focused == ctFieldVenue))
+
+	field("Pending ID:", f.pendingIDInput.View())
+	field("Timeout:", f.timeoutInput.View())
+
+	// Flags in a three-column grid
+	for row := 0; row*3 < len(transferFlagLabels); row++ {
+		var cells []string
+		for i := row * 3; i < row*3+3 && i < len(transferFlagLabels); i++ {
+			cells = append(cells, lipgloss.NewStyle().Width(28).Render(
+				checkboxView(transferFlagLabels[i], f.flags[i], f.focused == ctFieldFlags+i)))
+		}
+		label := ""
+		if row == 0 {
+			label = "Flags:"
+		}
+		field(label, strings.Join(cells, ""))
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(buttonView("Add to batch", f.focused == ctFieldAdd, false))
+	sb.WriteString("  ")
+	submitLabel := "Create"
+	if len(f.batch) > 0 {
+		submitLabel = fmt.Sprintf("Create %d", len(f.batch))
+	}
+	sb.WriteString(buttonView(submitLabel, f.focused == ctFieldSubmit, f.submitting))
+
+	if len(f.batch) > 0 {
+		sb.WriteString("\n\n")
+		sb.WriteString(dimStyle.Render(fmt.Sprintf("Batch (%d):", len(f.batch))))
+		for _, t := range lastN(f.batch, 5) {
+			sb.WriteString("\n")
+			sb.WriteString(textStyle.Render(fmt.Sprintf("  %s  %s  %s", domain.FormatID(t.ID),
+				transferAmountLabel(t), domain.TransferTypeName(t.Code))))
+		}
+	}
+
+	sb.WriteString(resultsView(f.results))
+
+	if f.errMsg != "" {
+		sb.WriteString("\n\n")
+		sb.WriteString(lipgloss.NewStyle().Foreground(theme.Error).Render(f.errMsg))
+	}
+
+	return sb.String()
+}
+
+// transferAmountLabel formats a transfer amount with its ledger, showing
+// AMOUNT_MAX as "max".
+func transferAmountLabel(t types.Transfer) string {
+	if t.Amount == tb.AmountMax {
+		return "max " + domain.LedgerLabel(t.Ledger)
+	}
+	return domain.NewMoney(t.Amount, t.Ledger).String()
+}
@@ -1,29 +1,267 @@
 package components
 
 import (
+	"math"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
 )
 
 // Dashboard renders the main dashboard shell with tabs.
 type Dashboard struct {
-	activeTab int // 0=Accounts, 1=Transfers, 2=Balance Sheet
+	activeTab int // 0=Accounts, 1=Transfers, 2=Balance Sheet, 3=Pending
+	accounts  AccountsTable
+	transfers TransfersTable
+	balance   BalanceSheet
+	pending   PendingTable
+	history   AccountHistory
+	showHist  bool
+	details   bool // split view with the details panel
+	detailsOn bool // the details panel has focus instead of the table
+	scroll    int  // details panel scroll offset
 	width     int
 	height    int
 }
 
-var tabNames = []string{"Accounts", "Transfers", "Balance Sheet"}
+var tabNames = []string{"Accounts", "Transfers", "Balance Sheet", "Pending"}
 
 // NewDashboard creates a new dashboard.
 func NewDashboard() Dashboard {
-	return Dashboard{}
+	return Dashboard{
+		accounts:  NewAccountsTable(),
+		transfers: NewTransfersTable(),
+		balance:   NewBalanceSheet(),
+		pending:   NewPendingTable(),
+		history:   NewAccountHistory(),
+	}
 }
 
 // SetSize sets the available dimensions.
 func (d *Dashboard) SetSize(w, h int) {
 	d.width = w
 	d.height = h
+	tw, th := w-4, d.contentHeight()
+	if d.details {
+		tw -= d.detailsWidth() + 1 + paneFrameWidth
+		th -= paneFrameHeight
+	}
+	d.accounts.SetSize(tw, th)
+	d.transfers.SetSize(tw, th)
+	d.balance.SetSize(w-4, d.contentHeight())
+	d.pending.SetSize(tw, th)
+	d.history.SetSize(w-4, d.contentHeight())
+}
+
+// detailsWidth returns the outer width of the details panel: room for a
+// full 128-bit ID next to its label where the terminal allows.
+func (d *Dashboard) detailsWidth() int {
+	return min(max((d.width-4)*2/5, 40), 64)
+}
+
+// Accounts returns the accounts table.
+func (d *Dashboard) Accounts() *AccountsTable {
+	return &d.accounts
+}
+
+// Transfers returns the transfers table.
+func (d *Dashboard) Transfers() *TransfersTable {
+	return &d.transfers
+}
+
+// BalanceSheet returns the balance sheet view.
+func (d *Dashboard) BalanceSheet() *BalanceSheet {
+	return &d.balance
+}
+
+// Pending returns the pending transfers table.
+func (d *Dashboard) Pending() *PendingTable {
+	return &d.pending
+}
+
+// History returns the account history panel.
+func (d *Dashboard) History() *AccountHistory {
+	return &d.history
+}
+
+// OpenHistory shows the balance history panel for an account in place of
+// the accounts table.
+func (d *Dashboard) OpenHistory(acc types.Account) {
+	d.history.Open(acc)
+	d.showHist = true
+}
+
+// CloseHistory returns to the accounts table.
+func (d *Dashboard) CloseHistory() {
+	d.showHist = false
+}
+
+// HistoryOpen reports whether the history panel is showing.
+func (d *Dashboard) HistoryOpen() bool {
+	return d.showHist && d.activeTab == 0
+}
+
+// SetTab switches to the tab at index i.
+func (d *Dashboard) SetTab(i int) {
+	if i >= 0 && i < len(tabNames) {
+		d.activeTab = i
+		d.detailsOn = false
+		d.scroll = 0
+	}
+}
+
+// SearchTable returns the table incremental search applies to on the active
+// tab: Accounts (outside balance history) and Transfers.
+func (d *Dashboard) SearchTable() (*Table, bool) {
+	switch {
+	case d.activeTab == 0 && !d.showHist:
+		return d.accounts.Table(), true
+	case d.activeTab == 1:
+		return d.transfers.Table(), true
+	}
+	return nil, false
+}
+
+// LayoutTable is a dashboard table whose columns and sort can be changed.
+type LayoutTable interface {
+	Table() *Table
+	// Resort orders the rows after the table's sort changed.
+	Resort()
+}
+
+// LayoutTable returns the table of a tab with one: Accounts, Transfers and
+// Pending.
+func (d *Dashboard) LayoutTable(tab int) (LayoutTable, bool) {
+	switch tab {
+	case 0:
+		return &d.accounts, true
+	case 1:
+		return &d.transfers, true
+	case 3:
+		return &d.pending, true
+	}
+	return nil, false
+}
+
+// detailsTable returns the active tab's table with a details panel:
+// Accounts (outside balance history), Transfers and Pending.
+func (d *Dashboard) detailsTable() (interface{ Details() string }, bool) {
+	switch {
+	case d.activeTab == 0 && !d.showHist:
+		return &d.accounts, true
+	case d.activeTab == 1:
+		return &d.transfers, true
+	case d.activeTab == 3:
+		return &d.pending, true
+	}
+	return nil, false
+}
+
+// ToggleDetails opens or closes the details panel next to the active
+// table, leaving the table focused. It reports false on tabs without one.
+func (d *Dashboard) ToggleDetails() bool {
+	if _, ok := d.detailsTable(); !ok {
+		return false
+	}
+	d.details = !d.details
+	d.detailsOn = false
+	d.scroll = 0
+	d.SetSize(d.width, d.height)
+	return true
+}
+
+// DetailsOpen reports whether the details panel is showing.
+func (d *Dashboard) DetailsOpen() bool {
+	_, ok := d.detailsTable()
+	return ok && d.details
+}
+
+// FocusDetails moves the focus to the details panel, or back to the table.
+func (d *Dashboard) FocusDetails(focus bool) {
+	d.detailsOn = focus && d.DetailsOpen()
+}
+
+// DetailsFocused reports whether the details panel has the focus.
+func (d *Dashboard) DetailsFocused() bool {
+	return d.detailsOn && d.DetailsOpen()
+}
+
+// ScrollDetails scrolls the details panel by n lines.
+func (d *Dashboard) ScrollDetails(n int) {
+	t, ok := d.detailsTable()
+	if !ok {
+		return
+	}
+	lines, room := detailsLines(t.Details(), d.detailsWidth(), d.contentHeight())
+	d.scroll = min(max(d.scroll+n, 0), max(len(lines)-room, 0))
+}
+
+// MoveUp moves the selection up n rows in the active tab.
+func (d *Dashboard) MoveUp(n int) {
+	d.scroll = 0
+	switch d.activeTab {
+	case 0:
+		if d.showHist {
+			d.history.MoveUp(n)
+			return
+		}
+		d.accounts.MoveUp(n)
+	case 1:
+		d.transfers.MoveUp(n)
+	case 2:
+		d.balance.MoveUp(n)
+	case 3:
+		d.pending.MoveUp(n)
+	}
+}
+
+// MoveDown moves the selection down n rows in the active tab.
+func (d *Dashboard) MoveDown(n int) {
+	d.scroll = 0
+	switch d.activeTab {
+	case 0:
+		if d.showHist {
+			d.history.MoveDown(n)
+			return
+		}
+		d.accounts.MoveDown(n)
+	case 1:
+		d.transfers.MoveDown(n)
+	case 2:
+		d.balance.MoveDown(n)
+	case 3:
+		d.pending.MoveDown(n)
+	}
+}
+
+// allRows moves the selection past every row; the tables stop it at the
+// first or last one.
+const allRows = math.MaxInt32
+
+// MoveFirst selects the first row of the active tab.
+func (d *Dashboard) MoveFirst() {
+	d.MoveUp(allRows)
+}
+
+// MoveLast selects the last loaded row of the active tab.
+func (d *Dashboard) MoveLast() {
+	d.MoveDown(allRows)
+}
+
+// PageRows returns the number of rows half a page moves, at least one.
+func (d *Dashboard) PageRows() int {
+	return max(d.contentHeight()/2, 1)
+}
+
+// contentHeight returns the height available below the tab bar.
+func (d *Dashboard) contentHeight() int {
+	h := d.height - 6 // Reserve space for tabs + status
+	if h < 3 {
+		h = 3
+	}
+	return h
 }
 
 // ActiveTab returns the current active tab index.
@@ -33,27 +271,27 @@ func (d *Dashboard) ActiveTab() int {
 
 // NextTab cycles to the next tab.
 func (d *Dashboard) NextTab() {
-	d.activeTab = (d.activeTab + 1) % len(tabNames)
+	d.SetTab((d.activeTab + 1) % len(tabNames))
 }
 
 // PrevTab cycles to the previous tab.
 func (d *Dashboard) PrevTab() {
-	d.activeTab = (d.activeTab + len(tabNames) - 1) % len(tabNames)
+	d.SetTab((d.activeTab + len(tabNames) - 1) % len(tabNames))
 }
 
 // View renders the dashboard.
 func (d *Dashboard) View() string {
 	activeStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(colorAccent).
+		Foreground(theme.Accent).
 		Border(lipgloss.NormalBorder(), false, false, true, false).
-		BorderForeground(colorAccent).
+		BorderForeground(theme.Accent).
 		Padding(0, 2)
 
 	inactiveStyle := lipgloss.NewStyle().
-		Foreground(colorMuted).
+		Foreground(theme.Muted).
 		Border(lipgloss.NormalBorder(), false, false, true, false).
-		BorderForeground(colorDim).
+		BorderForeground(theme.Dim).
 		Padding(0, 2)
 
 	// Render tabs
@@ -67,26 +305,27 @@ func (d *Dashboard) View() string {
 	}
 	tabBar := lipgloss.JoinHorizontal(lipgloss.Bottom, tabs...)
 
-	// Content area (placeholder)
-	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+	// Content area
 	var content string
 	switch d.activeTab {
 	case 0:
-		content = dimStyle.Render("  Accounts will appear here after connecting.")
+		if d.showHist {
+			content = d.history.View()
+		} else {
+			content = d.withDetails(d.accounts.View())
+		}
 	case 1:
-		content = dimStyle.Render("  Transfers will appear here after connecting.")
+		content = d.withDetails(d.transfers.View())
 	case 2:
-		content = dimStyle.Render("  Balance Sheet will appear here after connecting.")
+		content = d.balance.View()
+	case 3:
+		content = d.withDetails(d.pending.View())
 	}
 
 	// Content box
-	contentHeight := d.height - 6 // Reserve space for tabs + status
-	if contentHeight < 3 {
-		contentHeight = 3
-	}
 	contentBox := lipgloss.NewStyle().
 		Width(d.width - 4).
-		Height(contentHeight).
+		Height(d.contentHeight()).
 		Render(content)
 
 	var sb strings.Builder
@@ -96,3 +335,20 @@ func (d *Dashboard) View() string {
 
 	return sb.String()
 }
+
+// withDetails lays the active table out next to the details panel when it
+// is open. Both get a border; only the focused one is accented.
+func (d *Dashboard) withDetails(table string) string {
+	t, ok := d.detailsTable()
+	if !ok || !d.details {
+		return table
+	}
+	h := d.contentHeight()
+	dw := d.detailsWidth()
+	left := paneStyle(!d.detailsOn).
+		Width(d.width - 4 - dw - 1 - paneFrameWidth + 2).
+		Height(h - paneFrameHeight).
+		Render(table)
+	right := detailsPane(t.Details(), dw, h, d.scroll, d.detailsOn)
+	return lipgloss.JoinHorizontal(lipgloss.Top, left, " ", right)
+}
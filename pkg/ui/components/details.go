@@ -0,0 +1,60 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
+)
+
+// paneStyle borders one pane of a split view: the accent color marks the
+// focused pane, like BoxFocusedStyle, the others get BoxStyle's border.
+func paneStyle(focused bool) lipgloss.Style {
+	border := theme.Border
+	if focused {
+		border = theme.Accent
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(border).
+		Padding(0, 1)
+}
+
+// The width and height a pane's border and padding take.
+const (
+	paneFrameWidth  = 4
+	paneFrameHeight = 2
+)
+
+// detailsPane renders the details of the selected row in a pane of the
+// given outer size, scrolled down by scroll lines.
+func detailsPane(details string, width, height, scroll int, focused bool) string {
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+	hint := "space close · → focus details"
+	if focused {
+		hint = "↑/↓ scroll · ← back to table"
+	}
+
+	// The hint stays on the last line; the fields scroll above it.
+	lines, room := detailsLines(details, width, height)
+	scroll = min(max(scroll, 0), max(len(lines)-room, 0))
+	lines = lines[scroll:min(scroll+room, len(lines))]
+	body := strings.Join(lines, "\n")
+	if pad := room - len(lines); pad > 0 {
+		body += strings.Repeat("\n", pad)
+	}
+	body += "\n\n" + dimStyle.Render(hint)
+
+	return paneStyle(focused).Width(width - paneFrameWidth + 2).Render(body)
+}
+
+// detailsLines wraps the details to a pane of the given outer size and
+// returns their lines with the number that fit at once.
+func detailsLines(details string, width, height int) ([]string, int) {
+	if details == "" {
+		details = lipgloss.NewStyle().Foreground(theme.Dim).Render("No row selected.")
+	}
+	wrapped := lipgloss.NewStyle().Width(width - paneFrameWidth).Render(details)
+	return strings.Split(wrapped, "\n"), max(height-paneFrameHeight-2, 1)
+}
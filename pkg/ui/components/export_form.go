@@ -0,0 +1,134 @@
+package components
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	exportdomain "github.com/fd1az/tiger-tui/business/export/domain"
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
+)
+
+// Export form fields, in focus order.
+const (
+	exFieldFormat = iota
+	exFieldPath
+	exFieldSubmit
+	exFieldCount
+)
+
+// ExportForm picks the format and file for exporting the active view.
+type ExportForm struct {
+	view      string // e.g. "transfers"
+	title     string // what the view shows, e.g. "Transfers of account 42"
+	formatIdx int
+	pathInput textinput.Model
+	focused   int
+	errMsg    string
+	keys      ModalKeys
+}
+
+// NewExportForm creates the form for a view, suggesting a timestamped file
+// name in the working directory.
+func NewExportForm(view, title string, keys ModalKeys) ExportForm {
+	f := ExportForm{
+		view:      view,
+		title:     title,
+		pathInput: newFormInput("file", 256),
+		keys:      keys,
+	}
+	f.pathInput.Width = 48
+	f.pathInput.SetValue(exportdomain.FileName(view, f.Format(), time.Now()))
+	return f
+}
+
+// ViewName returns the exported view's name.
+func (f *ExportForm) ViewName() string {
+	return f.view
+}
+
+// Format returns the selected format.
+func (f *ExportForm) Format() exportdomain.Format {
+	return exportdomain.Formats[f.formatIdx]
+}
+
+// Path returns the file to write.
+func (f *ExportForm) Path() string {
+	return strings.TrimSpace(f.pathInput.Value())
+}
+
+// FocusNext moves focus to the next field.
+func (f *ExportForm) FocusNext() {
+	f.focused = (f.focused + 1) % exFieldCount
+	f.updateFocus()
+}
+
+// FocusPrev moves focus to the previous field.
+func (f *ExportForm) FocusPrev() {
+	f.focused = (f.focused + exFieldCount - 1) % exFieldCount
+	f.updateFocus()
+}
+
+// IsSubmitFocused reports whether the Export button has focus.
+func (f *ExportForm) IsSubmitFocused() bool {
+	return f.focused == exFieldSubmit
+}
+
+// SetError sets the form error message.
+func (f *ExportForm) SetError(msg string) {
+	f.errMsg = msg
+}
+
+func (f *ExportForm) updateFocus() {
+	if f.focused == exFieldPath {
+		f.pathInput.Focus()
+	} else {
+		f.pathInput.Blur()
+	}
+}
+
+// Update forwards keys to the focused field. Changing the format also
+// changes the file extension.
+func (f *ExportForm) Update(msg tea.KeyMsg) tea.Cmd {
+	switch f.focused {
+	case exFieldFormat:
+		old := f.Format()
+		f.formatIdx = cycle(f.formatIdx, len(exportdomain.Formats), msg, f.keys)
+		if path := f.Path(); f.Format() != old && strings.EqualFold(filepath.Ext(path), "."+string(old)) {
+			f.pathInput.SetValue(strings.TrimSuffix(path, filepath.Ext(path)) + "." + string(f.Format()))
+		}
+	case exFieldPath:
+		var cmd tea.Cmd
+		f.pathInput, cmd = f.pathInput.Update(msg)
+		return cmd
+	}
+	return nil
+}
+
+// View renders the form.
+func (f *ExportForm) View() string {
+	labelStyle := lipgloss.NewStyle().Foreground(theme.Muted).Width(8)
+	textStyle := lipgloss.NewStyle().Foreground(theme.Text)
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+
+	var sb strings.Builder
+	sb.WriteString(labelStyle.Render("View:") + " " + textStyle.Render(f.title))
+	sb.WriteString("\n")
+	sb.WriteString(labelStyle.Render("Format:") + " " + pickerView(strings.ToUpper(string(f.Format())), f.focused == exFieldFormat))
+	sb.WriteString("\n")
+	sb.WriteString(labelStyle.Render("File:") + " " + f.pathInput.View())
+	sb.WriteString("\n\n")
+	sb.WriteString(dimStyle.Render("Raw uint128 values are exported next to formatted amounts and labels."))
+	sb.WriteString("\n\n")
+	sb.WriteString(buttonView("Export", f.focused == exFieldSubmit, false))
+
+	if f.errMsg != "" {
+		sb.WriteString("\n\n")
+		sb.WriteString(lipgloss.NewStyle().Foreground(theme.Error).Render(f.errMsg))
+	}
+	return renderModal("Export", sb.String(), 72)
+}
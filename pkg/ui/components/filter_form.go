@@ -0,0 +1,138 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
+)
+
+// FilterChip is a filter condition as shown on the dashboard. Server chips
+// are evaluated by TigerBeetle; the others are applied to each loaded page.
+type FilterChip struct {
+	Text   string
+	Server bool
+}
+
+// chipsView renders chips in a row, marking client-side ones "local".
+func chipsView(chips []FilterChip) string {
+	serverStyle := lipgloss.NewStyle().Foreground(theme.Accent)
+	clientStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+	parts := make([]string, len(chips))
+	for i, c := range chips {
+		if c.Server {
+			parts[i] = serverStyle.Render("[" + c.Text + "]")
+		} else {
+			parts[i] = clientStyle.Render("[" + c.Text + " · local]")
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// filterLine renders the chips above a table, or "" when there are none.
+func filterLine(chips []FilterChip, width int) string {
+	if len(chips) == 0 {
+		return ""
+	}
+	label := lipgloss.NewStyle().Foreground(theme.Muted).Render(" Filter: ")
+	return lipgloss.NewStyle().MaxWidth(width).Render(label + chipsView(chips))
+}
+
+// FilterForm builds the filter of a tab from chips typed one at a time.
+type FilterForm struct {
+	title       string
+	chips       []FilterChip
+	input       textinput.Model
+	suggestions []string
+	errMsg      string
+}
+
+// NewFilterForm creates the builder for a tab's current chips.
+func NewFilterForm(title string, chips []FilterChip) FilterForm {
+	f := FilterForm{
+		title: title,
+		chips: chips,
+		input: newFormInput("e.g. ledger=USD, amount>=100, since=2h", 128),
+	}
+	f.input.Width = 52
+	f.input.Focus()
+	return f
+}
+
+// Value returns the typed chip.
+func (f *FilterForm) Value() string {
+	return strings.TrimSpace(f.input.Value())
+}
+
+// SetValue replaces the typed chip.
+func (f *FilterForm) SetValue(s string) {
+	f.input.SetValue(s)
+	f.input.CursorEnd()
+}
+
+// SetChips shows the compiled chips and clears the input.
+func (f *FilterForm) SetChips(chips []FilterChip) {
+	f.chips = chips
+	f.input.SetValue("")
+	f.errMsg = ""
+}
+
+// SetSuggestions sets the completions for the typed chip, best first.
+func (f *FilterForm) SetSuggestions(s []string) {
+	f.suggestions = s
+}
+
+// Suggestion returns the best completion.
+func (f *FilterForm) Suggestion() (string, bool) {
+	if len(f.suggestions) == 0 {
+		return "", false
+	}
+	return f.suggestions[0], true
+}
+
+// SetError shows why a chip was rejected.
+func (f *FilterForm) SetError(msg string) {
+	f.errMsg = msg
+}
+
+// Update forwards keys to the input.
+func (f *FilterForm) Update(msg tea.KeyMsg) tea.Cmd {
+	f.errMsg = ""
+	var cmd tea.Cmd
+	f.input, cmd = f.input.Update(msg)
+	return cmd
+}
+
+// View renders the form.
+func (f *FilterForm) View() string {
+	labelStyle := lipgloss.NewStyle().Foreground(theme.Muted).Width(8)
+	textStyle := lipgloss.NewStyle().Foreground(theme.Text)
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+
+	var sb strings.Builder
+	sb.WriteString(labelStyle.Render("View:") + " " + textStyle.Render(f.title))
+	sb.WriteString("\n")
+	chips := dimStyle.Render("none")
+	if len(f.chips) > 0 {
+		chips = chipsView(f.chips)
+	}
+	sb.WriteString(labelStyle.Render("Chips:") + " " + lipgloss.NewStyle().Width(56).Render(chips))
+	sb.WriteString("\n")
+	sb.WriteString(labelStyle.Render("Add:") + " " + f.input.View())
+	if len(f.suggestions) > 0 {
+		sb.WriteString("\n" + labelStyle.Render("") + " " + dimStyle.Render(strings.Join(f.suggestions[:min(len(f.suggestions), 5)], "  ")))
+	}
+	sb.WriteString("\n\n")
+	sb.WriteString(dimStyle.Render("Plain chips are sent to TigerBeetle; \"local\" ones filter each\nloaded page (amount, flags, ledger within one account)."))
+	sb.WriteString("\n")
+	sb.WriteString(dimStyle.Render("enter adds a chip or, when empty, applies · tab completes ·\nbackspace on empty removes the last chip"))
+
+	if f.errMsg != "" {
+		sb.WriteString("\n\n")
+		sb.WriteString(lipgloss.NewStyle().Foreground(theme.Error).Render(f.errMsg))
+	}
+	return renderModal("Filter", sb.String(), 72)
+}
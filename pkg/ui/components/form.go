@@ -0,0 +1,175 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	"github.com/fd1az/tiger-tui/business/accounts/domain"
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
+)
+
+// autoIDPlaceholder is the ID field's placeholder before an ID is generated.
+const autoIDPlaceholder = "auto (time-based)"
+
+// autoID is the time-based ID an empty ID field stands for. It is generated
+// once and kept until the item settles, so a retry after a failed request
+// resends the ID the outbox journaled instead of creating the item twice.
+type autoID struct {
+	id types.Uint128
+}
+
+// get returns the ID, generating it on first use and showing it in the ID
+// field's placeholder.
+func (a *autoID) get(input *textinput.Model) types.Uint128 {
+	if a.id == (types.Uint128{}) {
+		a.id = types.ID()
+		input.Placeholder = domain.FormatID(a.id)
+	}
+	return a.id
+}
+
+// reset drops the ID so the next item gets a fresh one.
+func (a *autoID) reset(input *textinput.Model) {
+	a.id = types.Uint128{}
+	input.Placeholder = autoIDPlaceholder
+}
+
+// resultLine is one per-item result shown after a submit.
+type resultLine struct {
+	ok   bool
+	text string
+}
+
+// newFormInput creates a text input styled like the connection form.
+func newFormInput(placeholder string, limit int) textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = placeholder
+	ti.CharLimit = limit
+	ti.Width = 40
+	styleInput(&ti)
+	return ti
+}
+
+// styleInput colors a text input with the active theme.
+func styleInput(ti *textinput.Model) {
+	ti.PromptStyle = lipgloss.NewStyle().Foreground(theme.Accent)
+	ti.TextStyle = lipgloss.NewStyle().Foreground(theme.Text)
+	ti.PlaceholderStyle = lipgloss.NewStyle().Foreground(theme.Dim)
+	ti.Cursor.Style = lipgloss.NewStyle().Foreground(theme.Accent)
+}
+
+// ModalKeys are the key map's bindings the modals act on, so remapping a
+// key changes the modals along with the dashboard.
+type ModalKeys struct {
+	Up, Down         key.Binding // move through a list
+	PageUp, PageDown key.Binding // scroll a list
+	Left, Right      key.Binding // cycle a picker's choices
+	Toggle           key.Binding // toggle a flag or column
+	MoveUp, MoveDown key.Binding // reorder a column
+	Reset            key.Binding // restore the default columns
+}
+
+// navKey reports whether msg is one of b's keys in a modal with a search
+// field, where letters and space are typed: ↑ moves, k is typed.
+func navKey(msg tea.KeyMsg, b key.Binding) bool {
+	return msg.Type != tea.KeyRunes && msg.Type != tea.KeySpace && key.Matches(msg, b)
+}
+
+// cycle moves a picker index with the left and right bindings, wrapping.
+func cycle(idx, n int, msg tea.KeyMsg, keys ModalKeys) int {
+	if n == 0 {
+		return 0
+	}
+	switch {
+	case key.Matches(msg, keys.Left):
+		return (idx + n - 1) % n
+	case key.Matches(msg, keys.Right):
+		return (idx + 1) % n
+	}
+	return idx
+}
+
+// lastN returns at most the last n elements of s.
+func lastN[T any](s []T, n int) []T {
+	if len(s) > n {
+		return s[len(s)-n:]
+	}
+	return s
+}
+
+// resultsView renders per-item results, colored by outcome.
+func resultsView(results []resultLine) string {
+	if len(results) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("\n\n")
+	sb.WriteString(lipgloss.NewStyle().Foreground(theme.Dim).Render("Results:"))
+	for _, r := range lastN(results, 8) {
+		style := lipgloss.NewStyle().Foreground(theme.Success)
+		if !r.ok {
+			style = lipgloss.NewStyle().Foreground(theme.Error)
+		}
+		sb.WriteString("\n  ")
+		sb.WriteString(style.Render(r.text))
+	}
+	if len(results) > 8 {
+		sb.WriteString(lipgloss.NewStyle().Foreground(theme.Dim).Render(fmt.Sprintf("\n  … %d more", len(results)-8)))
+	}
+	return sb.String()
+}
+
+// pickerView renders a left/right picker value.
+func pickerView(value string, focused bool) string {
+	if focused {
+		return lipgloss.NewStyle().Foreground(theme.Accent).Render("◀ " + value + " ▶")
+	}
+	return lipgloss.NewStyle().Foreground(theme.Text).Render("  " + value)
+}
+
+// checkboxView renders a flag checkbox.
+func checkboxView(label string, checked, focused bool) string {
+	box := "[ ]"
+	if checked {
+		box = "[x]"
+	}
+	style := lipgloss.NewStyle().Foreground(theme.Text)
+	if focused {
+		style = lipgloss.NewStyle().Foreground(theme.Accent).Bold(true)
+	}
+	return style.Render(box + " " + label)
+}
+
+// buttonView renders a form button like the connection screen's.
+func buttonView(label string, focused, busy bool) string {
+	switch {
+	case busy:
+		return lipgloss.NewStyle().Foreground(theme.Warning).Bold(true).Padding(0, 2).Render("Submitting...")
+	case focused:
+		return theme.Highlight(theme.Accent).
+			Bold(true).
+			Padding(0, 2).
+			Render(label)
+	default:
+		return lipgloss.NewStyle().Foreground(theme.Dim).Padding(0, 2).Render(label)
+	}
+}
+
+// renderModal draws a titled modal box with the focused accent border.
+func renderModal(title, body string, width int) string {
+	titleStyle := lipgloss.NewStyle().Foreground(theme.Accent).Bold(true)
+	hintStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+	content := titleStyle.Render(title) + hintStyle.Render("  (esc to close)") + "\n\n" + body
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Accent).
+		Padding(1, 2).
+		Width(width).
+		Render(content)
+}
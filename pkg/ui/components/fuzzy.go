@@ -0,0 +1,64 @@
+package components
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// FuzzyScore matches pattern as a case-insensitive subsequence of s. Matches
+// at the start of s or of a word, and runs of consecutive characters, score
+// higher; shorter candidates win ties.
+func FuzzyScore(pattern, s string) (int, bool) {
+	if pattern == "" {
+		return 0, true
+	}
+	p, t := strings.ToLower(pattern), strings.ToLower(s)
+	score, pi, prev := 0, 0, -2
+	for ti, r := range t {
+		if pi >= len(p) {
+			break
+		}
+		pr, size := utf8.DecodeRuneInString(p[pi:])
+		if r != pr {
+			continue
+		}
+		switch {
+		case ti == 0:
+			score += 8
+		case ti == prev+1:
+			score += 5
+		case strings.ContainsRune(" -_./", rune(t[ti-1])):
+			score += 4
+		default:
+			score++
+		}
+		prev = ti
+		pi += size
+	}
+	if pi < len(p) {
+		return 0, false
+	}
+	return score*16 - len(t), true
+}
+
+// FuzzyFilter returns the items matching pattern, best first. Items with the
+// same score keep their order.
+func FuzzyFilter(pattern string, items []string) []string {
+	type scored struct {
+		item  string
+		score int
+	}
+	var matches []scored
+	for _, it := range items {
+		if score, ok := FuzzyScore(pattern, it); ok {
+			matches = append(matches, scored{it, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	out := make([]string, len(matches))
+	for i, m := range matches {
+		out[i] = m.item
+	}
+	return out
+}
@@ -0,0 +1,152 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
+)
+
+// HelpGroup is a titled group of bindings in the help overlay, such as the
+// keys of the dashboard tables or of modals.
+type HelpGroup struct {
+	Title    string
+	Bindings []key.Binding
+}
+
+// HelpView lists key bindings by group with a search line that narrows the
+// list to bindings whose keys, description or group match.
+type HelpView struct {
+	title  string
+	groups []HelpGroup
+	input  textinput.Model
+	offset int
+	height int
+	keys   ModalKeys
+}
+
+// The lines the modal's border, padding, title, search line and footer take.
+const helpChrome = 10
+
+// NewHelpView creates the help for the given groups, fitting a terminal of
+// the given height.
+func NewHelpView(title string, groups []HelpGroup, height int, keys ModalKeys) HelpView {
+	h := HelpView{
+		title:  title,
+		groups: groups,
+		input:  newFormInput("type to search keys and actions", 40),
+		height: height,
+		keys:   keys,
+	}
+	h.input.Focus()
+	return h
+}
+
+// SetQuery fills in the search.
+func (h *HelpView) SetQuery(q string) {
+	h.input.SetValue(q)
+	h.offset = 0
+}
+
+// SetHeight fits the list to a new terminal height.
+func (h *HelpView) SetHeight(height int) {
+	h.height = height
+	h.scroll(0)
+}
+
+// Update scrolls with the up, down and page bindings; other keys, and the
+// bindings' letter keys, edit the search.
+func (h *HelpView) Update(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case navKey(msg, h.keys.Up):
+		h.scroll(-1)
+		return nil
+	case navKey(msg, h.keys.Down):
+		h.scroll(1)
+		return nil
+	case navKey(msg, h.keys.PageUp):
+		h.scroll(-h.room())
+		return nil
+	case navKey(msg, h.keys.PageDown):
+		h.scroll(h.room())
+		return nil
+	}
+	q := h.input.Value()
+	var cmd tea.Cmd
+	h.input, cmd = h.input.Update(msg)
+	if h.input.Value() != q {
+		h.offset = 0
+	}
+	return cmd
+}
+
+func (h *HelpView) scroll(n int) {
+	h.offset = min(max(h.offset+n, 0), max(len(h.lines())-h.room(), 0))
+}
+
+// room returns the number of list lines that fit.
+func (h *HelpView) room() int {
+	return max(h.height-helpChrome, 3)
+}
+
+// lines renders the groups matching the search, one binding per line.
+// Disabled bindings are left out.
+func (h *HelpView) lines() []string {
+	headingStyle := lipgloss.NewStyle().Foreground(theme.Accent).Bold(true)
+	keyStyle := lipgloss.NewStyle().Foreground(theme.Text).Bold(true).Width(16)
+	descStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+
+	q := strings.ToLower(strings.TrimSpace(h.input.Value()))
+	var lines []string
+	for _, g := range h.groups {
+		all := strings.Contains(strings.ToLower(g.Title), q)
+		var rows []string
+		for _, b := range g.Bindings {
+			if !b.Enabled() {
+				continue
+			}
+			help := b.Help()
+			if !all && !strings.Contains(strings.ToLower(help.Key+" "+help.Desc), q) {
+				continue
+			}
+			rows = append(rows, "  "+keyStyle.Render(help.Key)+descStyle.Render(help.Desc))
+		}
+		if len(rows) == 0 {
+			continue
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, headingStyle.Render(g.Title))
+		lines = append(lines, rows...)
+	}
+	return lines
+}
+
+// View renders the help modal.
+func (h *HelpView) View() string {
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+
+	var sb strings.Builder
+	sb.WriteString(lipgloss.NewStyle().Foreground(theme.Muted).Render("Search: "))
+	sb.WriteString(h.input.View())
+	sb.WriteString("\n\n")
+
+	lines := h.lines()
+	if len(lines) == 0 {
+		sb.WriteString(dimStyle.Render("No bindings match."))
+	} else {
+		end := min(h.offset+h.room(), len(lines))
+		sb.WriteString(strings.Join(lines[h.offset:end], "\n"))
+		if len(lines) > h.room() {
+			sb.WriteString("\n\n")
+			sb.WriteString(dimStyle.Render(fmt.Sprintf("↑/↓ pgup/pgdn scroll · lines %d-%d of %d", h.offset+1, end, len(lines))))
+		}
+	}
+	return renderModal(h.title, sb.String(), 64)
+}
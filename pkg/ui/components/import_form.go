@@ -0,0 +1,177 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	transfersdomain "github.com/fd1az/tiger-tui/business/transfers/domain"
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
+)
+
+// Import form fields, in focus order.
+const (
+	imFieldPath = iota
+	imFieldDryRun
+	imFieldSubmit
+	imFieldCount
+)
+
+// ImportForm asks for a CSV or NDJSON file of transfers to import and shows
+// the per-row report once it has run.
+type ImportForm struct {
+	pathInput   textinput.Model
+	dryRun      bool
+	focused     int
+	submitting  bool
+	report      *transfersdomain.ImportReport
+	resultsPath string
+	errMsg      string
+	keys        ModalKeys
+}
+
+// NewImportForm creates an empty import form.
+func NewImportForm(keys ModalKeys) ImportForm {
+	f := ImportForm{pathInput: newFormInput("transfers.csv or transfers.ndjson", 256), keys: keys}
+	f.pathInput.Width = 48
+	f.pathInput.Focus()
+	return f
+}
+
+// FocusNext moves focus to the next field.
+func (f *ImportForm) FocusNext() {
+	f.focused = (f.focused + 1) % imFieldCount
+	f.updateFocus()
+}
+
+// FocusPrev moves focus to the previous field.
+func (f *ImportForm) FocusPrev() {
+	f.focused = (f.focused + imFieldCount - 1) % imFieldCount
+	f.updateFocus()
+}
+
+// IsSubmitFocused reports whether the Import button has focus.
+func (f *ImportForm) IsSubmitFocused() bool {
+	return f.focused == imFieldSubmit
+}
+
+// Path returns the file to import.
+func (f *ImportForm) Path() string {
+	return strings.TrimSpace(f.pathInput.Value())
+}
+
+// SetPath fills in the file to import.
+func (f *ImportForm) SetPath(path string) {
+	f.pathInput.SetValue(path)
+}
+
+// DryRun reports whether the import should stop after validation.
+func (f *ImportForm) DryRun() bool {
+	return f.dryRun
+}
+
+// Submitting reports whether an import is in flight.
+func (f *ImportForm) Submitting() bool {
+	return f.submitting
+}
+
+// SetSubmitting marks an import as in flight.
+func (f *ImportForm) SetSubmitting(s bool) {
+	f.submitting = s
+	if s {
+		f.errMsg = ""
+	}
+}
+
+// SetError sets the form error message.
+func (f *ImportForm) SetError(msg string) {
+	f.errMsg = msg
+	f.submitting = false
+}
+
+// SetReport shows the outcome of an import and where its results were
+// written.
+func (f *ImportForm) SetReport(r transfersdomain.ImportReport, resultsPath string) {
+	f.submitting = false
+	f.report = &r
+	f.resultsPath = resultsPath
+}
+
+func (f *ImportForm) updateFocus() {
+	if f.focused == imFieldPath {
+		f.pathInput.Focus()
+	} else {
+		f.pathInput.Blur()
+	}
+}
+
+// Update forwards keys to the focused field.
+func (f *ImportForm) Update(msg tea.KeyMsg) tea.Cmd {
+	switch f.focused {
+	case imFieldPath:
+		var cmd tea.Cmd
+		f.pathInput, cmd = f.pathInput.Update(msg)
+		return cmd
+	case imFieldDryRun:
+		if key.Matches(msg, f.keys.Toggle) {
+			f.dryRun = !f.dryRun
+		}
+	}
+	return nil
+}
+
+// View renders the form.
+func (f *ImportForm) View() string {
+	labelStyle := lipgloss.NewStyle().Foreground(theme.Muted).Width(8)
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+
+	var sb strings.Builder
+	sb.WriteString(labelStyle.Render("File:") + " " + f.pathInput.View())
+	sb.WriteString("\n")
+	sb.WriteString(dimStyle.Render("Columns: " + strings.Join(transfersdomain.ImportColumns, ", ")))
+	sb.WriteString("\n\n")
+	sb.WriteString(labelStyle.Render("") + " " + checkboxView("dry run (validate only)", f.dryRun, f.focused == imFieldDryRun))
+	sb.WriteString("\n\n")
+	sb.WriteString(buttonView("Import", f.focused == imFieldSubmit, f.submitting))
+
+	if r := f.report; r != nil {
+		level := theme.Success
+		if r.Failures() > 0 {
+			level = theme.Warning
+		}
+		sb.WriteString("\n\n")
+		sb.WriteString(lipgloss.NewStyle().Foreground(level).Render(r.Summary()))
+		if f.resultsPath != "" {
+			sb.WriteString("\n")
+			sb.WriteString(dimStyle.Render("Results written to " + f.resultsPath))
+		}
+		sb.WriteString(resultsView(importProblems(r.Results)))
+	}
+
+	if f.errMsg != "" {
+		sb.WriteString("\n\n")
+		sb.WriteString(lipgloss.NewStyle().Foreground(theme.Error).Render(f.errMsg))
+	}
+	return renderModal("Import Transfers", sb.String(), 84)
+}
+
+// importProblems lists the rows that were not created or already present.
+func importProblems(results []transfersdomain.ImportResult) []resultLine {
+	var lines []resultLine
+	for _, r := range results {
+		switch {
+		case r.OK(), r.Result == "exists", r.Result == transfersdomain.ImportValid,
+			r.Result == transfersdomain.ImportNotSubmitted:
+			continue
+		case r.Error != "":
+			lines = append(lines, resultLine{text: fmt.Sprintf("line %d  %s", r.Line, r.Error)})
+		default:
+			lines = append(lines, resultLine{text: fmt.Sprintf("line %d  %s", r.Line, r.Result)})
+		}
+	}
+	return lines
+}
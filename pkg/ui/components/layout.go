@@ -0,0 +1,277 @@
+package components
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// SortKey orders rows by one column.
+type SortKey struct {
+	Column string `json:"column"`
+	Desc   bool   `json:"desc,omitempty"`
+}
+
+// String renders the key as the :sort command takes it, e.g. "amount:desc".
+func (k SortKey) String() string {
+	if k.Desc {
+		return k.Column + ":desc"
+	}
+	return k.Column
+}
+
+// Layout is the user's choice of column order, visibility and sort for a
+// table. The zero Layout is the table's default.
+type Layout struct {
+	// Columns lists column keys in display order. Columns missing from it,
+	// such as ones added in a later version, follow in definition order
+	// with their default visibility.
+	Columns []string  `json:"columns,omitempty"`
+	Hidden  []string  `json:"hidden,omitempty"`
+	Sort    []SortKey `json:"sort,omitempty"`
+}
+
+// SetLayout applies a layout, ignoring unknown columns. Rows must be sorted
+// again with Sort.
+func (t *Table) SetLayout(l Layout) {
+	t.arrange = t.arrange[:0]
+	t.hidden = make([]bool, len(t.columns))
+	listed := make([]bool, len(t.columns))
+	for _, k := range l.Columns {
+		if i := t.column(k); i >= 0 && !listed[i] {
+			listed[i] = true
+			t.arrange = append(t.arrange, i)
+			t.hidden[i] = slices.Contains(l.Hidden, k)
+		}
+	}
+	for i, c := range t.columns {
+		if !listed[i] {
+			t.arrange = append(t.arrange, i)
+			t.hidden[i] = c.Hidden
+		}
+	}
+	if len(t.shown()) == 0 && len(t.columns) > 0 {
+		t.hidden[t.arrange[0]] = false
+	}
+
+	t.sortBy = nil
+	for _, k := range l.Sort {
+		if t.column(k.Column) >= 0 && !slices.ContainsFunc(t.sortBy, func(s SortKey) bool { return s.Column == k.Column }) {
+			t.sortBy = append(t.sortBy, k)
+		}
+	}
+}
+
+// Layout returns the table's current layout.
+func (t *Table) Layout() Layout {
+	l := Layout{Sort: slices.Clone(t.sortBy)}
+	for _, i := range t.arrange {
+		l.Columns = append(l.Columns, t.columns[i].Key)
+		if t.hidden[i] {
+			l.Hidden = append(l.Hidden, t.columns[i].Key)
+		}
+	}
+	return l
+}
+
+// Columns returns every column in display order and whether it is shown.
+func (t *Table) Columns() ([]Column, []bool) {
+	cols := make([]Column, len(t.arrange))
+	shown := make([]bool, len(t.arrange))
+	for j, i := range t.arrange {
+		cols[j] = t.columns[i]
+		shown[j] = !t.hidden[i]
+	}
+	return cols, shown
+}
+
+// ColumnKeys returns the keys of every column in display order.
+func (t *Table) ColumnKeys() []string {
+	keys := make([]string, len(t.arrange))
+	for j, i := range t.arrange {
+		keys[j] = t.columns[i].Key
+	}
+	return keys
+}
+
+// column returns the index of the column with key k, or -1.
+func (t *Table) column(k string) int {
+	return slices.IndexFunc(t.columns, func(c Column) bool { return c.Key == k })
+}
+
+// shown returns the displayed column indices in order.
+func (t *Table) shown() []int {
+	var cols []int
+	for _, i := range t.arrange {
+		if !t.hidden[i] {
+			cols = append(cols, i)
+		}
+	}
+	return cols
+}
+
+// SortKeys returns the sort, primary key first.
+func (t *Table) SortKeys() []SortKey {
+	return t.sortBy
+}
+
+// SetSort replaces the sort. An empty sort shows rows in load order. Rows
+// must be sorted again with Sort.
+func (t *Table) SetSort(keys []SortKey) error {
+	for i, k := range keys {
+		if t.column(k.Column) < 0 {
+			return fmt.Errorf("unknown column %q, want one of %s", k.Column, strings.Join(t.ColumnKeys(), ", "))
+		}
+		if slices.ContainsFunc(keys[:i], func(s SortKey) bool { return s.Column == k.Column }) {
+			return fmt.Errorf("%s is sorted by twice", k.Column)
+		}
+	}
+	t.sortBy = slices.Clone(keys)
+	return nil
+}
+
+// CycleSort moves the primary sort to the next shown column, ascending,
+// keeping the other keys. Past the last column rows return to load order.
+// Rows must be sorted again with Sort.
+func (t *Table) CycleSort() {
+	shown := t.shown()
+	next := 0
+	if len(t.sortBy) > 0 {
+		next = slices.Index(shown, t.column(t.sortBy[0].Column)) + 1
+	}
+	if next >= len(shown) {
+		t.sortBy = nil
+		return
+	}
+	key := t.columns[shown[next]].Key
+	var rest []SortKey
+	if len(t.sortBy) > 0 {
+		rest = slices.DeleteFunc(slices.Clone(t.sortBy[1:]), func(s SortKey) bool { return s.Column == key })
+	}
+	t.sortBy = append([]SortKey{{Column: key}}, rest...)
+}
+
+// ReverseSort flips the direction of the primary sort. It reports false
+// when the table is not sorted. Rows must be sorted again with Sort.
+func (t *Table) ReverseSort() bool {
+	if len(t.sortBy) == 0 {
+		return false
+	}
+	t.sortBy = slices.Clone(t.sortBy)
+	t.sortBy[0].Desc = !t.sortBy[0].Desc
+	return true
+}
+
+// SortSummary describes the sort, e.g. "amount ▼, ledger ▲", or "" when
+// rows are in load order.
+func (t *Table) SortSummary() string {
+	parts := make([]string, len(t.sortBy))
+	for i, k := range t.sortBy {
+		parts[i] = k.Column + " " + sortArrow(k.Desc)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Sort orders the rows by the sort keys using compare, which compares the
+// cells of column key in rows a and b. Ties keep load order and the cursor
+// stays on the same row.
+func (t *Table) Sort(compare func(key string, a, b int) int) {
+	row := t.Row()
+	t.hitView = nil
+	if len(t.sortBy) == 0 {
+		t.order, t.pos = nil, nil
+	} else {
+		order := make([]int, len(t.rows))
+		for i := range order {
+			order[i] = i
+		}
+		slices.SortStableFunc(order, func(a, b int) int {
+			for _, k := range t.sortBy {
+				c := compare(k.Column, a, b)
+				if k.Desc {
+					c = -c
+				}
+				if c != 0 {
+					return c
+				}
+			}
+			return 0
+		})
+		t.order = order
+		t.pos = make([]int, len(order))
+		for v, i := range order {
+			t.pos[i] = v
+		}
+	}
+	if row >= 0 {
+		t.SetCursor(t.viewOf(row))
+	}
+}
+
+// viewOf maps a row index to its view position.
+func (t *Table) viewOf(row int) int {
+	if t.pos == nil {
+		return row
+	}
+	return t.pos[row]
+}
+
+// compareText compares the cells of column key as text.
+func (t *Table) compareText(key string, a, b int) int {
+	col := t.column(key)
+	return strings.Compare(t.rows[a][col], t.rows[b][col])
+}
+
+// sortMarker returns the header suffix of a sorted column, numbered when
+// several columns are sorted.
+func (t *Table) sortMarker(key string) string {
+	i := slices.IndexFunc(t.sortBy, func(s SortKey) bool { return s.Column == key })
+	switch {
+	case i < 0:
+		return ""
+	case len(t.sortBy) == 1:
+		return " " + sortArrow(t.sortBy[i].Desc)
+	}
+	return " " + sortArrow(t.sortBy[i].Desc) + strconv.Itoa(i+1)
+}
+
+func sortArrow(desc bool) string {
+	if desc {
+		return "▼"
+	}
+	return "▲"
+}
+
+// ParseSort parses sort keys such as "amount:desc" or "ledger:asc".
+func ParseSort(args []string) ([]SortKey, error) {
+	keys := make([]SortKey, len(args))
+	for i, a := range args {
+		col, dir, _ := strings.Cut(a, ":")
+		keys[i].Column = col
+		switch dir {
+		case "", "asc":
+		case "desc":
+			keys[i].Desc = true
+		default:
+			return nil, fmt.Errorf("%s: want asc or desc, not %q", col, dir)
+		}
+	}
+	return keys, nil
+}
+
+// compareUint128 compares two unsigned 128-bit values, which TigerBeetle
+// stores little-endian.
+func compareUint128(a, b types.Uint128) int {
+	for i := len(a) - 1; i >= 0; i-- {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
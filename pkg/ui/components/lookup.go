@@ -0,0 +1,220 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	"github.com/fd1az/tiger-tui/business/accounts/domain"
+	lookupdomain "github.com/fd1az/tiger-tui/business/lookup/domain"
+	transfersdomain "github.com/fd1az/tiger-tui/business/transfers/domain"
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
+)
+
+// LookupPrompt asks for an account or transfer ID.
+type LookupPrompt struct {
+	input     textinput.Model
+	searching bool
+	errMsg    string
+}
+
+// NewLookupPrompt creates an empty, focused lookup prompt.
+func NewLookupPrompt() LookupPrompt {
+	p := LookupPrompt{input: newFormInput("decimal, 0x-hex or UUID", 39)}
+	p.input.Focus()
+	return p
+}
+
+// Update forwards keys to the ID input.
+func (p *LookupPrompt) Update(msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	return cmd
+}
+
+// SetValue fills in the ID.
+func (p *LookupPrompt) SetValue(s string) {
+	p.input.SetValue(s)
+}
+
+// ID parses the typed ID.
+func (p *LookupPrompt) ID() (types.Uint128, error) {
+	id, err := domain.ParseID(p.input.Value())
+	if err != nil {
+		p.errMsg = err.Error()
+	}
+	return id, err
+}
+
+// Searching reports whether a lookup is in flight.
+func (p *LookupPrompt) Searching() bool {
+	return p.searching
+}
+
+// SetSearching marks a lookup as in flight.
+func (p *LookupPrompt) SetSearching(s bool) {
+	p.searching = s
+	if s {
+		p.errMsg = ""
+	}
+}
+
+// SetError shows a failed lookup.
+func (p *LookupPrompt) SetError(msg string) {
+	p.errMsg = msg
+	p.searching = false
+}
+
+// View renders the prompt.
+func (p *LookupPrompt) View() string {
+	var sb strings.Builder
+	sb.WriteString(lipgloss.NewStyle().Foreground(theme.Muted).Render("ID: "))
+	sb.WriteString(p.input.View())
+	if p.searching {
+		sb.WriteString("\n\n")
+		sb.WriteString(lipgloss.NewStyle().Foreground(theme.Warning).Render("Looking up accounts and transfers..."))
+	}
+	if p.errMsg != "" {
+		sb.WriteString("\n\n")
+		sb.WriteString(lipgloss.NewStyle().Foreground(theme.Error).Render(p.errMsg))
+	}
+	return renderModal("Lookup", sb.String(), 64)
+}
+
+// LookupDetail shows every field of the account and/or transfer found by a
+// lookup.
+type LookupDetail struct {
+	result lookupdomain.Result
+}
+
+// NewLookupDetail creates the detail view for a lookup result.
+func NewLookupDetail(result lookupdomain.Result) LookupDetail {
+	return LookupDetail{result: result}
+}
+
+// Account returns the account found, if any.
+func (d *LookupDetail) Account() (types.Account, bool) {
+	if d.result.Account == nil {
+		return types.Account{}, false
+	}
+	return *d.result.Account, true
+}
+
+// View renders the detail modal.
+func (d *LookupDetail) View() string {
+	var sections []string
+	if acc := d.result.Account; acc != nil {
+		sections = append(sections, accountDetail(*acc))
+	}
+	if t := d.result.Transfer; t != nil {
+		sections = append(sections, transferDetail(*t))
+	}
+	body := strings.Join(sections, "\n\n")
+	if d.result.Account != nil {
+		body += "\n\n" + lipgloss.NewStyle().Foreground(theme.Dim).Render("enter: show the account's transfers")
+	}
+	return renderModal("Lookup "+domain.FormatID(d.result.ID), body, 84)
+}
+
+// detailFields renders label/value lines under a section heading.
+func detailFields(heading string, fields [][2]string) string {
+	headingStyle := lipgloss.NewStyle().Foreground(theme.Accent).Bold(true)
+	labelStyle := lipgloss.NewStyle().Foreground(theme.Muted).Width(18)
+	textStyle := lipgloss.NewStyle().Foreground(theme.Text)
+
+	var sb strings.Builder
+	sb.WriteString(headingStyle.Render(heading))
+	for _, f := range fields {
+		sb.WriteString("\n")
+		sb.WriteString(labelStyle.Render(f[0]))
+		sb.WriteString(textStyle.Render(f[1]))
+	}
+	return sb.String()
+}
+
+// idFields lists an ID in decimal, UUID and hex form.
+func idFields(label string, id types.Uint128) [][2]string {
+	return [][2]string{
+		{label, domain.FormatID(id)},
+		{"  as UUID", domain.FormatUUID(id)},
+		{"  as hex", domain.FormatHex(id)},
+	}
+}
+
+// userData128Fields lists user_data_128, with its UUID form when set.
+func userData128Fields(v types.Uint128) [][2]string {
+	if v == (types.Uint128{}) {
+		return [][2]string{{"User Data 128", "0"}}
+	}
+	return idFields("User Data 128", v)[:2]
+}
+
+// flagsField joins flag names, or "none".
+func flagsField(names []string) string {
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
+
+func accountDetail(acc types.Account) string {
+	return detailFields("Account", accountFields(acc))
+}
+
+// accountFields lists every field of an account with chart labels.
+func accountFields(acc types.Account) [][2]string {
+	l := acc.Ledger
+	fields := idFields("ID", acc.ID)
+	fields = append(fields, [][2]string{
+		{"Ledger", fmt.Sprintf("%s (%d)", domain.LedgerLabel(l), l)},
+		{"Type", fmt.Sprintf("%s (%d)", domain.AccountTypeName(acc.Code), acc.Code)},
+		{"Flags", flagsField(domain.AccountFlagNames(acc.Flags))},
+		{"Debits Posted", domain.FormatAmount(acc.DebitsPosted, l)},
+		{"Credits Posted", domain.FormatAmount(acc.CreditsPosted, l)},
+		{"Debits Pending", domain.FormatAmount(acc.DebitsPending, l)},
+		{"Credits Pending", domain.FormatAmount(acc.CreditsPending, l)},
+		{"Balance", domain.FormatBigAmount(domain.NetBalance(acc.Flags, acc.DebitsPosted, acc.CreditsPosted), l)},
+	}...)
+	fields = append(fields, userData128Fields(acc.UserData128)...)
+	fields = append(fields, [][2]string{
+		{"User Data 64", fmt.Sprintf("%d", acc.UserData64)},
+		{"User Data 32", fmt.Sprintf("%d", acc.UserData32)},
+		{"Timestamp", domain.FormatTimestamp(acc.Timestamp)},
+	}...)
+	return fields
+}
+
+func transferDetail(t types.Transfer) string {
+	return detailFields("Transfer", transferFields(t))
+}
+
+// transferFields lists every field of a transfer with chart labels.
+func transferFields(t types.Transfer) [][2]string {
+	l := t.Ledger
+	fields := idFields("ID", t.ID)
+	fields = append(fields, [][2]string{
+		{"Debit Account", domain.FormatID(t.DebitAccountID)},
+		{"Credit Account", domain.FormatID(t.CreditAccountID)},
+		{"Amount", domain.NewMoney(t.Amount, l).String()},
+		{"Ledger", fmt.Sprintf("%s (%d)", domain.LedgerLabel(l), l)},
+		{"Type", fmt.Sprintf("%s (%d)", domain.TransferTypeName(t.Code), t.Code)},
+		{"Flags", flagsField(transfersdomain.TransferFlagNames(t.Flags))},
+	}...)
+	if t.PendingID != (types.Uint128{}) {
+		fields = append(fields, [2]string{"Pending ID", domain.FormatID(t.PendingID)})
+	}
+	if t.Timeout > 0 {
+		fields = append(fields, [2]string{"Timeout", fmt.Sprintf("%ds", t.Timeout)})
+	}
+	fields = append(fields, userData128Fields(t.UserData128)...)
+	fields = append(fields,
+		[2]string{"User Data 64", fmt.Sprintf("%d", t.UserData64)},
+		[2]string{"Venue", fmt.Sprintf("%s (%d)", domain.VenueName(t.UserData32), t.UserData32)},
+		[2]string{"Timestamp", domain.FormatTimestamp(t.Timestamp)},
+	)
+	return fields
+}
@@ -0,0 +1,273 @@
+package components
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	"github.com/fd1az/tiger-tui/business/accounts/domain"
+	transfersdomain "github.com/fd1az/tiger-tui/business/transfers/domain"
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
+)
+
+// Countdown columns, refreshed on every tick.
+const (
+	holdAgeCol     = 6
+	holdExpiresCol = 7
+)
+
+// PendingTable lists unresolved pending transfers (holds) with their age and
+// the time left until they expire.
+type PendingTable struct {
+	table   Table
+	holds   []transfersdomain.Hold
+	now     time.Time // of the last refresh, for ages and countdowns
+	loading bool
+	loaded  bool
+}
+
+// NewPendingTable creates an empty pending transfers table.
+func NewPendingTable() PendingTable {
+	return PendingTable{
+		table: NewTable([]Column{
+			{Key: "id", Title: "ID", Width: 24, Priority: 9},
+			{Key: "code", Title: "Type", Width: 16, Priority: 5},
+			{Key: "debit_account", Title: "Debit Account", Width: 24, Priority: 7},
+			{Key: "credit_account", Title: "Credit Account", Width: 24, Priority: 7},
+			{Key: "amount", Title: "Amount", Width: 20, Right: true, Priority: 9},
+			{Key: "ledger", Title: "Ledger", Width: 6, Priority: 6},
+			{Key: "age", Title: "Age", Width: 8, Right: true, Priority: 4},
+			{Key: "expires", Title: "Expires In", Width: 10, Right: true, Priority: 8, Style: countdownStyle},
+			{Key: "venue", Title: "Venue", Width: 10, Hidden: true, Priority: 3},
+			{Key: "user_data_128", Title: "User Data 128", Width: 24, Hidden: true, Priority: 1},
+			{Key: "user_data_64", Title: "User Data 64", Width: 20, Right: true, Hidden: true, Priority: 1},
+			{Key: "timestamp", Title: "Timestamp", Width: 23, Hidden: true, Priority: 2},
+		}),
+	}
+}
+
+// SetSize sets the available width and height (including header and footer).
+func (p *PendingTable) SetSize(w, h int) {
+	p.table.SetSize(w, h-3)
+}
+
+// SetLoading marks a scan as in flight.
+func (p *PendingTable) SetLoading(loading bool) {
+	p.loading = loading
+}
+
+// SetHolds replaces the listed holds.
+func (p *PendingTable) SetHolds(holds []transfersdomain.Hold, now time.Time) {
+	p.holds = holds
+	p.now = now
+	rows := make([][]string, len(holds))
+	for i, h := range holds {
+		t := h.Transfer
+		rows[i] = []string{
+			domain.FormatID(t.ID),
+			domain.TransferTypeName(t.Code),
+			domain.FormatID(t.DebitAccountID),
+			domain.FormatID(t.CreditAccountID),
+			domain.FormatAmount(t.Amount, t.Ledger),
+			domain.LedgerLabel(t.Ledger),
+			formatCountdown(h.Age(now)),
+			holdExpiry(h, now),
+			domain.VenueName(t.UserData32),
+			optionalID(t.UserData128),
+			optionalUint(t.UserData64),
+			domain.FormatTimestamp(t.Timestamp),
+		}
+	}
+	p.table.SetRows(rows)
+	p.Resort()
+	p.loading = false
+	p.loaded = true
+}
+
+// Tick refreshes the age and countdown columns.
+func (p *PendingTable) Tick(now time.Time) {
+	p.now = now
+	for i, h := range p.holds {
+		p.table.SetCell(i, holdAgeCol, formatCountdown(h.Age(now)))
+		p.table.SetCell(i, holdExpiresCol, holdExpiry(h, now))
+	}
+}
+
+// Remove drops the hold with the given pending ID, e.g. once it has been
+// posted or voided.
+func (p *PendingTable) Remove(id types.Uint128) {
+	for i, h := range p.holds {
+		if h.Transfer.ID != id {
+			continue
+		}
+		cursor := p.table.Cursor()
+		holds := append(p.holds[:i:i], p.holds[i+1:]...)
+		p.SetHolds(holds, time.Now())
+		p.table.SetCursor(cursor)
+		return
+	}
+}
+
+// Holds returns the listed holds in load order.
+func (p *PendingTable) Holds() []transfersdomain.Hold {
+	return p.holds
+}
+
+// SortedHolds returns the listed holds in the order they are shown.
+func (p *PendingTable) SortedHolds() []transfersdomain.Hold {
+	order := p.table.Order()
+	holds := make([]transfersdomain.Hold, len(order))
+	for v, i := range order {
+		holds[v] = p.holds[i]
+	}
+	return holds
+}
+
+// Selected returns the hold under the cursor.
+func (p *PendingTable) Selected() (transfersdomain.Hold, bool) {
+	i := p.table.Row()
+	if i < 0 || i >= len(p.holds) {
+		return transfersdomain.Hold{}, false
+	}
+	return p.holds[i], true
+}
+
+// Details renders every field of the selected hold with its age and time
+// left, or "" when there is none.
+func (p *PendingTable) Details() string {
+	h, ok := p.Selected()
+	if !ok {
+		return ""
+	}
+	fields := append([][2]string{
+		{"Age", formatCountdown(h.Age(p.now))},
+		{"Expires In", holdExpiry(h, p.now)},
+	}, transferFields(h.Transfer)...)
+	return detailFields("Pending Transfer", fields)
+}
+
+// Table returns the underlying table, for layout changes.
+func (p *PendingTable) Table() *Table {
+	return &p.table
+}
+
+// Resort orders the rows after the table's sort changed.
+func (p *PendingTable) Resort() {
+	p.table.Sort(p.compare)
+}
+
+// compare orders two holds by column key. Age follows creation time and
+// holds without a timeout expire last.
+func (p *PendingTable) compare(key string, i, j int) int {
+	x, y := &p.holds[i], &p.holds[j]
+	switch key {
+	case "id":
+		return compareUint128(x.Transfer.ID, y.Transfer.ID)
+	case "code":
+		return cmp.Compare(x.Transfer.Code, y.Transfer.Code)
+	case "debit_account":
+		return compareUint128(x.Transfer.DebitAccountID, y.Transfer.DebitAccountID)
+	case "credit_account":
+		return compareUint128(x.Transfer.CreditAccountID, y.Transfer.CreditAccountID)
+	case "amount":
+		return compareUint128(x.Transfer.Amount, y.Transfer.Amount)
+	case "ledger":
+		return cmp.Compare(x.Transfer.Ledger, y.Transfer.Ledger)
+	case "age":
+		return cmp.Compare(y.Transfer.Timestamp, x.Transfer.Timestamp)
+	case "expires":
+		switch {
+		case x.HasTimeout() != y.HasTimeout():
+			if x.HasTimeout() {
+				return -1
+			}
+			return 1
+		case !x.HasTimeout():
+			return 0
+		}
+		return x.ExpiresAt().Compare(y.ExpiresAt())
+	case "venue":
+		return cmp.Compare(x.Transfer.UserData32, y.Transfer.UserData32)
+	case "user_data_128":
+		return compareUint128(x.Transfer.UserData128, y.Transfer.UserData128)
+	case "user_data_64":
+		return cmp.Compare(x.Transfer.UserData64, y.Transfer.UserData64)
+	case "timestamp":
+		return cmp.Compare(x.Transfer.Timestamp, y.Transfer.Timestamp)
+	}
+	return p.table.compareText(key, i, j)
+}
+
+// MoveUp moves the selection up n rows.
+func (p *PendingTable) MoveUp(n int) {
+	p.table.MoveUp(n)
+}
+
+// MoveDown moves the selection down n rows.
+func (p *PendingTable) MoveDown(n int) {
+	p.table.MoveDown(n)
+}
+
+// View renders the pending transfers table.
+func (p *PendingTable) View() string {
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+
+	switch {
+	case !p.loaded:
+		return dimStyle.Render("  Scanning transfers for open holds...")
+	case len(p.holds) == 0:
+		return dimStyle.Render("  No open pending transfers.")
+	}
+
+	footer := fmt.Sprintf("  %d open holds · p post · P post partial · v void", len(p.holds))
+	if p.loading {
+		footer += " · rescanning..."
+	}
+	return p.table.View() + "\n" + dimStyle.Render(footer)
+}
+
+// holdExpiry renders the time left on a hold.
+func holdExpiry(h transfersdomain.Hold, now time.Time) string {
+	switch {
+	case !h.HasTimeout():
+		return "never"
+	case h.Expired(now):
+		return "expired"
+	}
+	return formatCountdown(h.Remaining(now))
+}
+
+// formatCountdown renders a duration with its two most significant units,
+// e.g. "3d04h", "2h05m", "4m30s".
+func formatCountdown(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	s := int64(d / time.Second)
+	switch {
+	case s >= 86400:
+		return fmt.Sprintf("%dd%02dh", s/86400, s%86400/3600)
+	case s >= 3600:
+		return fmt.Sprintf("%dh%02dm", s/3600, s%3600/60)
+	case s >= 60:
+		return fmt.Sprintf("%dm%02ds", s/60, s%60)
+	}
+	return fmt.Sprintf("%ds", s)
+}
+
+// countdownStyle colors expired holds and holds with under a minute left.
+func countdownStyle(v string) lipgloss.Style {
+	switch {
+	case v == "expired":
+		return lipgloss.NewStyle().Foreground(theme.Error)
+	case v == "never":
+		return lipgloss.NewStyle().Foreground(theme.Muted)
+	case !strings.ContainsAny(v, "dhm"):
+		return lipgloss.NewStyle().Foreground(theme.Warning)
+	}
+	return lipgloss.NewStyle().Foreground(theme.Text)
+}
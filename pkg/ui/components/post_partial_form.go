@@ -0,0 +1,104 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	"github.com/fd1az/tiger-tui/business/accounts/domain"
+	transfersdomain "github.com/fd1az/tiger-tui/business/transfers/domain"
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
+)
+
+// PostPartialForm asks for the amount to post from a pending transfer. The
+// rest of the hold is released.
+type PostPartialForm struct {
+	hold        transfersdomain.Hold
+	amountInput textinput.Model
+	submitting  bool
+	errMsg      string
+}
+
+// NewPostPartialForm creates the prompt for a hold.
+func NewPostPartialForm(hold transfersdomain.Hold) PostPartialForm {
+	f := PostPartialForm{
+		hold:        hold,
+		amountInput: newFormInput("amount to post", 48),
+	}
+	f.amountInput.Focus()
+	return f
+}
+
+// Hold returns the hold being posted.
+func (f *PostPartialForm) Hold() transfersdomain.Hold {
+	return f.hold
+}
+
+// Submitting reports whether a submit is in flight.
+func (f *PostPartialForm) Submitting() bool {
+	return f.submitting
+}
+
+// SetSubmitting marks a submit as in flight.
+func (f *PostPartialForm) SetSubmitting(s bool) {
+	f.submitting = s
+}
+
+// SetError sets the form error message.
+func (f *PostPartialForm) SetError(msg string) {
+	f.errMsg = msg
+	f.submitting = false
+}
+
+// Update forwards keys to the amount input.
+func (f *PostPartialForm) Update(msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+	f.amountInput, cmd = f.amountInput.Update(msg)
+	return cmd
+}
+
+// Build validates the amount and returns the post-pending transfer.
+func (f *PostPartialForm) Build() (types.Transfer, error) {
+	p := f.hold.Transfer
+	amount, err := domain.ParseMoney(f.amountInput.Value(), p.Ledger)
+	if err != nil {
+		f.errMsg = err.Error()
+		return types.Transfer{}, err
+	}
+	held := domain.NewMoney(p.Amount, p.Ledger)
+	if amount.IsZero() || amount.Cmp(held) > 0 {
+		err := fmt.Errorf("amount must be above 0 and at most %s", held)
+		f.errMsg = err.Error()
+		return types.Transfer{}, err
+	}
+	f.errMsg = ""
+	return transfersdomain.PostPending(p, amount.Amount), nil
+}
+
+// View renders the prompt.
+func (f *PostPartialForm) View() string {
+	labelStyle := lipgloss.NewStyle().Foreground(theme.Muted).Width(10)
+	textStyle := lipgloss.NewStyle().Foreground(theme.Text)
+
+	p := f.hold.Transfer
+	var sb strings.Builder
+	sb.WriteString(labelStyle.Render("Pending:") + " " + textStyle.Render(domain.FormatID(p.ID)))
+	sb.WriteString("\n")
+	sb.WriteString(labelStyle.Render("Type:") + " " + textStyle.Render(domain.TransferTypeName(p.Code)))
+	sb.WriteString("\n")
+	sb.WriteString(labelStyle.Render("Held:") + " " + textStyle.Render(domain.NewMoney(p.Amount, p.Ledger).String()))
+	sb.WriteString("\n\n")
+	sb.WriteString(labelStyle.Render("Post:") + " " + f.amountInput.View())
+	sb.WriteString("\n\n")
+	sb.WriteString(buttonView("Post", true, f.submitting))
+
+	if f.errMsg != "" {
+		sb.WriteString("\n\n")
+		sb.WriteString(lipgloss.NewStyle().Foreground(theme.Error).Render(f.errMsg))
+	}
+	return renderModal("Post Partial", sb.String(), 64)
+}
@@ -0,0 +1,199 @@
+package components
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	"github.com/fd1az/tiger-tui/business/accounts/domain"
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
+)
+
+// Search is an incremental substring search over the rows of a table. Each
+// row's text is lower-cased once when the row is loaded, and a query that
+// extends the previous one only rescans the previous hits, so typing stays
+// fast with hundreds of thousands of rows.
+type Search struct {
+	texts []string // per row, lower-cased
+	query string   // lower-cased
+	hits  []int    // matching row indices, ascending
+}
+
+// reset drops the indexed rows, keeping the query for the rows that follow.
+func (s *Search) reset() {
+	s.texts = nil
+	s.hits = nil
+}
+
+// add indexes rows appended to the table and matches them.
+func (s *Search) add(texts []string) {
+	base := len(s.texts)
+	s.texts = append(s.texts, texts...)
+	if s.query == "" {
+		return
+	}
+	for i, t := range texts {
+		if strings.Contains(t, s.query) {
+			s.hits = append(s.hits, base+i)
+		}
+	}
+}
+
+// setQuery matches the indexed rows against q.
+func (s *Search) setQuery(q string) {
+	q = strings.ToLower(q)
+	switch {
+	case q == s.query:
+		return
+	case q == "":
+		s.hits = nil
+	case s.query != "" && strings.Contains(q, s.query):
+		// Every row matching q matched the previous query.
+		hits := s.hits[:0]
+		for _, i := range s.hits {
+			if strings.Contains(s.texts[i], q) {
+				hits = append(hits, i)
+			}
+		}
+		s.hits = hits
+	default:
+		s.hits = nil
+		for i, t := range s.texts {
+			if strings.Contains(t, q) {
+				s.hits = append(s.hits, i)
+			}
+		}
+	}
+	s.query = q
+}
+
+// hit reports whether row matches the query.
+func (s *Search) hit(row int) bool {
+	i := sort.SearchInts(s.hits, row)
+	return i < len(s.hits) && s.hits[i] == row
+}
+
+// nextHit returns the first of the ascending positions at or after v,
+// wrapping around.
+func nextHit(hits []int, v int) (int, bool) {
+	if len(hits) == 0 {
+		return 0, false
+	}
+	if i := sort.SearchInts(hits, v); i < len(hits) {
+		return hits[i], true
+	}
+	return hits[0], true
+}
+
+// prevHit returns the last of the ascending positions before v, wrapping
+// around.
+func prevHit(hits []int, v int) (int, bool) {
+	if len(hits) == 0 {
+		return 0, false
+	}
+	if i := sort.SearchInts(hits, v); i > 0 {
+		return hits[i-1], true
+	}
+	return hits[len(hits)-1], true
+}
+
+// hitPosition returns the 1-based position of v among the ascending
+// positions, or 0.
+func hitPosition(hits []int, v int) int {
+	i := sort.SearchInts(hits, v)
+	if i < len(hits) && hits[i] == v {
+		return i + 1
+	}
+	return 0
+}
+
+// searchText joins the fields incremental search matches: IDs in decimal,
+// the type name, ledger symbol, venue name and user data. Fields are
+// separated so a match never spans two of them.
+func searchText(ids []types.Uint128, typeName string, ledger uint32, venue uint32, userData128 types.Uint128, userData64 uint64) string {
+	fields := make([]string, 0, len(ids)+5)
+	for _, id := range ids {
+		fields = append(fields, domain.FormatID(id))
+	}
+	fields = append(fields, typeName, domain.LedgerLabel(ledger))
+	if name, ok := domain.ActiveChart().Venues[venue]; ok {
+		fields = append(fields, name)
+	}
+	if userData128 != (types.Uint128{}) {
+		fields = append(fields, domain.FormatID(userData128))
+	}
+	if userData64 != 0 {
+		fields = append(fields, strconv.FormatUint(userData64, 10))
+	}
+	return strings.ToLower(strings.Join(fields, "\x00"))
+}
+
+// highlight renders cell with every case-insensitive occurrence of query
+// marked. query is lower-cased.
+func highlight(cell, query string, base lipgloss.Style) string {
+	mark := theme.Highlight(theme.Warning)
+	lower := strings.ToLower(cell)
+	if query == "" || len(lower) != len(cell) || !strings.Contains(lower, query) {
+		return base.Render(cell)
+	}
+	var sb strings.Builder
+	for {
+		i := strings.Index(lower, query)
+		if i < 0 {
+			break
+		}
+		sb.WriteString(base.Render(cell[:i]))
+		sb.WriteString(mark.Render(cell[i : i+len(query)]))
+		cell, lower = cell[i+len(query):], lower[i+len(query):]
+	}
+	sb.WriteString(base.Render(cell))
+	return sb.String()
+}
+
+// SearchBar is the "/" line shown in place of the status bar while a search
+// is typed, with the match count next to it.
+type SearchBar struct {
+	input  textinput.Model
+	status string
+	width  int
+}
+
+// NewSearchBar creates an empty, focused search bar.
+func NewSearchBar(width int) SearchBar {
+	ti := newFormInput("search loaded rows", 128)
+	ti.Prompt = "/"
+	ti.Width = max(width/2, 20)
+	ti.Focus()
+	return SearchBar{input: ti, width: width}
+}
+
+// Value returns the typed query.
+func (b *SearchBar) Value() string {
+	return b.input.Value()
+}
+
+// SetStatus sets the text shown after the query, such as the match count.
+func (b *SearchBar) SetStatus(s string) {
+	b.status = s
+}
+
+// Update forwards keys to the input.
+func (b *SearchBar) Update(msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+	b.input, cmd = b.input.Update(msg)
+	return cmd
+}
+
+// View renders the search line.
+func (b *SearchBar) View() string {
+	line := b.input.View()
+	if b.status != "" {
+		line += "  " + lipgloss.NewStyle().Foreground(theme.Muted).Render(b.status)
+	}
+	return lipgloss.NewStyle().MaxWidth(b.width).Render(line)
+}
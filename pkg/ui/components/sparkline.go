@@ -0,0 +1,47 @@
+package components
+
+import (
+	"math/big"
+	"strings"
+)
+
+// sparkBlocks are the eight sparkline levels, lowest first.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a single line of block characters at most width
+// cells wide. When there are more values than cells, the most recent values
+// are kept. Scaling uses exact integer arithmetic.
+func Sparkline(values []*big.Int, width int) string {
+	if len(values) == 0 || width <= 0 {
+		return ""
+	}
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values[1:] {
+		if v.Cmp(lo) < 0 {
+			lo = v
+		}
+		if v.Cmp(hi) > 0 {
+			hi = v
+		}
+	}
+	span := new(big.Int).Sub(hi, lo)
+	top := big.NewInt(int64(len(sparkBlocks) - 1))
+
+	var sb strings.Builder
+	for _, v := range values {
+		if span.Sign() == 0 {
+			sb.WriteRune(sparkBlocks[len(sparkBlocks)/2])
+			continue
+		}
+		// level = (v - lo) * top / span
+		level := new(big.Int).Sub(v, lo)
+		level.Mul(level, top)
+		level.Quo(level, span)
+		sb.WriteRune(sparkBlocks[level.Int64()])
+	}
+	return sb.String()
+}
@@ -6,22 +6,33 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
 )
 
 // StatusBar renders the bottom status bar.
 type StatusBar struct {
-	connectionStatus int    // 0=disconnected, 1=connecting, 2=connected
+	connectionStatus int // 0=disconnected, 1=connecting, 2=connected
 	clusterID        string
 	address          string
+	profile          ConnectionProfile // connection's saved profile, if any
 	message          string
 	messageLevel     int // 0=info, 1=success, 2=warning, 3=error
 	messageTime      time.Time
+	pendingWrites    int
+	task             string // background task progress, e.g. an export
+	hints            string // key hints on the right
 	width            int
 }
 
 // NewStatusBar creates a new status bar.
 func NewStatusBar() StatusBar {
-	return StatusBar{}
+	return StatusBar{hints: "? Help  q Quit"}
+}
+
+// SetHints sets the key hints shown on the right, such as "? Help".
+func (s *StatusBar) SetHints(text string) {
+	s.hints = text
 }
 
 // SetConnection updates the connection info displayed.
@@ -31,6 +42,12 @@ func (s *StatusBar) SetConnection(status int, clusterID, address string) {
 	s.address = address
 }
 
+// SetProfile sets the saved profile of the connection; a zero profile
+// clears it.
+func (s *StatusBar) SetProfile(p ConnectionProfile) {
+	s.profile = p
+}
+
 // SetMessage sets a temporary message.
 func (s *StatusBar) SetMessage(text string, level int) {
 	s.message = text
@@ -38,6 +55,16 @@ func (s *StatusBar) SetMessage(text string, level int) {
 	s.messageTime = time.Now()
 }
 
+// SetPendingWrites sets the number of journaled writes awaiting a reply.
+func (s *StatusBar) SetPendingWrites(n int) {
+	s.pendingWrites = n
+}
+
+// SetTask shows the progress of a background task; empty hides it.
+func (s *StatusBar) SetTask(text string) {
+	s.task = text
+}
+
 // SetWidth sets the available width.
 func (s *StatusBar) SetWidth(w int) {
 	s.width = w
@@ -46,37 +73,53 @@ func (s *StatusBar) SetWidth(w int) {
 // View renders the status bar.
 func (s *StatusBar) View() string {
 	barStyle := lipgloss.NewStyle().
-		Foreground(colorMuted).
+		Foreground(theme.Muted).
 		Width(s.width)
 
-	dimStyle := lipgloss.NewStyle().Foreground(colorDim)
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Dim)
 
 	var parts []string
 
 	// Connection status
 	switch s.connectionStatus {
 	case 2: // Connected
-		connStyle := lipgloss.NewStyle().Foreground(colorSuccess).Bold(true)
-		parts = append(parts, connStyle.Render(fmt.Sprintf("● Connected %s:%s", s.clusterID, s.address)))
+		connStyle := lipgloss.NewStyle().Foreground(theme.Success).Bold(true)
+		conn := connStyle.Render(fmt.Sprintf("● Connected %s:%s", s.clusterID, s.address))
+		if s.profile.Name != "" {
+			conn = ProfileBadge(s.profile) + " " + conn
+		}
+		parts = append(parts, conn)
 	case 1: // Connecting
-		connStyle := lipgloss.NewStyle().Foreground(colorWarning)
+		connStyle := lipgloss.NewStyle().Foreground(theme.Warning)
 		parts = append(parts, connStyle.Render("○ Connecting..."))
 	default: // Disconnected
 		parts = append(parts, dimStyle.Render("○ Disconnected"))
 	}
 
+	if s.pendingWrites > 0 {
+		label := "pending writes"
+		if s.pendingWrites == 1 {
+			label = "pending write"
+		}
+		parts = append(parts, lipgloss.NewStyle().Foreground(theme.Warning).Render(fmt.Sprintf("%d %s", s.pendingWrites, label)))
+	}
+
+	if s.task != "" {
+		parts = append(parts, lipgloss.NewStyle().Foreground(theme.Accent).Render(s.task))
+	}
+
 	// Status message (show for 10 seconds)
 	if s.message != "" && time.Since(s.messageTime) < 10*time.Second {
 		var style lipgloss.Style
 		switch s.messageLevel {
 		case 1:
-			style = lipgloss.NewStyle().Foreground(colorSuccess)
+			style = lipgloss.NewStyle().Foreground(theme.Success)
 		case 2:
-			style = lipgloss.NewStyle().Foreground(colorWarning)
+			style = lipgloss.NewStyle().Foreground(theme.Warning)
 		case 3:
-			style = lipgloss.NewStyle().Foreground(colorError)
+			style = lipgloss.NewStyle().Foreground(theme.Error)
 		default:
-			style = lipgloss.NewStyle().Foreground(colorMuted)
+			style = lipgloss.NewStyle().Foreground(theme.Muted)
 		}
 		parts = append(parts, style.Render(s.message))
 	}
@@ -84,7 +127,7 @@ func (s *StatusBar) View() string {
 	left := strings.Join(parts, "  │  ")
 
 	// Right side: version + help hint
-	right := dimStyle.Render("? Help  q Quit")
+	right := dimStyle.Render(s.hints)
 
 	// Calculate spacing
 	leftLen := lipgloss.Width(left)
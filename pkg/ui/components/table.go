@@ -0,0 +1,379 @@
+package components
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
+)
+
+// Column describes a single table column.
+type Column struct {
+	Key   string // stable name for layouts and sorting, e.g. "amount"
+	Title string
+	Width int
+	Right bool // right-align (numeric columns)
+
+	// Hidden columns are only shown once enabled in the column chooser.
+	Hidden bool
+	// Priority decides which columns drop first when the terminal is too
+	// narrow: lower priorities go first, then the rightmost.
+	Priority int
+
+	// Searchable marks columns whose text incremental search highlights.
+	Searchable bool
+
+	// Style optionally colors a cell by its value (e.g. status markers).
+	// It is not applied to the selected row.
+	Style func(value string) lipgloss.Style
+}
+
+// Table is a windowed table: it only renders the rows that fit on screen, so
+// it stays responsive with very large row counts.
+//
+// Rows hold one cell per column in definition order and keep their load
+// order; sorting only changes the order they are shown in. The cursor is a
+// position in that order and Row maps it back to a row index.
+type Table struct {
+	columns []Column
+	arrange []int  // every column index, in display order
+	hidden  []bool // per column
+	sortBy  []SortKey
+	rows    [][]string
+	order   []int // view position -> row index; nil while unsorted
+	pos     []int // row index -> view position; nil while unsorted
+	cursor  int
+	offset  int
+	width   int
+	height  int // visible data rows
+	search  Search
+	hitView []int // view positions of search hits, ascending; nil until needed
+}
+
+// NewTable creates a table with the given columns.
+func NewTable(columns []Column) Table {
+	t := Table{columns: columns, height: 10}
+	t.SetLayout(Layout{})
+	return t
+}
+
+// SetSize sets the available width and the number of visible data rows.
+func (t *Table) SetSize(w, h int) {
+	t.width = w
+	if h < 1 {
+		h = 1
+	}
+	t.height = h
+	t.clamp()
+}
+
+// SetRows replaces all rows and resets the cursor. Rows must be indexed
+// again with IndexRows to be searched, and sorted again with Sort.
+func (t *Table) SetRows(rows [][]string) {
+	t.rows = rows
+	t.order, t.pos = nil, nil
+	t.cursor = 0
+	t.offset = 0
+	t.search.reset()
+	t.hitView = nil
+}
+
+// SetCell replaces a single cell value of row.
+func (t *Table) SetCell(row, col int, value string) {
+	if row < 0 || row >= len(t.rows) || col < 0 || col >= len(t.rows[row]) {
+		return
+	}
+	t.rows[row][col] = value
+}
+
+// AppendRows adds rows at the end, keeping the cursor in place. A sorted
+// table must be sorted again with Sort.
+func (t *Table) AppendRows(rows [][]string) {
+	base := len(t.rows)
+	t.rows = append(t.rows, rows...)
+	if t.order != nil {
+		for i := base; i < len(t.rows); i++ {
+			t.order = append(t.order, i)
+			t.pos = append(t.pos, len(t.order)-1)
+		}
+	}
+	t.hitView = nil
+}
+
+// IndexRows adds the search text of the rows last added, in order. Tables
+// that never index rows are not searchable.
+func (t *Table) IndexRows(texts []string) {
+	t.search.add(texts)
+	t.hitView = nil
+}
+
+// hits returns the view positions of the search hits, ascending.
+func (t *Table) hits() []int {
+	if t.order == nil {
+		return t.search.hits
+	}
+	if t.hitView == nil {
+		t.hitView = make([]int, len(t.search.hits))
+		for i, row := range t.search.hits {
+			t.hitView[i] = t.pos[row]
+		}
+		slices.Sort(t.hitView)
+	}
+	return t.hitView
+}
+
+// Search highlights query and moves the cursor to the first match at or
+// after origin, or back to origin when nothing matches. It reports whether
+// any row matches.
+func (t *Table) Search(query string, origin int) bool {
+	t.search.setQuery(query)
+	t.hitView = nil
+	v, ok := nextHit(t.hits(), origin)
+	if !ok {
+		v = origin
+	}
+	t.SetCursor(v)
+	return ok
+}
+
+// ClearSearch drops the search and its highlight.
+func (t *Table) ClearSearch() {
+	t.search.setQuery("")
+	t.hitView = nil
+}
+
+// SearchMatches returns the number of rows matching the search.
+func (t *Table) SearchMatches() int {
+	return len(t.search.hits)
+}
+
+// SearchNext moves the cursor to the next match, or the previous one when
+// backward is set, wrapping around.
+func (t *Table) SearchNext(backward bool) bool {
+	v, ok := nextHit(t.hits(), t.cursor+1)
+	if backward {
+		v, ok = prevHit(t.hits(), t.cursor)
+	}
+	if ok {
+		t.SetCursor(v)
+	}
+	return ok
+}
+
+// SearchQuery returns the active search, lower-cased, or "".
+func (t *Table) SearchQuery() string {
+	return t.search.query
+}
+
+// SearchStatus describes the matches relative to the cursor, e.g.
+// "3 of 120 matches".
+func (t *Table) SearchStatus() string {
+	n := len(t.search.hits)
+	switch {
+	case t.search.query == "":
+		return ""
+	case n == 0:
+		return "no matches in loaded rows"
+	}
+	if pos := hitPosition(t.hits(), t.cursor); pos > 0 {
+		return fmt.Sprintf("%d of %d matches", pos, n)
+	}
+	return fmt.Sprintf("%d matches", n)
+}
+
+// Len returns the number of rows.
+func (t *Table) Len() int {
+	return len(t.rows)
+}
+
+// Cursor returns the selected view position.
+func (t *Table) Cursor() int {
+	return t.cursor
+}
+
+// Row returns the index of the selected row, or -1 when there are none.
+func (t *Table) Row() int {
+	return t.rowAt(t.cursor)
+}
+
+// Order returns the row indices in the order they are shown.
+func (t *Table) Order() []int {
+	order := make([]int, len(t.rows))
+	for v := range order {
+		order[v] = t.rowAt(v)
+	}
+	return order
+}
+
+// rowAt maps a view position to a row index.
+func (t *Table) rowAt(v int) int {
+	if v < 0 || v >= len(t.rows) {
+		return -1
+	}
+	if t.order == nil {
+		return v
+	}
+	return t.order[v]
+}
+
+// SetCursor selects view position i.
+func (t *Table) SetCursor(i int) {
+	t.cursor = i
+	t.clamp()
+}
+
+// MoveUp moves the cursor up n rows.
+func (t *Table) MoveUp(n int) {
+	t.cursor -= n
+	t.clamp()
+}
+
+// MoveDown moves the cursor down n rows.
+func (t *Table) MoveDown(n int) {
+	t.cursor += n
+	t.clamp()
+}
+
+func (t *Table) clamp() {
+	if t.cursor >= len(t.rows) {
+		t.cursor = len(t.rows) - 1
+	}
+	if t.cursor < 0 {
+		t.cursor = 0
+	}
+	if t.cursor < t.offset {
+		t.offset = t.cursor
+	}
+	if t.cursor >= t.offset+t.height {
+		t.offset = t.cursor - t.height + 1
+	}
+}
+
+// View renders the header and the visible window of rows.
+func (t *Table) View() string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Text)
+	ruleStyle := lipgloss.NewStyle().Foreground(theme.Border)
+	cellStyle := lipgloss.NewStyle().Foreground(theme.Text)
+	selectedStyle := theme.Highlight(theme.Accent).Bold(true)
+
+	cols := t.visibleColumns()
+	query := t.search.query
+
+	var sb strings.Builder
+	header := make([]string, len(cols))
+	for i, ci := range cols {
+		c := t.columns[ci]
+		header[i] = fitCell(t.headerCell(c), c.Width, c.Right)
+	}
+	headerLine := " " + strings.Join(header, "  ")
+	sb.WriteString(headerStyle.Render(headerLine))
+	sb.WriteString("\n")
+	sb.WriteString(ruleStyle.Render(strings.Repeat("─", lipgloss.Width(headerLine))))
+
+	end := t.offset + t.height
+	if end > len(t.rows) {
+		end = len(t.rows)
+	}
+	for v := t.offset; v < end; v++ {
+		i := t.rowAt(v)
+		row := t.rows[i]
+		selected := v == t.cursor
+		hit := query != "" && t.search.hit(i)
+		cells := make([]string, len(cols))
+		for j, ci := range cols {
+			c := t.columns[ci]
+			var val string
+			if ci < len(row) {
+				val = row[ci]
+			}
+			cells[j] = fitCell(val, c.Width, c.Right)
+			if !selected {
+				switch {
+				case c.Style != nil:
+					cells[j] = c.Style(val).Render(cells[j])
+				case hit && c.Searchable:
+					cells[j] = highlight(cells[j], query, cellStyle)
+				default:
+					cells[j] = cellStyle.Render(cells[j])
+				}
+			}
+		}
+		sb.WriteString("\n")
+		if selected {
+			sb.WriteString(selectedStyle.Render(" " + strings.Join(cells, "  ")))
+		} else {
+			sb.WriteString(" " + strings.Join(cells, "  "))
+		}
+	}
+
+	return sb.String()
+}
+
+// visibleColumns returns the shown columns that fit in the table width, in
+// display order. The highest priorities are kept first, leftmost first on
+// ties, so the least important columns drop on narrow terminals; at least
+// one column always stays.
+func (t *Table) visibleColumns() []int {
+	shown := t.shown()
+	if t.width <= 0 || columnsWidth(t.columns, shown) <= t.width {
+		return shown
+	}
+	byPriority := slices.Clone(shown)
+	slices.SortStableFunc(byPriority, func(a, b int) int {
+		return t.columns[b].Priority - t.columns[a].Priority
+	})
+	keep := make([]bool, len(t.columns))
+	var kept []int
+	for _, ci := range byPriority {
+		if len(kept) == 0 || columnsWidth(t.columns, append(kept, ci)) <= t.width {
+			kept = append(kept, ci)
+			keep[ci] = true
+		}
+	}
+	return slices.DeleteFunc(shown, func(ci int) bool { return !keep[ci] })
+}
+
+// headerCell returns a column title with its sort marker, shortening the
+// title rather than the marker.
+func (t *Table) headerCell(c Column) string {
+	marker := t.sortMarker(c.Key)
+	if marker == "" {
+		return c.Title
+	}
+	room := c.Width - len([]rune(marker))
+	if len([]rune(c.Title)) > room {
+		return fitCell(c.Title, max(room, 0), false) + marker
+	}
+	return c.Title + marker
+}
+
+// columnsWidth returns the rendered width of the given columns.
+func columnsWidth(columns []Column, cols []int) int {
+	w := 1
+	for i, ci := range cols {
+		w += columns[ci].Width
+		if i > 0 {
+			w += 2
+		}
+	}
+	return w
+}
+
+// fitCell truncates s with an ellipsis or pads it to exactly width runes.
+func fitCell(s string, width int, right bool) string {
+	r := []rune(s)
+	if len(r) > width {
+		if width <= 1 {
+			return string(r[:width])
+		}
+		return string(r[:width-1]) + "…"
+	}
+	pad := strings.Repeat(" ", width-len(r))
+	if right {
+		return pad + s
+	}
+	return s + pad
+}
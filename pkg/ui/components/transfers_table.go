@@ -0,0 +1,317 @@
+package components
+
+import (
+	"cmp"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	"github.com/fd1az/tiger-tui/business/accounts/domain"
+	transfersdomain "github.com/fd1az/tiger-tui/business/transfers/domain"
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
+)
+
+// transferStatusCol is the index of the status marker column.
+const transferStatusCol = 0
+
+// TransfersTable shows loaded transfers, either cluster-wide or scoped to a
+// single account, and tracks the pagination cursor.
+type TransfersTable struct {
+	table       Table
+	transfers   []types.Transfer
+	resolutions transfersdomain.Resolutions
+	pendingRows map[types.Uint128]int // pending transfer ID -> row index
+	scope       types.Uint128
+	filters     []FilterChip
+	scanned     int // transfers fetched, before client-side filters
+	next        transfersdomain.TransferQuery
+	hasMore     bool
+	loading     bool
+	loaded      bool
+	width       int
+	height      int
+}
+
+// NewTransfersTable creates an empty transfers table.
+func NewTransfersTable() TransfersTable {
+	return TransfersTable{
+		table: NewTable([]Column{
+			{Key: "status", Title: "St", Width: 3, Priority: 7, Style: statusMarkerStyle},
+			{Key: "id", Title: "ID", Width: 24, Priority: 9, Searchable: true},
+			{Key: "debit_account", Title: "Debit Account", Width: 24, Priority: 8, Searchable: true},
+			{Key: "credit_account", Title: "Credit Account", Width: 24, Priority: 8, Searchable: true},
+			{Key: "amount", Title: "Amount", Width: 20, Right: true, Priority: 9},
+			{Key: "ledger", Title: "Ledger", Width: 6, Priority: 6, Searchable: true},
+			{Key: "code", Title: "Type", Width: 14, Priority: 5, Searchable: true},
+			{Key: "venue", Title: "Venue", Width: 10, Priority: 3, Searchable: true},
+			{Key: "pending_id", Title: "Pending ID", Width: 24, Hidden: true, Priority: 1},
+			{Key: "flags", Title: "Flags", Width: 24, Hidden: true, Priority: 1},
+			{Key: "timeout", Title: "Timeout", Width: 8, Right: true, Hidden: true, Priority: 1},
+			{Key: "user_data_128", Title: "User Data 128", Width: 24, Hidden: true, Priority: 1, Searchable: true},
+			{Key: "user_data_64", Title: "User Data 64", Width: 20, Right: true, Hidden: true, Priority: 1, Searchable: true},
+			{Key: "timestamp", Title: "Timestamp", Width: 23, Hidden: true, Priority: 2},
+		}),
+		resolutions: transfersdomain.Resolutions{},
+		pendingRows: map[types.Uint128]int{},
+	}
+}
+
+// SetSize sets the available width and height (including header, footer
+// and the filter line).
+func (t *TransfersTable) SetSize(w, h int) {
+	t.width, t.height = w, h
+	if len(t.filters) > 0 {
+		h--
+	}
+	t.table.SetSize(w, h-3)
+}
+
+// SetFilters shows the chips the rows are filtered by.
+func (t *TransfersTable) SetFilters(chips []FilterChip) {
+	t.filters = chips
+	t.SetSize(t.width, t.height)
+}
+
+// SetLoading marks a page request as in flight.
+func (t *TransfersTable) SetLoading(loading bool) {
+	t.loading = loading
+}
+
+// SetScope limits the table to transfers of one account. A zero ID shows the
+// whole cluster. Changing scope clears the loaded rows.
+func (t *TransfersTable) SetScope(accountID types.Uint128) {
+	t.scope = accountID
+	t.transfers = nil
+	t.resolutions = transfersdomain.Resolutions{}
+	t.pendingRows = map[types.Uint128]int{}
+	t.table.SetRows(nil)
+	t.table.ClearSearch()
+	t.hasMore = false
+	t.loaded = false
+}
+
+// Scope returns the account the table is scoped to, or zero.
+func (t *TransfersTable) Scope() types.Uint128 {
+	return t.scope
+}
+
+// Scoped reports whether the table is scoped to a single account.
+func (t *TransfersTable) Scoped() bool {
+	return t.scope != types.Uint128{}
+}
+
+// SetPage stores a loaded page, replacing or appending to the current rows.
+func (t *TransfersTable) SetPage(page transfersdomain.TransferPage, appendRows bool) {
+	if !appendRows {
+		t.transfers = nil
+		t.resolutions = transfersdomain.Resolutions{}
+		t.pendingRows = map[types.Uint128]int{}
+		t.table.SetRows(nil)
+		t.scanned = 0
+	}
+	t.scanned += page.Scanned
+
+	// Record resolutions first so pending rows in this page render settled.
+	var resolved []types.Uint128
+	for _, tr := range page.Transfers {
+		if id, ok := t.resolutions.Observe(tr); ok {
+			resolved = append(resolved, id)
+		}
+	}
+
+	base := len(t.transfers)
+	rows := make([][]string, len(page.Transfers))
+	texts := make([]string, len(page.Transfers))
+	for i, tr := range page.Transfers {
+		rows[i] = t.transferRow(tr)
+		texts[i] = searchText([]types.Uint128{tr.ID, tr.DebitAccountID, tr.CreditAccountID},
+			domain.TransferTypeName(tr.Code), tr.Ledger, tr.UserData32, tr.UserData128, tr.UserData64)
+		if tr.TransferFlags().Pending {
+			t.pendingRows[tr.ID] = base + i
+		}
+	}
+	t.transfers = append(t.transfers, page.Transfers...)
+	t.table.AppendRows(rows)
+	t.table.IndexRows(texts)
+
+	// Pending rows from earlier pages may have been resolved by this one.
+	for _, id := range resolved {
+		if idx, ok := t.pendingRows[id]; ok && idx < base {
+			t.table.SetCell(idx, transferStatusCol, t.resolutions.StatusOf(t.transfers[idx]).Marker())
+		}
+	}
+	t.Resort()
+
+	t.next = page.Next
+	t.hasMore = page.HasMore
+	t.loading = false
+	t.loaded = true
+}
+
+// NeedsMore reports whether the cursor is near the end of the loaded rows and
+// another page is available.
+func (t *TransfersTable) NeedsMore() bool {
+	return t.hasMore && !t.loading && t.table.Len()-t.table.Cursor() <= loadMoreThreshold
+}
+
+// Next returns the query for the next page.
+func (t *TransfersTable) Next() transfersdomain.TransferQuery {
+	return t.next
+}
+
+// Selected returns the transfer under the cursor.
+func (t *TransfersTable) Selected() (types.Transfer, bool) {
+	i := t.table.Row()
+	if i < 0 || i >= len(t.transfers) {
+		return types.Transfer{}, false
+	}
+	return t.transfers[i], true
+}
+
+// Details renders every field of the selected transfer and its status as
+// far as the loaded rows tell, or "" when there is none.
+func (t *TransfersTable) Details() string {
+	tr, ok := t.Selected()
+	if !ok {
+		return ""
+	}
+	fields := append([][2]string{{"Status", t.resolutions.StatusOf(tr).String()}}, transferFields(tr)...)
+	return detailFields("Transfer", fields)
+}
+
+// Table returns the underlying table, for search and layout changes.
+func (t *TransfersTable) Table() *Table {
+	return &t.table
+}
+
+// Resort orders the rows after the table's sort changed.
+func (t *TransfersTable) Resort() {
+	t.table.Sort(t.compare)
+}
+
+// compare orders two transfers by column key: numbers and IDs by value,
+// types, ledgers and venues in chart order.
+func (t *TransfersTable) compare(key string, i, j int) int {
+	x, y := &t.transfers[i], &t.transfers[j]
+	switch key {
+	case "id":
+		return compareUint128(x.ID, y.ID)
+	case "debit_account":
+		return compareUint128(x.DebitAccountID, y.DebitAccountID)
+	case "credit_account":
+		return compareUint128(x.CreditAccountID, y.CreditAccountID)
+	case "amount":
+		return compareUint128(x.Amount, y.Amount)
+	case "ledger":
+		return cmp.Compare(x.Ledger, y.Ledger)
+	case "code":
+		return cmp.Compare(x.Code, y.Code)
+	case "venue":
+		return cmp.Compare(x.UserData32, y.UserData32)
+	case "pending_id":
+		return compareUint128(x.PendingID, y.PendingID)
+	case "flags":
+		return cmp.Compare(x.Flags, y.Flags)
+	case "timeout":
+		return cmp.Compare(x.Timeout, y.Timeout)
+	case "user_data_128":
+		return compareUint128(x.UserData128, y.UserData128)
+	case "user_data_64":
+		return cmp.Compare(x.UserData64, y.UserData64)
+	case "timestamp":
+		return cmp.Compare(x.Timestamp, y.Timestamp)
+	}
+	return t.table.compareText(key, i, j)
+}
+
+// MoveUp moves the selection up n rows.
+func (t *TransfersTable) MoveUp(n int) {
+	t.table.MoveUp(n)
+}
+
+// MoveDown moves the selection down n rows.
+func (t *TransfersTable) MoveDown(n int) {
+	t.table.MoveDown(n)
+}
+
+// View renders the transfers table.
+func (t *TransfersTable) View() string {
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+
+	filters := filterLine(t.filters, t.width)
+	if filters != "" {
+		filters += "\n"
+	}
+	if !t.loaded {
+		return filters + dimStyle.Render("  Loading transfers...")
+	}
+	if len(t.transfers) == 0 && !t.hasMore {
+		if t.Scoped() {
+			return filters + dimStyle.Render(fmt.Sprintf("  No transfers for account %s.  (esc to show all)", domain.FormatID(t.scope)))
+		}
+		return filters + dimStyle.Render("  No transfers found.")
+	}
+
+	footer := fmt.Sprintf("  %d transfers loaded", len(t.transfers))
+	if t.scanned != len(t.transfers) {
+		footer = fmt.Sprintf("  %d of %d scanned transfers match", len(t.transfers), t.scanned)
+	}
+	if t.Scoped() {
+		footer += fmt.Sprintf(" · account %s (esc to show all)", domain.FormatID(t.scope))
+	}
+	switch {
+	case t.loading:
+		footer += " · loading more..."
+	case t.hasMore:
+		footer += " · more available"
+	}
+	if status := t.table.SearchStatus(); status != "" {
+		footer += fmt.Sprintf(" · /%s: %s", t.table.SearchQuery(), status)
+	}
+
+	return filters + t.table.View() + "\n" + dimStyle.Render(footer)
+}
+
+// transferRow renders a transfer's cells in column definition order.
+func (t *TransfersTable) transferRow(tr types.Transfer) []string {
+	return []string{
+		t.resolutions.StatusOf(tr).Marker(),
+		domain.FormatID(tr.ID),
+		domain.FormatID(tr.DebitAccountID),
+		domain.FormatID(tr.CreditAccountID),
+		domain.FormatAmount(tr.Amount, tr.Ledger),
+		domain.LedgerLabel(tr.Ledger),
+		domain.TransferTypeName(tr.Code),
+		domain.VenueName(tr.UserData32),
+		optionalID(tr.PendingID),
+		flagList(transfersdomain.TransferFlagNames(tr.Flags)),
+		formatTimeout(tr.Timeout),
+		optionalID(tr.UserData128),
+		optionalUint(tr.UserData64),
+		domain.FormatTimestamp(tr.Timestamp),
+	}
+}
+
+// formatTimeout renders a pending transfer's timeout, leaving none blank.
+func formatTimeout(seconds uint32) string {
+	if seconds == 0 {
+		return ""
+	}
+	return formatCountdown(time.Duration(seconds) * time.Second)
+}
+
+// statusMarkerStyle colors a design.md status marker by its meaning.
+func statusMarkerStyle(marker string) lipgloss.Style {
+	switch marker {
+	case "OK":
+		return lipgloss.NewStyle().Foreground(theme.Success)
+	case "~":
+		return lipgloss.NewStyle().Foreground(theme.Warning)
+	case "ERR":
+		return lipgloss.NewStyle().Foreground(theme.Error)
+	default:
+		return lipgloss.NewStyle().Foreground(theme.Text)
+	}
+}
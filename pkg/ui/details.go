@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"errors"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func init() {
+	registerCommand(paletteCommand{
+		name:    "details",
+		summary: "show or hide the details panel of the selected row",
+		run: func(m *Model, _ []string) (tea.Cmd, error) {
+			if !m.dashboard.ToggleDetails() {
+				return nil, errNoDetails
+			}
+			return nil, nil
+		},
+	})
+}
+
+// errNoDetails is returned when the active tab has no rows to detail.
+var errNoDetails = errors.New("details are shown for Accounts, Transfers and Pending rows")
+
+// toggleDetails opens or closes the details panel, leaving the table
+// focused.
+func (m *Model) toggleDetails() {
+	if !m.dashboard.ToggleDetails() {
+		m.statusBar.SetMessage(errNoDetails.Error(), 2)
+	}
+}
+
+// updateDetails handles keys while the details panel has the focus: it
+// scrolls, count lines at a time, and only the keys that leave it or the
+// dashboard act; the table's keys wait until it has the focus again.
+func (m Model) updateDetails(msg tea.KeyMsg, count int) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		m.dashboard.ScrollDetails(-max(count, 1))
+	case key.Matches(msg, m.keys.Down):
+		m.dashboard.ScrollDetails(max(count, 1))
+	case key.Matches(msg, m.keys.Left, m.keys.Escape):
+		m.dashboard.FocusDetails(false)
+	case key.Matches(msg, m.keys.Details):
+		m.dashboard.ToggleDetails()
+	case key.Matches(msg, m.keys.Tab):
+		m.dashboard.NextTab()
+	case key.Matches(msg, m.keys.ShiftTab):
+		m.dashboard.PrevTab()
+	case key.Matches(msg, m.keys.Command):
+		m.openCommandBar()
+	case key.Matches(msg, m.keys.Help):
+		m.openHelp("")
+	case key.Matches(msg, m.keys.Quit):
+		m.quitting = true
+		return m, tea.Quit
+	}
+	return m, nil
+}
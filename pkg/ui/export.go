@@ -0,0 +1,188 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	"github.com/fd1az/tiger-tui/business/accounts/domain"
+	exportapp "github.com/fd1az/tiger-tui/business/export/app"
+	exportdomain "github.com/fd1az/tiger-tui/business/export/domain"
+	"github.com/fd1az/tiger-tui/pkg/ui/components"
+)
+
+func init() {
+	registerCommand(paletteCommand{
+		name:    "export",
+		args:    "[csv|json|ndjson|file]",
+		summary: "export the active tab",
+		complete: func(_ *Model, n int, _ []string) []string {
+			if n > 0 {
+				return nil
+			}
+			return formatNames()
+		},
+		run: func(m *Model, args []string) (tea.Cmd, error) {
+			if len(args) == 0 {
+				return nil, m.openExport()
+			}
+			view, _ := m.exportView()
+			if format, ok := exportdomain.FormatFromPath(args[0]); ok {
+				return m.startExport(view, args[0], format)
+			}
+			name, err := matchArg("format", args[0], formatNames())
+			if err != nil {
+				return nil, err
+			}
+			format := exportdomain.Format(name)
+			return m.startExport(view, exportdomain.FileName(view, format, time.Now()), format)
+		},
+	})
+}
+
+// formatNames lists the export formats for completion.
+func formatNames() []string {
+	names := make([]string, len(exportdomain.Formats))
+	for i, f := range exportdomain.Formats {
+		names[i] = string(f)
+	}
+	return names
+}
+
+// openExport opens the Export modal for the active tab, unless an export is
+// already running.
+func (m *Model) openExport() error {
+	if m.export != nil {
+		return fmt.Errorf("an export of %s is already running", m.export.View)
+	}
+	view, title := m.exportView()
+	m.exportForm = components.NewExportForm(view, title, m.keys.modalKeys())
+	m.overlay = OverlayExport
+	return nil
+}
+
+// startExport starts exporting the active tab to path in the background.
+func (m *Model) startExport(view, path string, format exportdomain.Format) (tea.Cmd, error) {
+	if m.export != nil {
+		return nil, fmt.Errorf("an export of %s is already running", m.export.View)
+	}
+	columns, src, err := m.exportSource()
+	if err != nil {
+		return nil, err
+	}
+	m.export = NewExportJob(view, path, format, columns, src)
+	m.statusBar.SetTask(fmt.Sprintf("Exporting %s...", view))
+	return ExportStepCmd(m.export), nil
+}
+
+// updateExport handles keys in the Export modal. The export itself runs in
+// the background once started.
+func (m Model) updateExport(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	f := &m.exportForm
+	switch {
+	case key.Matches(msg, m.keys.Tab):
+		f.FocusNext()
+		return m, nil
+
+	case key.Matches(msg, m.keys.ShiftTab):
+		f.FocusPrev()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Enter):
+		if !f.IsSubmitFocused() {
+			f.FocusNext()
+			return m, nil
+		}
+		if f.Path() == "" {
+			f.SetError("file is required")
+			return m, nil
+		}
+		// A recognized extension wins over the picker.
+		format := f.Format()
+		if ext, ok := exportdomain.FormatFromPath(f.Path()); ok {
+			format = ext
+		}
+		cmd, err := m.startExport(f.ViewName(), f.Path(), format)
+		if err != nil {
+			f.SetError(err.Error())
+			return m, nil
+		}
+		m.overlay = OverlayNone
+		return m, cmd
+	}
+
+	return m, f.Update(msg)
+}
+
+// exportView names the active view and describes what it shows.
+func (m *Model) exportView() (view, title string) {
+	switch m.dashboard.ActiveTab() {
+	case 1:
+		if scope := m.dashboard.Transfers().Scope(); scope != (types.Uint128{}) {
+			return "transfers", "Transfers of account " + domain.FormatID(scope)
+		}
+		return "transfers", "All transfers"
+	case 2:
+		return "balance-sheet", "Balance sheet"
+	case 3:
+		return "pending", "Open pending transfers"
+	}
+	return "accounts", "All accounts"
+}
+
+// exportSource returns the columns and records of the active view. Accounts
+// and transfers are streamed from the cluster with the tab's query, so the
+// export is not limited to the pages loaded on screen; the balance sheet and
+// pending holds are exported as shown.
+//
+// The Accounts, Transfers and Pending exports follow the table's layout: the
+// fields of its shown columns, in its column order. Pending holds keep the
+// table's sort, but streamed accounts and transfers come in load order
+// (accounts oldest first, transfers newest first), since sorting them would
+// mean holding the whole export in memory.
+func (m *Model) exportSource() ([]string, exportapp.Source, error) {
+	var columns []string
+	var src exportapp.Source
+	switch m.dashboard.ActiveTab() {
+	case 0:
+		if m.accounts == nil {
+			return nil, nil, fmt.Errorf("not connected")
+		}
+		columns, src = exportdomain.AccountColumns, exportapp.Accounts(m.accounts, m.accountsQuery())
+	case 1:
+		if m.transfers == nil {
+			return nil, nil, fmt.Errorf("not connected")
+		}
+		columns, src = exportdomain.TransferColumns, exportapp.Transfers(m.transfers, m.transfersQuery())
+	case 2:
+		summary, ok := m.dashboard.BalanceSheet().Summary()
+		if !ok {
+			return nil, nil, fmt.Errorf("the balance sheet has not loaded yet")
+		}
+		return exportdomain.BalanceSheetColumns, exportapp.Records(exportdomain.BalanceSheetRecords(summary)), nil
+	case 3:
+		now := time.Now()
+		holds := m.dashboard.Pending().SortedHolds()
+		records := make([][]string, len(holds))
+		for i, h := range holds {
+			records[i] = exportdomain.HoldRecord(h, now)
+		}
+		columns, src = exportdomain.HoldColumns, exportapp.Records(records)
+	default:
+		return nil, nil, fmt.Errorf("nothing to export")
+	}
+
+	t, _ := m.dashboard.LayoutTable(m.dashboard.ActiveTab())
+	cols, shown := t.Table().Columns()
+	var keys []string
+	for i, c := range cols {
+		if shown[i] {
+			keys = append(keys, c.Key)
+		}
+	}
+	columns, idx := exportdomain.Layout(columns, keys)
+	return columns, exportapp.Select(src, idx), nil
+}
@@ -0,0 +1,220 @@
+package ui
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fd1az/tiger-tui/business/accounts/domain"
+	filterdomain "github.com/fd1az/tiger-tui/business/filter/domain"
+	transfersdomain "github.com/fd1az/tiger-tui/business/transfers/domain"
+	"github.com/fd1az/tiger-tui/pkg/ui/components"
+)
+
+func init() {
+	registerCommand(paletteCommand{
+		name:    "filter",
+		args:    "[chip...|clear]",
+		summary: "filter the active tab, e.g. ledger=USD amount>=100",
+		complete: func(m *Model, _ int, _ []string) []string {
+			tab, ok := m.filterTab()
+			if !ok {
+				return nil
+			}
+			return append([]string{"clear"}, filterCandidates(m.filterTarget(tab))...)
+		},
+		run: func(m *Model, args []string) (tea.Cmd, error) {
+			tab, ok := m.filterTab()
+			switch {
+			case !ok:
+				return nil, errNoFilter
+			case len(args) == 0:
+				m.openFilter()
+				return nil, nil
+			case len(args) == 1 && args[0] == "clear":
+				return m.applyFilters(tab, nil), nil
+			}
+			chips := slices.Clone(m.filters[tab])
+			for _, arg := range args {
+				c, err := filterdomain.ParseChip(arg)
+				if err != nil {
+					return nil, err
+				}
+				chips = append(chips, c)
+			}
+			if _, err := filterdomain.Compile(chips, m.filterTarget(tab), time.Now()); err != nil {
+				return nil, err
+			}
+			return m.applyFilters(tab, chips), nil
+		},
+	})
+}
+
+// errNoFilter is returned when the active tab cannot be filtered.
+var errNoFilter = errors.New("filters apply to the Accounts and Transfers tabs")
+
+// filterTab returns the active tab if it can be filtered.
+func (m *Model) filterTab() (int, bool) {
+	switch tab := m.dashboard.ActiveTab(); {
+	case tab == 0 && !m.dashboard.HistoryOpen(), tab == 1:
+		return tab, true
+	}
+	return 0, false
+}
+
+// filterTarget returns the query a tab's chips compile for.
+func (m *Model) filterTarget(tab int) filterdomain.Target {
+	switch {
+	case tab == 0:
+		return filterdomain.Accounts
+	case m.dashboard.Transfers().Scoped():
+		return filterdomain.AccountTransfers
+	}
+	return filterdomain.Transfers
+}
+
+// filterPlan compiles a tab's chips. They were validated when added, so an
+// error (a relative time overtaking until, say) falls back to no filter.
+func (m *Model) filterPlan(tab int) filterdomain.Plan {
+	plan, err := filterdomain.Compile(m.filters[tab], m.filterTarget(tab), time.Now())
+	if err != nil {
+		return filterdomain.Plan{}
+	}
+	return plan
+}
+
+// chipViews pairs chips with where their plan evaluates them.
+func chipViews(chips []filterdomain.Chip, plan filterdomain.Plan) []components.FilterChip {
+	views := make([]components.FilterChip, len(chips))
+	for i, c := range chips {
+		views[i] = components.FilterChip{Text: c.String(), Server: i < len(plan.Server) && plan.Server[i]}
+	}
+	return views
+}
+
+// openFilter opens the filter builder on the active tab's chips.
+func (m *Model) openFilter() {
+	tab, ok := m.filterTab()
+	if !ok {
+		m.statusBar.SetMessage(errNoFilter.Error(), 2)
+		return
+	}
+	m.filterDraft = slices.Clone(m.filters[tab])
+	m.filterForm = components.NewFilterForm(tabViews[tab], chipViews(m.filterDraft, m.filterPlan(tab)))
+	m.filterForm.SetSuggestions(filterCandidates(m.filterTarget(tab)))
+	m.overlay = OverlayFilter
+}
+
+// applyFilters sets a tab's chips and reloads it.
+func (m *Model) applyFilters(tab int, chips []filterdomain.Chip) tea.Cmd {
+	m.filters[tab] = chips
+	if tab == 0 {
+		return m.reloadAccounts()
+	}
+	return m.reloadTransfers()
+}
+
+// updateFilter handles keys in the filter builder: Enter adds the typed chip
+// or, with nothing typed, applies the chips; Tab completes; Backspace on an
+// empty line removes the last chip.
+func (m Model) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	tab, ok := m.filterTab()
+	if !ok {
+		m.overlay = OverlayNone
+		return m, nil
+	}
+	f := &m.filterForm
+	target := m.filterTarget(tab)
+
+	switch {
+	case key.Matches(msg, m.keys.Enter) && f.Value() == "":
+		m.overlay = OverlayNone
+		return m, m.applyFilters(tab, m.filterDraft)
+
+	case key.Matches(msg, m.keys.Enter):
+		c, err := filterdomain.ParseChip(f.Value())
+		if err != nil {
+			f.SetError(err.Error())
+			return m, nil
+		}
+		chips := append(slices.Clone(m.filterDraft), c)
+		plan, err := filterdomain.Compile(chips, target, time.Now())
+		if err != nil {
+			f.SetError(err.Error())
+			return m, nil
+		}
+		m.filterDraft = chips
+		f.SetChips(chipViews(chips, plan))
+		return m, nil
+
+	case msg.String() == "tab":
+		if s, ok := f.Suggestion(); ok {
+			f.SetValue(s)
+		}
+		return m, nil
+
+	case msg.String() == "backspace" && f.Value() == "" && len(m.filterDraft) > 0:
+		m.filterDraft = m.filterDraft[:len(m.filterDraft)-1]
+		plan, _ := filterdomain.Compile(m.filterDraft, target, time.Now())
+		f.SetChips(chipViews(m.filterDraft, plan))
+		return m, nil
+	}
+
+	cmd := f.Update(msg)
+	f.SetSuggestions(components.FuzzyFilter(f.Value(), filterCandidates(target)))
+	return m, cmd
+}
+
+// filterCandidates lists chips to complete: every chart value of ledger,
+// code, venue and flags, and the other keys with sample values or a bare
+// "key=".
+func filterCandidates(target filterdomain.Target) []string {
+	var out []string
+	for _, k := range filterdomain.Keys {
+		var values []string
+		switch k {
+		case "ledger":
+			for _, id := range domain.LedgerIDs() {
+				values = append(values, domain.LedgerLabel(id))
+			}
+		case "code":
+			if target == filterdomain.Accounts {
+				for _, c := range domain.AccountCodes() {
+					values = append(values, domain.AccountTypeName(c))
+				}
+			} else {
+				for _, c := range domain.TransferCodes() {
+					values = append(values, domain.TransferTypeName(c))
+				}
+			}
+		case "venue":
+			for _, id := range domain.VenueIDs() {
+				values = append(values, domain.VenueName(id))
+			}
+		case "since", "until":
+			values = []string{"15m", "1h", "24h", "7d"}
+		case "amount":
+			if target == filterdomain.Accounts {
+				continue
+			}
+			out = append(out, "amount>=", "amount<=")
+			continue
+		case "flags":
+			if target == filterdomain.Accounts {
+				values = domain.AccountFlagNames(^uint16(0))
+			} else {
+				values = transfersdomain.TransferFlagNames(^uint16(0))
+			}
+		}
+		if len(values) == 0 {
+			out = append(out, k+"=")
+		}
+		for _, v := range values {
+			out = append(out, k+"="+v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fd1az/tiger-tui/pkg/ui/components"
+)
+
+func init() {
+	registerCommand(paletteCommand{
+		name:    "help",
+		args:    "[text]",
+		summary: "list the key bindings, optionally searching for text",
+		run: func(m *Model, args []string) (tea.Cmd, error) {
+			m.openHelp(strings.Join(args, " "))
+			return nil, nil
+		},
+	})
+}
+
+// openHelp opens the help overlay on the active screen's bindings, with
+// the search filled in.
+func (m *Model) openHelp(query string) {
+	title := "Help: Dashboard"
+	if m.screen == ScreenConnection {
+		title = "Help: Connection"
+	}
+	m.help = components.NewHelpView(title, m.keys.ContextHelp(m.screen), m.height-4, m.keys.modalKeys())
+	m.help.SetQuery(query)
+	m.overlay = OverlayHelp
+}
+
+// updateHelp handles keys in the help overlay: they scroll the list or
+// edit its search.
+func (m Model) updateHelp(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	cmd := m.help.Update(msg)
+	return m, cmd
+}
@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fd1az/tiger-tui/pkg/ui/components"
+)
+
+func init() {
+	registerCommand(paletteCommand{
+		name:    "import",
+		args:    "[file]",
+		summary: "import transfers from a CSV or NDJSON file",
+		run: func(m *Model, args []string) (tea.Cmd, error) {
+			if m.transfers == nil {
+				return nil, errNotConnected
+			}
+			if err := m.writable(); err != nil {
+				return nil, err
+			}
+			m.openImport()
+			if len(args) == 0 {
+				return nil, nil
+			}
+			m.importForm.SetPath(args[0])
+			return m.startImport(), nil
+		},
+	})
+}
+
+// openImport opens an empty Import Transfers modal.
+func (m *Model) openImport() {
+	m.importForm = components.NewImportForm(m.keys.modalKeys())
+	m.overlay = OverlayImport
+}
+
+// startImport submits the file in the import form.
+func (m *Model) startImport() tea.Cmd {
+	f := &m.importForm
+	f.SetSubmitting(true)
+	m.statusBar.SetMessage("Importing "+f.Path()+"...", 0)
+	return ImportTransfersCmd(m.transfers, f.Path(), f.DryRun())
+}
+
+// updateImport handles keys in the Import Transfers modal.
+func (m Model) updateImport(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	f := &m.importForm
+	switch {
+	case key.Matches(msg, m.keys.Tab):
+		f.FocusNext()
+		return m, nil
+
+	case key.Matches(msg, m.keys.ShiftTab):
+		f.FocusPrev()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Enter):
+		if !f.IsSubmitFocused() {
+			f.FocusNext()
+			return m, nil
+		}
+		if f.Submitting() || m.transfers == nil {
+			return m, nil
+		}
+		if f.Path() == "" {
+			f.SetError("file is required")
+			return m, nil
+		}
+		return m, m.startImport()
+	}
+
+	return m, f.Update(msg)
+}
@@ -1,26 +1,73 @@
 package ui
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fd1az/tiger-tui/internal/apperror"
+	"github.com/fd1az/tiger-tui/internal/config"
+	"github.com/fd1az/tiger-tui/pkg/ui/components"
+)
 
 // KeyMap defines all keybindings for the TUI.
 type KeyMap struct {
-	Quit    key.Binding
-	Tab     key.Binding
-	ShiftTab key.Binding
-	Enter   key.Binding
-	Escape  key.Binding
-	Up      key.Binding
-	Down    key.Binding
-	Refresh key.Binding
-	Help    key.Binding
+	ForceQuit    key.Binding
+	Quit         key.Binding
+	Tab          key.Binding
+	ShiftTab     key.Binding
+	Enter        key.Binding
+	Escape       key.Binding
+	Up           key.Binding
+	Down         key.Binding
+	Top          key.Binding
+	Bottom       key.Binding
+	HalfPageUp   key.Binding
+	HalfPageDown key.Binding
+	Left         key.Binding
+	Right        key.Binding
+	Details      key.Binding
+	Refresh      key.Binding
+	History      key.Binding
+	Create       key.Binding
+	Import       key.Binding
+	Export       key.Binding
+	Search       key.Binding
+	Lookup       key.Binding
+	Filter       key.Binding
+	NextMatch    key.Binding
+	PrevMatch    key.Binding
+	Command      key.Binding
+	Sort         key.Binding
+	ReverseSort  key.Binding
+	Columns      key.Binding
+	Post         key.Binding
+	PostPartial  key.Binding
+	Void         key.Binding
+	Help         key.Binding
+	MoveUp       key.Binding
+	MoveDown     key.Binding
+	ResetColumns key.Binding
+
+	// Counts makes digits typed before a motion repeat it, as in 5j.
+	Counts bool
 }
 
 // DefaultKeyMap returns the default keybindings.
 func DefaultKeyMap() KeyMap {
 	return KeyMap{
-		Quit: key.NewBinding(
+		ForceQuit: key.NewBinding(
 			key.WithKeys("ctrl+c"),
-			key.WithHelp("ctrl+c", "quit"),
+			key.WithHelp("ctrl+c", "force quit"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("q"),
+			key.WithHelp("q", "quit"),
 		),
 		Tab: key.NewBinding(
 			key.WithKeys("tab"),
@@ -46,15 +93,150 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("down", "j"),
 			key.WithHelp("↓/j", "down"),
 		),
+		Top: key.NewBinding(
+			key.WithKeys("home"),
+			key.WithHelp("home", "first row"),
+		),
+		Bottom: key.NewBinding(
+			key.WithKeys("end"),
+			key.WithHelp("end", "last row"),
+		),
+		HalfPageUp: key.NewBinding(
+			key.WithKeys("pgup"),
+			key.WithHelp("pgup", "half page up"),
+		),
+		HalfPageDown: key.NewBinding(
+			key.WithKeys("pgdown"),
+			key.WithHelp("pgdown", "half page down"),
+		),
+		Left: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/h", "focus table"),
+		),
+		Right: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→/l", "focus details"),
+		),
+		Details: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "details"),
+		),
 		Refresh: key.NewBinding(
 			key.WithKeys("r"),
 			key.WithHelp("r", "refresh"),
 		),
+		History: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "balance history"),
+		),
+		Create: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "create"),
+		),
+		Import: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "import transfers"),
+		),
+		Export: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "export view"),
+		),
+		Search: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "search (lookup id elsewhere)"),
+		),
+		Lookup: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "look up id"),
+		),
+		Filter: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "filter"),
+		),
+		NextMatch: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "next match"),
+		),
+		PrevMatch: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "prev match"),
+		),
+		Command: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", "command"),
+		),
+		Sort: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "sort by next column"),
+		),
+		ReverseSort: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "reverse sort"),
+		),
+		Columns: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "choose columns"),
+		),
+		Post: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "post pending"),
+		),
+		PostPartial: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "post partial"),
+		),
+		Void: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "void pending"),
+		),
 		Help: key.NewBinding(
 			key.WithKeys("?"),
 			key.WithHelp("?", "help"),
 		),
+		MoveUp: key.NewBinding(
+			key.WithKeys("shift+up", "K"),
+			key.WithHelp("shift+↑/K", "move column up"),
+		),
+		MoveDown: key.NewBinding(
+			key.WithKeys("shift+down", "J"),
+			key.WithHelp("shift+↓/J", "move column down"),
+		),
+		ResetColumns: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "restore default columns"),
+		),
+	}
+}
+
+// modalKeys returns the bindings the modals act on.
+func (k KeyMap) modalKeys() components.ModalKeys {
+	return components.ModalKeys{
+		Up:       k.Up,
+		Down:     k.Down,
+		PageUp:   k.HalfPageUp,
+		PageDown: k.HalfPageDown,
+		Left:     k.Left,
+		Right:    k.Right,
+		Toggle:   k.Details,
+		MoveUp:   k.MoveUp,
+		MoveDown: k.MoveDown,
+		Reset:    k.ResetColumns,
+	}
+}
+
+// statusHints returns the key hints of the status bar, such as "? Help  q
+// Quit", leaving out disabled bindings.
+func (k KeyMap) statusHints() string {
+	var hints []string
+	for _, h := range []struct {
+		b    key.Binding
+		text string
+	}{{k.Help, "Help"}, {k.Quit, "Quit"}} {
+		if h.b.Enabled() {
+			hints = append(hints, h.b.Help().Key+" "+h.text)
+		}
 	}
+	return strings.Join(hints, "  ")
 }
 
 // ShortHelp returns keybindings to be shown in the mini help view.
@@ -62,11 +244,367 @@ func (k KeyMap) ShortHelp() []key.Binding {
 	return []key.Binding{k.Tab, k.Enter, k.Escape, k.Quit}
 }
 
-// FullHelp returns keybindings for the expanded help view.
+// FullHelp returns keybindings for the expanded help view: one column per
+// dashboard context.
 func (k KeyMap) FullHelp() [][]key.Binding {
-	return [][]key.Binding{
-		{k.Tab, k.ShiftTab, k.Enter, k.Escape},
-		{k.Up, k.Down, k.Refresh, k.Help},
-		{k.Quit},
+	var cols [][]key.Binding
+	for _, g := range k.ContextHelp(ScreenDashboard) {
+		cols = append(cols, g.Bindings)
 	}
+	return cols
+}
+
+// ContextHelp returns the bindings that apply on a screen, grouped by the
+// context they act in, for the help overlay. Keys come from the key map and
+// descriptions are reworded per context.
+func (k KeyMap) ContextHelp(s Screen) []components.HelpGroup {
+	modal := components.HelpGroup{Title: "Modal", Bindings: k.modalHelp()}
+	if s == ScreenConnection {
+		return []components.HelpGroup{
+			{Title: "Connection screen", Bindings: []key.Binding{
+				helpAs(k.Tab, "next field"),
+				helpAs(k.ShiftTab, "previous field"),
+				helpAs(k.Up, "previous profile (in the picker)"),
+				helpAs(k.Down, "next profile (in the picker)"),
+				helpAs(k.Enter, "next field / connect"),
+				k.Help,
+				helpAs(k.Quit, "quit (on the picker or Connect button)"),
+				k.ForceQuit,
+			}},
+			modal,
+		}
+	}
+	return []components.HelpGroup{
+		{Title: "Dashboard", Bindings: []key.Binding{
+			helpAs(k.Tab, "next tab"),
+			helpAs(k.ShiftTab, "previous tab"),
+			k.Refresh,
+			k.Command,
+			k.Search,
+			k.Lookup,
+			k.Export,
+			helpAs(k.Escape, "clear search / close panel / disconnect"),
+			k.Help,
+			k.Quit,
+			k.ForceQuit,
+		}},
+		{Title: "Table", Bindings: []key.Binding{
+			k.Up,
+			k.Down,
+			k.Top,
+			k.Bottom,
+			k.HalfPageUp,
+			k.HalfPageDown,
+			helpAs(k.Enter, "account transfers"),
+			k.Details,
+			k.Right,
+			k.Left,
+			k.History,
+			k.Create,
+			k.Import,
+			k.Filter,
+			k.NextMatch,
+			k.PrevMatch,
+			k.Sort,
+			k.ReverseSort,
+			k.Columns,
+		}},
+		{Title: "Pending tab", Bindings: []key.Binding{k.Post, k.PostPartial, k.Void}},
+		modal,
+	}
+}
+
+// modalHelp lists the bindings modals act on, taken from modalKeys so the
+// help shows the keys the modals read.
+func (k KeyMap) modalHelp() []key.Binding {
+	mk := k.modalKeys()
+	return []key.Binding{
+		helpAs(k.Tab, "next field"),
+		helpAs(k.ShiftTab, "previous field"),
+		helpAs(mk.Left, "previous choice"),
+		helpAs(mk.Right, "next choice"),
+		helpAs(mk.Toggle, "toggle flag or column"),
+		helpAs(mk.Up, "previous item (letter keys are typed in search fields)"),
+		helpAs(mk.Down, "next item (letter keys are typed in search fields)"),
+		helpAs(mk.PageUp, "scroll help up"),
+		helpAs(mk.PageDown, "scroll help down"),
+		helpAs(mk.MoveUp, "move column up"),
+		helpAs(mk.MoveDown, "move column down"),
+		helpAs(mk.Reset, "restore default columns"),
+		helpAs(k.Enter, "submit"),
+		helpAs(k.Escape, "close"),
+		k.ForceQuit,
+	}
+}
+
+// helpAs returns a copy of b described as desc.
+func helpAs(b key.Binding, desc string) key.Binding {
+	b.SetHelp(b.Help().Key, desc)
+	return b
+}
+
+// keyAction names a binding in the keys section of the config.
+type keyAction struct {
+	name    string
+	binding *key.Binding
+}
+
+// actions lists every binding by its config name, in KeyMap order.
+func (k *KeyMap) actions() []keyAction {
+	return []keyAction{
+		{"force_quit", &k.ForceQuit},
+		{"quit", &k.Quit},
+		{"tab", &k.Tab},
+		{"shift_tab", &k.ShiftTab},
+		{"enter", &k.Enter},
+		{"escape", &k.Escape},
+		{"up", &k.Up},
+		{"down", &k.Down},
+		{"top", &k.Top},
+		{"bottom", &k.Bottom},
+		{"half_page_up", &k.HalfPageUp},
+		{"half_page_down", &k.HalfPageDown},
+		{"left", &k.Left},
+		{"right", &k.Right},
+		{"details", &k.Details},
+		{"refresh", &k.Refresh},
+		{"history", &k.History},
+		{"create", &k.Create},
+		{"import", &k.Import},
+		{"export", &k.Export},
+		{"search", &k.Search},
+		{"lookup", &k.Lookup},
+		{"filter", &k.Filter},
+		{"next_match", &k.NextMatch},
+		{"prev_match", &k.PrevMatch},
+		{"command", &k.Command},
+		{"sort", &k.Sort},
+		{"reverse_sort", &k.ReverseSort},
+		{"columns", &k.Columns},
+		{"post", &k.Post},
+		{"post_partial", &k.PostPartial},
+		{"void", &k.Void},
+		{"help", &k.Help},
+		{"move_up", &k.MoveUp},
+		{"move_down", &k.MoveDown},
+		{"reset_columns", &k.ResetColumns},
+	}
+}
+
+// keyContexts lists the actions each screen or panel dispatches on the same
+// key presses; no two of them may share a key.
+var keyContexts = []struct {
+	name    string
+	actions []string
+}{
+	{"the connection screen", []string{"force_quit", "quit", "tab", "shift_tab", "enter", "up", "down", "help"}},
+	{"the dashboard", []string{
+		"force_quit", "quit", "tab", "shift_tab", "enter", "escape",
+		"up", "down", "top", "bottom", "half_page_up", "half_page_down",
+		"right", "details", "refresh", "history", "create", "import", "export",
+		"search", "lookup", "filter", "next_match", "prev_match", "command",
+		"sort", "reverse_sort", "columns", "post", "post_partial", "void", "help",
+	}},
+	{"the details panel", []string{
+		"force_quit", "quit", "tab", "shift_tab", "escape",
+		"up", "down", "left", "details", "command", "help",
+	}},
+	{"modals", []string{
+		"force_quit", "tab", "shift_tab", "enter", "escape",
+		"up", "down", "half_page_up", "half_page_down", "left", "right", "details",
+		"move_up", "move_down", "reset_columns",
+	}},
+}
+
+// keyPresets are the presets keys.preset selects: extra keys per action,
+// and whether counts are on.
+var keyPresets = map[string]struct {
+	keys   map[string][]string
+	counts bool
+}{
+	"vim": {
+		keys: map[string][]string{
+			"top":            {"g g"},
+			"bottom":         {"G"},
+			"half_page_up":   {"ctrl+u"},
+			"half_page_down": {"ctrl+d"},
+		},
+		counts: true,
+	},
+}
+
+// LoadKeyMap applies the keys section of the config to the default key
+// map: first the preset's keys, then the per-action bindings, which replace
+// an action's keys (none disables it). Keys are Bubble Tea key names such
+// as "r", "ctrl+d" or "space"; a sequence is written with spaces between
+// its keys, as in "g g". Unknown actions and keys shared by two actions of
+// one context are reported together as a configuration error.
+func LoadKeyMap(cfg config.KeysConfig) (KeyMap, error) {
+	k := DefaultKeyMap()
+	actions := k.actions()
+	byName := make(map[string]*key.Binding, len(actions))
+	for _, a := range actions {
+		byName[a.name] = a.binding
+	}
+
+	var problems []string
+	if cfg.Preset != "" {
+		preset, ok := keyPresets[cfg.Preset]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unknown preset %q, want vim", cfg.Preset))
+		}
+		for name, keys := range preset.keys {
+			b := byName[name]
+			setKeys(b, append(b.Keys(), keys...))
+		}
+		k.Counts = preset.counts
+	}
+
+	names := slices.Sorted(maps.Keys(cfg.Bindings))
+	for _, name := range names {
+		b, ok := byName[name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unknown action %q", name))
+			continue
+		}
+		keys := slices.Clone(cfg.Bindings[name])
+		for i, s := range keys {
+			if s == "space" {
+				keys[i] = " "
+			}
+		}
+		setKeys(b, keys)
+	}
+	problems = append(problems, k.conflicts(byName)...)
+	if len(problems) > 0 {
+		return KeyMap{}, apperror.New(apperror.CodeConfigurationError,
+			apperror.WithMessage("invalid key bindings: "+strings.Join(problems, "; ")),
+			apperror.WithContext("keys"))
+	}
+	return k, nil
+}
+
+// setKeys replaces a binding's keys and the keys its help shows, keeping
+// its description. A binding with no keys is disabled.
+func setKeys(b *key.Binding, keys []string) {
+	shown := make([]string, len(keys))
+	for i, s := range keys {
+		shown[i] = keyName(s)
+	}
+	b.SetKeys(keys...)
+	b.SetHelp(strings.Join(shown, "/"), b.Help().Desc)
+	b.SetEnabled(len(keys) > 0)
+}
+
+// keyName renders a key for help: arrows as symbols, " " as space and a
+// sequence such as "g g" as gg.
+func keyName(s string) string {
+	switch s {
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	case " ":
+		return "space"
+	case "shift+up":
+		return "shift+↑"
+	case "shift+down":
+		return "shift+↓"
+	}
+	return strings.ReplaceAll(s, " ", "")
+}
+
+// conflicts reports keys that two actions of one context share, keys that
+// are also the start of a sequence, and digits that counts would swallow.
+func (k *KeyMap) conflicts(byName map[string]*key.Binding) []string {
+	var problems []string
+	for _, c := range keyContexts {
+		owner := map[string]string{}
+		for _, name := range c.actions {
+			for _, s := range byName[name].Keys() {
+				if other, ok := owner[s]; ok && other != name {
+					problems = append(problems, fmt.Sprintf("%q is bound to both %s and %s on %s", keyName(s), other, name, c.name))
+				}
+				owner[s] = name
+			}
+		}
+		for s, name := range owner {
+			for prefix := range sequencePrefixes(s) {
+				if other, ok := owner[prefix]; ok {
+					problems = append(problems, fmt.Sprintf("%q of %s starts %q of %s on %s", keyName(prefix), other, keyName(s), name, c.name))
+				}
+			}
+			if k.Counts && c.name == "the dashboard" && isDigit(s) {
+				problems = append(problems, fmt.Sprintf("%q of %s is taken by counts in the vim preset", s, name))
+			}
+		}
+	}
+	slices.Sort(problems)
+	return problems
+}
+
+// sequencePrefixes yields the unfinished prefixes of a key sequence: "g"
+// for "g g".
+func sequencePrefixes(s string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for i := range len(s) {
+			if s[i] == ' ' && i > 0 && !yield(s[:i]) {
+				return
+			}
+		}
+	}
+}
+
+// startsSequence reports whether keys typed so far begin a sequence bound
+// to an enabled action.
+func (k *KeyMap) startsSequence(s string) bool {
+	for _, a := range k.actions() {
+		if !a.binding.Enabled() {
+			continue
+		}
+		for _, bound := range a.binding.Keys() {
+			if strings.HasPrefix(bound, s+" ") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// readKey collects counts and key sequences on the dashboard. It returns
+// the key to act on, with a finished sequence as one key named like its
+// binding ("g g"), and the count typed before it, or false while a count or
+// sequence is still being typed. Escape cancels one.
+func (m *Model) readKey(msg tea.KeyMsg) (tea.KeyMsg, int, bool) {
+	if (m.count > 0 || m.keySeq != "") && key.Matches(msg, m.keys.Escape) {
+		m.count, m.keySeq = 0, ""
+		return msg, 0, false
+	}
+	s := msg.String()
+	if m.keySeq != "" {
+		s = m.keySeq + " " + s
+		m.keySeq = ""
+	}
+	if m.keys.Counts && isDigit(s) && (s != "0" || m.count > 0) {
+		m.count = m.count*10 + int(s[0]-'0')
+		return msg, 0, false
+	}
+	if m.keys.startsSequence(s) {
+		m.keySeq = s
+		return msg, 0, false
+	}
+	if s != msg.String() {
+		msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+	count := m.count
+	m.count = 0
+	return msg, count, true
+}
+
+func isDigit(s string) bool {
+	return len(s) == 1 && s[0] >= '0' && s[0] <= '9'
 }
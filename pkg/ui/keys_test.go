@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fd1az/tiger-tui/internal/config"
+)
+
+func TestModalHelpFollowsRemap(t *testing.T) {
+	k, err := LoadKeyMap(config.KeysConfig{Bindings: map[string][]string{
+		"left":    {"["},
+		"right":   {"]"},
+		"details": {"x"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The modals read the remapped keys...
+	mk := k.modalKeys()
+	press := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+	if !key.Matches(press("["), mk.Left) || !key.Matches(press("]"), mk.Right) || !key.Matches(press("x"), mk.Toggle) {
+		t.Error("modal keys do not follow the remap")
+	}
+	if key.Matches(press("h"), mk.Left) || key.Matches(space, mk.Toggle) {
+		t.Error("modal keys still answer to the default keys")
+	}
+
+	// ...and the help lists them.
+	var modal []string
+	for _, g := range k.ContextHelp(ScreenDashboard) {
+		if g.Title != "Modal" {
+			continue
+		}
+		for _, b := range g.Bindings {
+			modal = append(modal, b.Help().Key+" "+b.Help().Desc)
+		}
+	}
+	help := strings.Join(modal, "\n")
+	for _, want := range []string{"[ previous choice", "] next choice", "x toggle flag or column"} {
+		if !strings.Contains(help, want) {
+			t.Errorf("modal help lacks %q:\n%s", want, help)
+		}
+	}
+}
+
+func TestModalKeyConflicts(t *testing.T) {
+	for _, bindings := range []map[string][]string{
+		{"left": {"r"}},          // restores the default columns
+		{"details": {"tab"}},     // next field
+		{"move_up": {"up"}},      // previous item
+		{"right": {"shift+tab"}}, // previous field
+	} {
+		if _, err := LoadKeyMap(config.KeysConfig{Bindings: bindings}); err == nil || !strings.Contains(err.Error(), "on modals") {
+			t.Errorf("LoadKeyMap(%v) error = %v, want a conflict on modals", bindings, err)
+		}
+	}
+}
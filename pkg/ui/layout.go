@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"errors"
+	"maps"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fd1az/tiger-tui/internal/state"
+	"github.com/fd1az/tiger-tui/pkg/ui/components"
+)
+
+func init() {
+	registerCommand(paletteCommand{
+		name:    "sort",
+		args:    "<column>[:desc]... | off",
+		summary: "sort the active table by one or more columns",
+		complete: func(m *Model, _ int, _ []string) []string {
+			t, ok := m.layoutTable()
+			if !ok {
+				return nil
+			}
+			out := []string{"off"}
+			for _, k := range t.Table().ColumnKeys() {
+				out = append(out, k, k+":desc")
+			}
+			return out
+		},
+		run: func(m *Model, args []string) (tea.Cmd, error) {
+			t, ok := m.layoutTable()
+			switch {
+			case !ok:
+				return nil, errNoLayout
+			case len(args) == 0:
+				return nil, errors.New("sort needs a column, e.g. sort amount:desc ledger, or off")
+			case len(args) == 1 && args[0] == "off":
+				args = nil
+			}
+			keys, err := components.ParseSort(args)
+			if err != nil {
+				return nil, err
+			}
+			for i := range keys {
+				if keys[i].Column, err = matchArg("column", keys[i].Column, t.Table().ColumnKeys()); err != nil {
+					return nil, err
+				}
+			}
+			if err := t.Table().SetSort(keys); err != nil {
+				return nil, err
+			}
+			return m.sorted(t), nil
+		},
+	})
+	registerCommand(paletteCommand{
+		name:    "columns",
+		summary: "choose and reorder the columns of the active table",
+		run: func(m *Model, _ []string) (tea.Cmd, error) {
+			if _, ok := m.layoutTable(); !ok {
+				return nil, errNoLayout
+			}
+			m.openColumns()
+			return nil, nil
+		},
+	})
+}
+
+// errNoLayout is returned when the active tab has no table to arrange.
+var errNoLayout = errors.New("sorting and columns apply to the Accounts, Transfers and Pending tabs")
+
+// layoutFile is where table layouts are kept, keyed by tab view name.
+const layoutFile = "layout.json"
+
+// layoutTable returns the active tab's table if its layout can change.
+func (m *Model) layoutTable() (components.LayoutTable, bool) {
+	if m.dashboard.HistoryOpen() {
+		return nil, false
+	}
+	return m.dashboard.LayoutTable(m.dashboard.ActiveTab())
+}
+
+// restoreLayouts applies the saved layouts to a new dashboard's tables.
+func (m *Model) restoreLayouts() {
+	for tab, view := range tabViews {
+		if t, ok := m.dashboard.LayoutTable(tab); ok {
+			t.Table().SetLayout(m.layouts[view])
+			t.Resort()
+		}
+	}
+}
+
+// saveLayout records the active table's layout and persists all of them.
+func (m *Model) saveLayout(t components.LayoutTable) tea.Cmd {
+	layouts := maps.Clone(m.layouts)
+	if layouts == nil {
+		layouts = map[string]components.Layout{}
+	}
+	layouts[tabViews[m.dashboard.ActiveTab()]] = t.Table().Layout()
+	m.layouts = layouts
+	return SaveLayoutsCmd(m.layoutFile, layouts)
+}
+
+// sorted resorts the active table after its sort changed, reports the new
+// order and saves it.
+func (m *Model) sorted(t components.LayoutTable) tea.Cmd {
+	t.Resort()
+	if s := t.Table().SortSummary(); s != "" {
+		m.statusBar.SetMessage("Sorted by "+s, 0)
+	} else {
+		m.statusBar.SetMessage("Rows in load order", 0)
+	}
+	return m.saveLayout(t)
+}
+
+// cycleSort handles s (next sort column) and S (reverse the sort).
+func (m *Model) cycleSort(reverse bool) tea.Cmd {
+	t, ok := m.layoutTable()
+	if !ok {
+		m.statusBar.SetMessage(errNoLayout.Error(), 2)
+		return nil
+	}
+	if !reverse {
+		t.Table().CycleSort()
+	} else if !t.Table().ReverseSort() {
+		m.statusBar.SetMessage("Not sorted; press s to sort", 2)
+		return nil
+	}
+	return m.sorted(t)
+}
+
+// openColumns opens the column chooser on the active table.
+func (m *Model) openColumns() {
+	t, ok := m.layoutTable()
+	if !ok {
+		m.statusBar.SetMessage(errNoLayout.Error(), 2)
+		return
+	}
+	m.columnChooser = components.NewColumnChooser(tabViews[m.dashboard.ActiveTab()], t.Table(), m.keys.modalKeys())
+	m.overlay = OverlayColumns
+}
+
+// updateColumns handles keys in the column chooser; Enter applies.
+func (m Model) updateColumns(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	t, ok := m.layoutTable()
+	if !ok {
+		m.overlay = OverlayNone
+		return m, nil
+	}
+	if key.Matches(msg, m.keys.Enter) {
+		m.overlay = OverlayNone
+		t.Table().SetLayout(m.columnChooser.Layout(t.Table().SortKeys()))
+		t.Resort()
+		return m, m.saveLayout(t)
+	}
+	m.columnChooser.Update(msg)
+	return m, nil
+}
+
+// loadLayouts reads the saved table layouts. A missing file is no error.
+func loadLayouts(f state.File) (map[string]components.Layout, error) {
+	layouts := map[string]components.Layout{}
+	if err := f.Load(&layouts); err != nil {
+		return nil, err
+	}
+	return layouts, nil
+}
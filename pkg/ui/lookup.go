@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fd1az/tiger-tui/business/accounts/domain"
+	"github.com/fd1az/tiger-tui/pkg/ui/components"
+)
+
+func init() {
+	registerCommand(paletteCommand{
+		name:    "lookup",
+		args:    "[id]",
+		summary: "look up an account or transfer by ID",
+		complete: func(m *Model, n int, _ []string) []string {
+			if n > 0 {
+				return nil
+			}
+			return m.selectedIDs()
+		},
+		run: func(m *Model, args []string) (tea.Cmd, error) {
+			if m.lookup == nil {
+				return nil, errNotConnected
+			}
+			if len(args) == 0 {
+				m.openLookup()
+				return nil, nil
+			}
+			return m.startLookup(args[0])
+		},
+	})
+}
+
+// startLookup opens the lookup prompt already searching for s.
+func (m *Model) startLookup(s string) (tea.Cmd, error) {
+	id, err := domain.ParseID(s)
+	if err != nil {
+		return nil, err
+	}
+	m.openLookup()
+	m.lookupPrompt.SetValue(s)
+	m.lookupPrompt.SetSearching(true)
+	return LookupCmd(m.lookup, id), nil
+}
+
+// openLookup opens an empty lookup prompt.
+func (m *Model) openLookup() {
+	m.lookupPrompt = components.NewLookupPrompt()
+	m.overlay = OverlayLookup
+}
+
+// selectedIDs returns the IDs of the rows selected on the dashboard, to
+// complete an ID argument.
+func (m *Model) selectedIDs() []string {
+	var ids []string
+	switch m.dashboard.ActiveTab() {
+	case 0:
+		if acc, ok := m.dashboard.Accounts().Selected(); ok {
+			ids = append(ids, domain.FormatID(acc.ID))
+		}
+	case 3:
+		if hold, ok := m.dashboard.Pending().Selected(); ok {
+			t := hold.Transfer
+			ids = append(ids, domain.FormatID(t.ID), domain.FormatID(t.DebitAccountID), domain.FormatID(t.CreditAccountID))
+		}
+	}
+	return ids
+}
+
+// updateLookup handles keys in the lookup prompt.
+func (m Model) updateLookup(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := &m.lookupPrompt
+	if key.Matches(msg, m.keys.Enter) {
+		if p.Searching() || m.lookup == nil {
+			return m, nil
+		}
+		id, err := p.ID()
+		if err != nil {
+			return m, nil
+		}
+		p.SetSearching(true)
+		return m, LookupCmd(m.lookup, id)
+	}
+	return m, p.Update(msg)
+}
+
+// updateLookupResult handles keys in the lookup detail view. Enter on an
+// account drills into its transfers.
+func (m Model) updateLookupResult(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if !key.Matches(msg, m.keys.Enter) {
+		return m, nil
+	}
+	acc, ok := m.lookupDetail.Account()
+	if !ok {
+		return m, nil
+	}
+	m.overlay = OverlayNone
+	m.dashboard.CloseHistory()
+	m.dashboard.Transfers().SetScope(acc.ID)
+	m.dashboard.SetTab(1)
+	return m, m.reloadTransfers()
+}
@@ -1,10 +1,23 @@
 package ui
 
-import "github.com/fd1az/tiger-tui/business/connection/infra"
+import (
+	"time"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	"github.com/fd1az/tiger-tui/business/accounts/domain"
+	bsdomain "github.com/fd1az/tiger-tui/business/balancesheet/domain"
+	"github.com/fd1az/tiger-tui/business/connection/infra"
+	exportapp "github.com/fd1az/tiger-tui/business/export/app"
+	lookupdomain "github.com/fd1az/tiger-tui/business/lookup/domain"
+	outboxdomain "github.com/fd1az/tiger-tui/business/outbox/domain"
+	transfersdomain "github.com/fd1az/tiger-tui/business/transfers/domain"
+)
 
 // ConnectedMsg signals successful TigerBeetle connection.
 type ConnectedMsg struct {
 	Client *infra.Client
+	Chart  domain.Chart // the connection's chart of accounts
 }
 
 // ConnectionFailedMsg signals a failed connection attempt.
@@ -12,6 +25,156 @@ type ConnectionFailedMsg struct {
 	Err error
 }
 
+// AccountsLoadedMsg carries a page of accounts. Append is true when the page
+// continues the rows already shown.
+type AccountsLoadedMsg struct {
+	Page   domain.AccountPage
+	Append bool
+}
+
+// AccountsLoadFailedMsg signals a failed accounts page request.
+type AccountsLoadFailedMsg struct {
+	Err error
+}
+
+// AccountHistoryLoadedMsg carries the balance snapshots of an account.
+type AccountHistoryLoadedMsg struct {
+	AccountID types.Uint128
+	Balances  []types.AccountBalance
+}
+
+// AccountHistoryLoadFailedMsg signals a failed balance history request.
+type AccountHistoryLoadFailedMsg struct {
+	AccountID types.Uint128
+	Err       error
+}
+
+// AccountsCreatedMsg carries one outcome per submitted account.
+type AccountsCreatedMsg struct {
+	Outcomes []domain.CreateOutcome
+}
+
+// AccountsCreateFailedMsg signals that a CreateAccounts request failed as a
+// whole (no per-item results).
+type AccountsCreateFailedMsg struct {
+	Err error
+}
+
+// TransfersLoadedMsg carries a page of transfers. Append is true when the page
+// continues the rows already shown.
+type TransfersLoadedMsg struct {
+	Page   transfersdomain.TransferPage
+	Append bool
+}
+
+// TransfersLoadFailedMsg signals a failed transfers page request.
+type TransfersLoadFailedMsg struct {
+	Err error
+}
+
+// BalanceSheetLoadedMsg carries a freshly computed balance sheet.
+type BalanceSheetLoadedMsg struct {
+	Summary bsdomain.Summary
+}
+
+// BalanceSheetLoadFailedMsg signals a failed balance sheet load.
+type BalanceSheetLoadFailedMsg struct {
+	Err error
+}
+
+// TransfersCreatedMsg carries one outcome per submitted transfer.
+type TransfersCreatedMsg struct {
+	Outcomes []transfersdomain.CreateOutcome
+}
+
+// TransfersCreateFailedMsg signals that a CreateTransfers request failed as a
+// whole (no per-item results).
+type TransfersCreateFailedMsg struct {
+	Err error
+}
+
+// TransfersImportedMsg carries the per-row report of an import and where
+// its results were written.
+type TransfersImportedMsg struct {
+	Report      transfersdomain.ImportReport
+	ResultsPath string
+}
+
+// TransfersImportFailedMsg signals that an import could not be read, sent or
+// recorded. Report holds whatever was done before the failure.
+type TransfersImportFailedMsg struct {
+	Report      transfersdomain.ImportReport
+	ResultsPath string
+	Err         error
+}
+
+// ExportProgressMsg reports that an export wrote another page; Rows is the
+// total so far.
+type ExportProgressMsg struct {
+	Job  *exportapp.Job
+	Rows int
+}
+
+// ExportDoneMsg reports a completed export.
+type ExportDoneMsg struct {
+	Job  *exportapp.Job
+	Rows int
+}
+
+// ExportFailedMsg signals that an export failed or was canceled; no file was
+// left behind.
+type ExportFailedMsg struct {
+	Job *exportapp.Job
+	Err error
+}
+
+// HoldsLoadedMsg carries the open pending transfers found by a scan.
+type HoldsLoadedMsg struct {
+	Holds []transfersdomain.Hold
+}
+
+// HoldsLoadFailedMsg signals that scanning for pending transfers failed.
+type HoldsLoadFailedMsg struct {
+	Err error
+}
+
+// PendingResolvedMsg carries the outcome of a post or void of a pending
+// transfer.
+type PendingResolvedMsg struct {
+	Outcome transfersdomain.CreateOutcome
+}
+
+// PendingResolveFailedMsg signals that a post or void request failed as a
+// whole.
+type PendingResolveFailedMsg struct {
+	Err error
+}
+
+// PendingTickMsg refreshes the pending transfers countdown.
+type PendingTickMsg time.Time
+
+// LookupDoneMsg carries what a lookup by ID found.
+type LookupDoneMsg struct {
+	Result lookupdomain.Result
+}
+
+// LookupFailedMsg signals that a lookup failed or matched nothing.
+type LookupFailedMsg struct {
+	Err error
+}
+
+// OutboxResubmittedMsg reports the resubmission of pending outbox entries.
+type OutboxResubmittedMsg struct {
+	Report outboxdomain.Report
+}
+
+// OutboxResubmitFailedMsg signals that resubmitting the outbox stopped on an
+// error; the remaining entries stay pending.
+type OutboxResubmitFailedMsg struct {
+	Report outboxdomain.Report
+	Err    error
+}
+
 // ErrorMsg is sent when an error occurs.
 type ErrorMsg struct {
 	Err error
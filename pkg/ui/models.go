@@ -8,6 +8,25 @@ const (
 	ScreenDashboard
 )
 
+// Overlay is the modal currently shown over the dashboard.
+type Overlay int
+
+const (
+	OverlayNone Overlay = iota
+	OverlayCreateAccount
+	OverlayCreateTransfer
+	OverlayImport
+	OverlayExport
+	OverlayPostPartial
+	OverlayLookup
+	OverlayLookupResult
+	OverlayCommand // the ":" command bar; drawn in place of the status bar
+	OverlaySearch  // the "/" search line; drawn in place of the status bar
+	OverlayFilter
+	OverlayColumns
+	OverlayHelp
+)
+
 // Tab represents the active dashboard tab.
 type Tab int
 
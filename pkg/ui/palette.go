@@ -0,0 +1,202 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fd1az/tiger-tui/pkg/ui/components"
+)
+
+// paletteCommand is an action the ":" command bar can run. Features register
+// theirs with registerCommand from an init function next to their handlers,
+// so the bar itself knows no commands.
+type paletteCommand struct {
+	name    string // e.g. "goto"
+	args    string // usage, e.g. "<tab> [account]"
+	summary string
+	// complete returns the candidates for argument n (0-based) after args;
+	// nil offers none.
+	complete func(m *Model, n int, args []string) []string
+	// run executes the command. An error keeps the bar open and is shown
+	// there.
+	run func(m *Model, args []string) (tea.Cmd, error)
+}
+
+// errNotConnected is returned by commands that need a cluster connection.
+var errNotConnected = errors.New("not connected")
+
+// paletteCommands holds the registered commands in registration order.
+var paletteCommands []paletteCommand
+
+// registerCommand adds a command to the palette. Names must be unique.
+func registerCommand(c paletteCommand) {
+	for _, existing := range paletteCommands {
+		if existing.name == c.name {
+			panic("ui: palette command registered twice: " + c.name)
+		}
+	}
+	paletteCommands = append(paletteCommands, c)
+}
+
+// findCommand resolves a typed command name: an exact name, or else the
+// best fuzzy match, so abbreviations like "exp" work.
+func findCommand(name string) (paletteCommand, bool) {
+	names := make([]string, len(paletteCommands))
+	for i, c := range paletteCommands {
+		if c.name == name {
+			return c, true
+		}
+		names[i] = c.name
+	}
+	matches := components.FuzzyFilter(name, names)
+	if len(matches) == 0 {
+		return paletteCommand{}, false
+	}
+	for _, c := range paletteCommands {
+		if c.name == matches[0] {
+			return c, true
+		}
+	}
+	return paletteCommand{}, false
+}
+
+// matchArg resolves an argument against its allowed values: an exact value
+// or a unique prefix, so "goto pen" works like "goto pending". what names
+// the argument in errors.
+func matchArg(what, arg string, values []string) (string, error) {
+	var matches []string
+	for _, v := range values {
+		if v == arg {
+			return v, nil
+		}
+		if strings.HasPrefix(v, arg) {
+			matches = append(matches, v)
+		}
+	}
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		return "", fmt.Errorf("unknown %s %q, want %s", what, arg, strings.Join(values, ", "))
+	}
+	return "", fmt.Errorf("ambiguous %s %q: %s", what, arg, strings.Join(matches, ", "))
+}
+
+// openCommandBar shows the ":" command bar with every command suggested.
+func (m *Model) openCommandBar() {
+	m.commandBar = components.NewCommandBar(m.width)
+	m.commandBar.SetSuggestions(m.commandSuggestions(""))
+	m.overlay = OverlayCommand
+}
+
+// updateCommandBar handles keys in the command bar: Tab completes the
+// highlighted suggestion, ↑/↓ (or Ctrl+P/Ctrl+N) move the highlight and
+// Enter runs the line as typed, or the suggestion picked with ↑/↓.
+func (m Model) updateCommandBar(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	b := &m.commandBar
+	switch msg.String() {
+	case "enter":
+		if s, ok := b.Selected(); ok && b.Picked() {
+			b.SetValue(s.Text)
+		}
+		return m.runCommandLine()
+
+	case "tab":
+		s, ok := b.Selected()
+		if !ok {
+			return m, nil
+		}
+		text := s.Text
+		if c, ok := findCommand(text); ok && c.name == text && c.args != "" {
+			text += " "
+		}
+		b.SetValue(text)
+		b.SetSuggestions(m.commandSuggestions(text))
+		return m, nil
+
+	case "up", "ctrl+p", "shift+tab":
+		b.SelectPrev()
+		return m, nil
+
+	case "down", "ctrl+n":
+		b.SelectNext()
+		return m, nil
+	}
+
+	cmd := b.Update(msg)
+	b.SetSuggestions(m.commandSuggestions(b.Value()))
+	return m, cmd
+}
+
+// runCommandLine runs the typed command and closes the bar, or shows why it
+// could not run.
+func (m Model) runCommandLine() (tea.Model, tea.Cmd) {
+	fields := strings.Fields(m.commandBar.Value())
+	if len(fields) == 0 {
+		m.overlay = OverlayNone
+		return m, nil
+	}
+	c, ok := findCommand(fields[0])
+	if !ok {
+		m.commandBar.SetError(fmt.Sprintf("unknown command %q", fields[0]))
+		return m, nil
+	}
+
+	// The command may open an overlay of its own.
+	m.overlay = OverlayNone
+	cmd, err := c.run(&m, fields[1:])
+	if err != nil {
+		m.overlay = OverlayCommand
+		m.commandBar.SetError(err.Error())
+		return m, nil
+	}
+	return m, cmd
+}
+
+// commandSuggestions completes a command line: command names while the
+// first word is typed, then the arguments the command offers.
+func (m *Model) commandSuggestions(line string) []components.Suggestion {
+	fields := strings.Fields(line)
+	trailing := strings.HasSuffix(line, " ")
+
+	if len(fields) == 0 || (len(fields) == 1 && !trailing) {
+		typed := ""
+		if len(fields) == 1 {
+			typed = fields[0]
+		}
+		names := make([]string, len(paletteCommands))
+		for i, c := range paletteCommands {
+			names[i] = c.name
+		}
+		var out []components.Suggestion
+		for _, name := range components.FuzzyFilter(typed, names) {
+			c, _ := findCommand(name)
+			out = append(out, components.Suggestion{Text: name, Hint: strings.TrimSpace(c.args + "  " + c.summary)})
+		}
+		return out
+	}
+
+	c, ok := findCommand(fields[0])
+	if !ok {
+		return nil
+	}
+	args, typed := fields[1:], ""
+	if !trailing {
+		args, typed = args[:len(args)-1], args[len(args)-1]
+	}
+	if c.complete == nil {
+		return []components.Suggestion{{Text: strings.TrimSpace(line), Hint: c.name + " " + c.args}}
+	}
+	prefix := strings.Join(append([]string{c.name}, args...), " ") + " "
+	var out []components.Suggestion
+	for _, cand := range components.FuzzyFilter(typed, c.complete(m, len(args), args)) {
+		out = append(out, components.Suggestion{Text: prefix + cand})
+	}
+	if len(out) == 0 {
+		out = append(out, components.Suggestion{Text: strings.TrimSpace(line), Hint: c.name + " " + c.args})
+	}
+	return out
+}
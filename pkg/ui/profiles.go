@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	conndomain "github.com/fd1az/tiger-tui/business/connection/domain"
+	"github.com/fd1az/tiger-tui/internal/config"
+	"github.com/fd1az/tiger-tui/pkg/ui/components"
+)
+
+func init() {
+	registerCommand(paletteCommand{
+		name:    "connect",
+		args:    "<profile>",
+		summary: "disconnect and connect with a saved profile",
+		complete: func(m *Model, n int, _ []string) []string {
+			if n > 0 {
+				return nil
+			}
+			return m.profileNames()
+		},
+		run: func(m *Model, args []string) (tea.Cmd, error) {
+			if len(m.profiles) == 0 {
+				return nil, fmt.Errorf("no profiles are configured")
+			}
+			if len(args) == 0 {
+				return nil, fmt.Errorf("connect needs a profile")
+			}
+			name, err := matchArg("profile", args[0], m.profileNames())
+			if err != nil {
+				return nil, err
+			}
+			m.disconnect()
+			m.connForm.SelectProfile(name)
+			return m.connect(), nil
+		},
+	})
+}
+
+// connectionProfiles returns the saved profiles for the connection screen's
+// picker, in name order.
+func connectionProfiles(cfg *config.Config) []components.ConnectionProfile {
+	var profiles []components.ConnectionProfile
+	for _, name := range cfg.ProfileNames() {
+		p := cfg.Profiles[name]
+		profiles = append(profiles, components.ConnectionProfile{
+			Name:      name,
+			ClusterID: p.ClusterID,
+			Addresses: p.Addresses,
+			ReadOnly:  p.ReadOnly,
+			Color:     p.Color,
+		})
+	}
+	return profiles
+}
+
+// profileNames returns the saved profiles' names in order.
+func (m *Model) profileNames() []string {
+	return slices.Sorted(maps.Keys(m.profiles))
+}
+
+// connect validates the connection form's cluster ID and replica addresses
+// and connects with them, as the picked profile while its cluster and
+// addresses are unchanged, or always when it is read-only. The profile's
+// chart of accounts replaces the configured one.
+func (m *Model) connect() tea.Cmd {
+	if m.connForm.ClusterID() == "" {
+		m.connForm.SetError("Cluster ID is required")
+		return nil
+	}
+	if _, err := conndomain.ParseClusterID(m.connForm.ClusterID()); err != nil {
+		m.connForm.SetError(err.Error())
+		return nil
+	}
+	addresses, err := conndomain.ParseAddresses(m.connForm.Address())
+	if err != nil {
+		m.connForm.SetError(err.Error())
+		return nil
+	}
+
+	m.profile, _ = m.connForm.Profile()
+	chartFile := m.chartFile
+	if p := m.profiles[m.profile.Name]; p.ChartFile != "" {
+		chartFile = p.ChartFile
+	}
+
+	m.connStatus = Connecting
+	m.connForm.SetStatus(1)
+	m.connForm.SetError("")
+	if m.profile.Name != "" {
+		m.statusBar.SetMessage(fmt.Sprintf("Connecting to %s...", m.profile.Name), 0)
+	} else {
+		m.statusBar.SetMessage("Connecting...", 0)
+	}
+	return ConnectCmd(m.connForm.ClusterID(), addresses, chartFile)
+}
+
+// writable returns an error when the connection's profile is read-only.
+func (m *Model) writable() error {
+	if m.profile.ReadOnly {
+		return fmt.Errorf("profile %s is read-only; writes are disabled", m.profile.Name)
+	}
+	return nil
+}
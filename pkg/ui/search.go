@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fd1az/tiger-tui/business/accounts/domain"
+	"github.com/fd1az/tiger-tui/pkg/ui/components"
+)
+
+func init() {
+	registerCommand(paletteCommand{
+		name:    "search",
+		args:    "<text>",
+		summary: "search the loaded rows of the active tab",
+		run: func(m *Model, args []string) (tea.Cmd, error) {
+			t, ok := m.dashboard.SearchTable()
+			if !ok {
+				return nil, errNoSearch
+			}
+			if len(args) == 0 {
+				m.openSearch()
+				return nil, nil
+			}
+			t.Search(strings.Join(args, " "), t.Cursor())
+			return m.loadMore(), nil
+		},
+	})
+}
+
+// errNoSearch is returned when the active tab has no searchable table.
+var errNoSearch = errors.New("search works on the Accounts and Transfers tabs")
+
+// openSearch starts an incremental search of the active table. Tabs without
+// one open the lookup prompt instead, so "/" always finds something.
+func (m *Model) openSearch() {
+	t, ok := m.dashboard.SearchTable()
+	if !ok {
+		if m.lookup != nil {
+			m.openLookup()
+		}
+		return
+	}
+	m.searchBar = components.NewSearchBar(m.width)
+	m.searchOrigin = t.Cursor()
+	t.ClearSearch()
+	m.overlay = OverlaySearch
+}
+
+// searchActive reports whether the active table has a confirmed search for
+// n/N and Esc to act on.
+func (m *Model) searchActive() bool {
+	t, ok := m.dashboard.SearchTable()
+	return ok && t.SearchQuery() != ""
+}
+
+// updateSearch handles keys on the search line. The cursor follows the first
+// match at or after where the search started; Enter keeps the search for
+// n/N, Esc drops it and returns to the starting row. Enter on an ID that
+// matches no loaded row looks it up on the cluster.
+func (m Model) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	t, ok := m.dashboard.SearchTable()
+	if !ok {
+		m.overlay = OverlayNone
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Escape):
+		t.ClearSearch()
+		t.SetCursor(m.searchOrigin)
+		m.overlay = OverlayNone
+		return m, nil
+
+	case key.Matches(msg, m.keys.Enter):
+		m.overlay = OverlayNone
+		query := strings.TrimSpace(m.searchBar.Value())
+		if query == "" || t.SearchMatches() > 0 || m.lookup == nil {
+			return m, m.loadMore()
+		}
+		if _, err := domain.ParseID(query); err != nil {
+			return m, nil
+		}
+		t.ClearSearch()
+		t.SetCursor(m.searchOrigin)
+		cmd, _ := m.startLookup(query)
+		return m, cmd
+	}
+
+	cmd := m.searchBar.Update(msg)
+	t.Search(m.searchBar.Value(), m.searchOrigin)
+	return m, tea.Batch(cmd, m.loadMore())
+}
+
+// searchBarView renders the search line with the match count, which grows
+// as more pages load.
+func (m Model) searchBarView() string {
+	bar := m.searchBar
+	if t, ok := m.dashboard.SearchTable(); ok {
+		status := t.SearchStatus()
+		if t.SearchMatches() == 0 && t.SearchQuery() != "" && m.lookup != nil {
+			if _, err := domain.ParseID(m.searchBar.Value()); err == nil {
+				status += " · enter to look up the ID"
+			}
+		}
+		bar.SetStatus(status)
+	}
+	return bar.View()
+}
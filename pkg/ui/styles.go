@@ -1,95 +1,110 @@
 // Package ui provides the Bubble Tea TUI for tiger-tui.
 package ui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
 
-// Mainframe Modern — color tokens (dark theme)
-var (
-	ColorBg      = lipgloss.Color("#0B0F14")
-	ColorPanelBg = lipgloss.Color("#101826")
-	ColorBorder  = lipgloss.Color("#243041")
-	ColorText    = lipgloss.Color("#E6EDF3")
-	ColorMuted   = lipgloss.Color("#9BA7B4")
-	ColorDim     = lipgloss.Color("#6B7785")
-
-	// Accent (aesthetic)
-	ColorAccent = lipgloss.Color("#F4B942") // TigerBeetle amber
-
-	// Semantic (status)
-	ColorSuccess = lipgloss.Color("#2ECC71")
-	ColorWarning = lipgloss.Color("#F39C12")
-	ColorError   = lipgloss.Color("#E74C3C")
-	ColorInfo    = lipgloss.Color("#22C1C3")
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
 )
 
-// Reusable styles
+// Reusable styles, built from the active theme's tokens by setStyles.
 var (
+	BoxStyle           lipgloss.Style
+	BoxFocusedStyle    lipgloss.Style
+	TitleStyle         lipgloss.Style
+	TextStyle          lipgloss.Style
+	MutedStyle         lipgloss.Style
+	DimStyle           lipgloss.Style
+	AccentStyle        lipgloss.Style
+	AccentBoldStyle    lipgloss.Style
+	SuccessStyle       lipgloss.Style
+	WarningStyle       lipgloss.Style
+	ErrorStyle         lipgloss.Style
+	InfoStyle          lipgloss.Style
+	StatusConnected    lipgloss.Style
+	StatusDisconnected lipgloss.Style
+	StatusConnecting   lipgloss.Style
+	ActiveTabStyle     lipgloss.Style
+	InactiveTabStyle   lipgloss.Style
+	TableHeaderStyle   lipgloss.Style
+	TableCellStyle     lipgloss.Style
+	HelpStyle          lipgloss.Style
+	InputLabelStyle    lipgloss.Style
+	LogoStyle          lipgloss.Style
+)
+
+func init() {
+	setStyles()
+}
+
+// setStyles rebuilds the reusable styles after the theme changed.
+func setStyles() {
 	// Box / panel
 	BoxStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorBorder).
-			Padding(0, 1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Border).
+		Padding(0, 1)
 
 	BoxFocusedStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorAccent).
-			Padding(0, 1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Accent).
+		Padding(0, 1)
 
 	// Title
 	TitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorAccent)
+		Bold(true).
+		Foreground(theme.Accent)
 
 	// Text
-	TextStyle  = lipgloss.NewStyle().Foreground(ColorText)
-	MutedStyle = lipgloss.NewStyle().Foreground(ColorMuted)
-	DimStyle   = lipgloss.NewStyle().Foreground(ColorDim)
+	TextStyle = lipgloss.NewStyle().Foreground(theme.Text)
+	MutedStyle = lipgloss.NewStyle().Foreground(theme.Muted)
+	DimStyle = lipgloss.NewStyle().Foreground(theme.Dim)
 
 	// Accent
-	AccentStyle     = lipgloss.NewStyle().Foreground(ColorAccent)
-	AccentBoldStyle = lipgloss.NewStyle().Foreground(ColorAccent).Bold(true)
+	AccentStyle = lipgloss.NewStyle().Foreground(theme.Accent)
+	AccentBoldStyle = lipgloss.NewStyle().Foreground(theme.Accent).Bold(true)
 
 	// Semantic
-	SuccessStyle = lipgloss.NewStyle().Foreground(ColorSuccess)
-	WarningStyle = lipgloss.NewStyle().Foreground(ColorWarning)
-	ErrorStyle   = lipgloss.NewStyle().Foreground(ColorError)
-	InfoStyle    = lipgloss.NewStyle().Foreground(ColorInfo)
+	SuccessStyle = lipgloss.NewStyle().Foreground(theme.Success)
+	WarningStyle = lipgloss.NewStyle().Foreground(theme.Warning)
+	ErrorStyle = lipgloss.NewStyle().Foreground(theme.Error)
+	InfoStyle = lipgloss.NewStyle().Foreground(theme.Info)
 
 	// Status indicators
-	StatusConnected    = lipgloss.NewStyle().Foreground(ColorSuccess).Bold(true)
-	StatusDisconnected = lipgloss.NewStyle().Foreground(ColorDim)
-	StatusConnecting   = lipgloss.NewStyle().Foreground(ColorWarning).Bold(true)
+	StatusConnected = lipgloss.NewStyle().Foreground(theme.Success).Bold(true)
+	StatusDisconnected = lipgloss.NewStyle().Foreground(theme.Dim)
+	StatusConnecting = lipgloss.NewStyle().Foreground(theme.Warning).Bold(true)
 
 	// Tabs
 	ActiveTabStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorAccent).
-			Border(lipgloss.NormalBorder(), false, false, true, false).
-			BorderForeground(ColorAccent).
-			Padding(0, 2)
+		Bold(true).
+		Foreground(theme.Accent).
+		Border(lipgloss.NormalBorder(), false, false, true, false).
+		BorderForeground(theme.Accent).
+		Padding(0, 2)
 
 	InactiveTabStyle = lipgloss.NewStyle().
-				Foreground(ColorMuted).
-				Border(lipgloss.NormalBorder(), false, false, true, false).
-				BorderForeground(ColorDim).
-				Padding(0, 2)
+		Foreground(theme.Muted).
+		Border(lipgloss.NormalBorder(), false, false, true, false).
+		BorderForeground(theme.Dim).
+		Padding(0, 2)
 
 	// Table
 	TableHeaderStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(ColorText).
-				BorderBottom(true).
-				BorderStyle(lipgloss.NormalBorder()).
-				BorderForeground(ColorBorder)
+		Bold(true).
+		Foreground(theme.Text).
+		BorderBottom(true).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(theme.Border)
 
 	TableCellStyle = lipgloss.NewStyle().Padding(0, 1)
 
 	// Help bar
-	HelpStyle = lipgloss.NewStyle().Foreground(ColorDim)
+	HelpStyle = lipgloss.NewStyle().Foreground(theme.Dim)
 
 	// Input field
-	InputLabelStyle = lipgloss.NewStyle().Foreground(ColorMuted).Width(14)
+	InputLabelStyle = lipgloss.NewStyle().Foreground(theme.Muted).Width(14)
 
 	// Logo
-	LogoStyle = lipgloss.NewStyle().Foreground(ColorAccent).Bold(true)
-)
+	LogoStyle = lipgloss.NewStyle().Foreground(theme.Accent).Bold(true)
+}
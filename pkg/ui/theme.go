@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fd1az/tiger-tui/internal/apperror"
+	"github.com/fd1az/tiger-tui/internal/config"
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
+)
+
+func init() {
+	registerCommand(paletteCommand{
+		name:    "theme",
+		args:    "<dark|light>",
+		summary: "switch the color theme",
+		complete: func(*Model, int, []string) []string {
+			return themeNames()
+		},
+		run: func(m *Model, args []string) (tea.Cmd, error) {
+			if len(args) != 1 {
+				return nil, fmt.Errorf("theme needs one of %s", strings.Join(themeNames(), ", "))
+			}
+			name, err := matchArg("theme", args[0], themeNames())
+			if err != nil {
+				return nil, err
+			}
+			t, _ := theme.ByName(name)
+			m.useTheme(t)
+			if theme.Colorless() {
+				m.statusBar.SetMessage(fmt.Sprintf("Theme %s selected; colors stay off while NO_COLOR is set", name), 2)
+			} else {
+				m.statusBar.SetMessage("Theme "+name, 0)
+			}
+			return nil, nil
+		},
+	})
+}
+
+func themeNames() []string {
+	names := make([]string, len(theme.Themes))
+	for i, t := range theme.Themes {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// ApplyTheme selects the configured theme and symbol set. An unknown theme
+// is a configuration error.
+func ApplyTheme(cfg config.ThemeConfig) error {
+	theme.UseASCII(cfg.ASCII)
+	if cfg.Name == "" {
+		return nil
+	}
+	t, ok := theme.ByName(cfg.Name)
+	if !ok {
+		return apperror.New(apperror.CodeConfigurationError,
+			apperror.WithMessage(fmt.Sprintf("unknown theme %q, want one of %s", cfg.Name, strings.Join(themeNames(), ", "))),
+			apperror.WithContext("theme.name"))
+	}
+	theme.Use(t)
+	setStyles()
+	return nil
+}
+
+// useTheme switches the theme at runtime, recoloring what keeps styles
+// between frames.
+func (m *Model) useTheme(t theme.Theme) {
+	theme.Use(t)
+	setStyles()
+	m.connForm.Restyle()
+}
@@ -0,0 +1,47 @@
+package theme
+
+import "strings"
+
+// asciiSymbols maps every non-ASCII glyph the TUI draws to an ASCII one of
+// the same width, so layouts stay put: status dots, box drawing, sort
+// arrows, pickers, sparklines and the logo.
+var asciiSymbols = strings.NewReplacer(
+	// Status and markers
+	"●", "*", "○", "o", "·", "-", "›", ">", "…", ".",
+	"▲", "^", "▼", "v", "◀", "<", "▶", ">",
+	"↑", "^", "↓", "v", "←", "<", "→", ">",
+	"✓", "+", "✗", "x", "—", "-",
+
+	// Borders and rules
+	"─", "-", "│", "|", "╭", "+", "╮", "+", "╰", "+", "╯", "+",
+	"┌", "+", "┐", "+", "└", "+", "┘", "+", "├", "+", "┤", "+",
+	"┬", "+", "┴", "+", "┼", "+",
+
+	// Sparkline levels
+	"▁", "_", "▂", ".", "▃", ":", "▄", "-", "▅", "=", "▆", "+", "▇", "*",
+
+	// Logo
+	"█", "#", "═", "=", "║", "|", "╔", "+", "╗", "+", "╚", "+", "╝", "+",
+)
+
+// ascii is set when the ASCII-only symbol set is in use.
+var ascii bool
+
+// UseASCII switches to the ASCII-only symbol set, or back to Unicode.
+func UseASCII(on bool) {
+	ascii = on
+}
+
+// ASCII reports whether the ASCII-only symbol set is in use.
+func ASCII() bool {
+	return ascii
+}
+
+// Symbols applies the symbol set to a rendered frame: with ASCII on, every
+// glyph of the Unicode set becomes its ASCII counterpart.
+func Symbols(frame string) string {
+	if !ascii {
+		return frame
+	}
+	return asciiSymbols.Replace(frame)
+}
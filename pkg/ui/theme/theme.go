@@ -0,0 +1,136 @@
+// Package theme holds the TUI's color tokens and symbols: the Mainframe
+// Modern dark and light themes, colors off when NO_COLOR is set, and an
+// ASCII-only symbol set for constrained terminals.
+package theme
+
+import (
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme is a set of color tokens.
+type Theme struct {
+	Name    string
+	Bg      lipgloss.TerminalColor
+	PanelBg lipgloss.TerminalColor
+	Border  lipgloss.TerminalColor
+	Text    lipgloss.TerminalColor
+	Muted   lipgloss.TerminalColor
+	Dim     lipgloss.TerminalColor
+
+	// Accent (aesthetic): focus ring, selected row, primary highlight
+	Accent lipgloss.TerminalColor
+
+	// Semantic (status)
+	Success lipgloss.TerminalColor
+	Warning lipgloss.TerminalColor
+	Error   lipgloss.TerminalColor
+	Info    lipgloss.TerminalColor
+}
+
+// Mainframe Modern — dark
+var Dark = Theme{
+	Name:    "dark",
+	Bg:      lipgloss.Color("#0B0F14"),
+	PanelBg: lipgloss.Color("#101826"),
+	Border:  lipgloss.Color("#243041"),
+	Text:    lipgloss.Color("#E6EDF3"),
+	Muted:   lipgloss.Color("#9BA7B4"),
+	Dim:     lipgloss.Color("#6B7785"),
+	Accent:  lipgloss.Color("#F4B942"), // TigerBeetle amber
+	Success: lipgloss.Color("#2ECC71"),
+	Warning: lipgloss.Color("#F39C12"),
+	Error:   lipgloss.Color("#E74C3C"),
+	Info:    lipgloss.Color("#22C1C3"),
+}
+
+// Mainframe Modern — light
+var Light = Theme{
+	Name:    "light",
+	Bg:      lipgloss.Color("#F7F7F8"),
+	PanelBg: lipgloss.Color("#FFFFFF"),
+	Border:  lipgloss.Color("#E5E7EB"),
+	Text:    lipgloss.Color("#111827"),
+	Muted:   lipgloss.Color("#4B5563"),
+	Dim:     lipgloss.Color("#6B7280"),
+	Accent:  lipgloss.Color("#B45309"), // darker amber for contrast
+	Success: lipgloss.Color("#15803D"),
+	Warning: lipgloss.Color("#B45309"),
+	Error:   lipgloss.Color("#B91C1C"),
+	Info:    lipgloss.Color("#0E7490"),
+}
+
+// Themes lists the themes in the order commands offer them.
+var Themes = []Theme{Dark, Light}
+
+// ByName returns the theme called name.
+func ByName(name string) (Theme, bool) {
+	for _, t := range Themes {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Theme{}, false
+}
+
+// The active theme's tokens. Use replaces them, so read them while
+// rendering rather than into package-level styles.
+var (
+	Bg      lipgloss.TerminalColor
+	PanelBg lipgloss.TerminalColor
+	Border  lipgloss.TerminalColor
+	Text    lipgloss.TerminalColor
+	Muted   lipgloss.TerminalColor
+	Dim     lipgloss.TerminalColor
+	Accent  lipgloss.TerminalColor
+	Success lipgloss.TerminalColor
+	Warning lipgloss.TerminalColor
+	Error   lipgloss.TerminalColor
+	Info    lipgloss.TerminalColor
+)
+
+var (
+	current Theme
+	// noColor is set by the NO_COLOR convention (https://no-color.org).
+	noColor = os.Getenv("NO_COLOR") != ""
+)
+
+func init() {
+	Use(Dark)
+}
+
+// Use makes t the active theme. With NO_COLOR set every token stays
+// colorless and only the theme's name is kept.
+func Use(t Theme) {
+	current = t
+	if noColor {
+		none := lipgloss.NoColor{}
+		t = Theme{Name: t.Name, Bg: none, PanelBg: none, Border: none, Text: none, Muted: none, Dim: none,
+			Accent: none, Success: none, Warning: none, Error: none, Info: none}
+	}
+	Bg, PanelBg, Border = t.Bg, t.PanelBg, t.Border
+	Text, Muted, Dim = t.Text, t.Muted, t.Dim
+	Accent = t.Accent
+	Success, Warning, Error, Info = t.Success, t.Warning, t.Error, t.Info
+}
+
+// Current returns the active theme.
+func Current() Theme {
+	return current
+}
+
+// Colorless reports whether NO_COLOR turned colors off.
+func Colorless() bool {
+	return noColor
+}
+
+// Highlight returns the style of highlighted text such as the selected row:
+// the background color bg under the theme's background color, or reverse
+// video when colors are off.
+func Highlight(bg lipgloss.TerminalColor) lipgloss.Style {
+	if noColor {
+		return lipgloss.NewStyle().Reverse(true)
+	}
+	return lipgloss.NewStyle().Foreground(Bg).Background(bg)
+}
@@ -2,50 +2,224 @@ package ui
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
 
+	accountsapp "github.com/fd1az/tiger-tui/business/accounts/app"
+	"github.com/fd1az/tiger-tui/business/accounts/domain"
+	accountsinfra "github.com/fd1az/tiger-tui/business/accounts/infra"
+	balancesheetapp "github.com/fd1az/tiger-tui/business/balancesheet/app"
 	"github.com/fd1az/tiger-tui/business/connection/infra"
+	exportapp "github.com/fd1az/tiger-tui/business/export/app"
+	filterdomain "github.com/fd1az/tiger-tui/business/filter/domain"
+	lookupapp "github.com/fd1az/tiger-tui/business/lookup/app"
+	outboxapp "github.com/fd1az/tiger-tui/business/outbox/app"
+	outboxinfra "github.com/fd1az/tiger-tui/business/outbox/infra"
+	transfersapp "github.com/fd1az/tiger-tui/business/transfers/app"
+	transfersdomain "github.com/fd1az/tiger-tui/business/transfers/domain"
+	transfersinfra "github.com/fd1az/tiger-tui/business/transfers/infra"
+	"github.com/fd1az/tiger-tui/internal/config"
+	"github.com/fd1az/tiger-tui/internal/state"
 	"github.com/fd1az/tiger-tui/pkg/ui/components"
+	"github.com/fd1az/tiger-tui/pkg/ui/theme"
 )
 
 // Model is the main Bubble Tea model for tiger-tui.
 type Model struct {
 	// Components
-	connForm  components.ConnectionForm
-	dashboard components.Dashboard
-	statusBar components.StatusBar
+	connForm       components.ConnectionForm
+	dashboard      components.Dashboard
+	statusBar      components.StatusBar
+	createAccount  components.CreateAccountForm
+	createTransfer components.CreateTransferForm
+	importForm     components.ImportForm
+	exportForm     components.ExportForm
+	postPartial    components.PostPartialForm
+	lookupPrompt   components.LookupPrompt
+	lookupDetail   components.LookupDetail
+	commandBar     components.CommandBar
+	searchBar      components.SearchBar
+	searchOrigin   int // cursor row when the search line opened
+	filterForm     components.FilterForm
+	filterDraft    []filterdomain.Chip    // chips being edited in the filter builder
+	filters        [2][]filterdomain.Chip // applied chips of the Accounts and Transfers tabs
+	columnChooser  components.ColumnChooser
+	layouts        map[string]components.Layout // saved table layouts by tab view name
+	layoutFile     state.File
+	help           components.HelpView
 
 	// Connection
-	tbClient *infra.Client
+	tbClient    *infra.Client
+	outboxStore *outboxinfra.FileStore
+	profiles    map[string]config.ProfileConfig // saved connection profiles by name
+	chartFile   string                          // chart of accounts without a profile's
+	profile     components.ConnectionProfile    // profile of the connection, if any
+	startup     tea.Cmd                         // connects with the --profile profile
+
+	// Services (available while connected)
+	accounts     *accountsapp.Service
+	transfers    *transfersapp.Service
+	balanceSheet *balancesheetapp.Service
+	lookup       *lookupapp.Service
+	outbox       *outboxapp.Service
+
+	// Background export, if one is running
+	export *exportapp.Job
 
 	// State
-	screen    Screen
+	overlay    Overlay
+	screen     Screen
 	connStatus ConnectionStatus
-	keys      KeyMap
-	width     int
-	height    int
-	ready     bool
-	quitting  bool
+	keys       KeyMap
+	width      int
+	height     int
+	ready      bool
+	quitting   bool
+	ticking    bool   // pending countdown tick loop is running
+	count      int    // count typed before a motion, with Counts on
+	keySeq     string // keys of an unfinished sequence such as the g of gg
 }
 
 // New creates a new TUI model.
-func New() Model {
-	return Model{
-		connForm:  components.NewConnectionForm(),
-		dashboard: components.NewDashboard(),
-		statusBar: components.NewStatusBar(),
-		screen:    ScreenConnection,
-		keys:      DefaultKeyMap(),
+func New(cfg *config.Config) Model {
+	m := Model{
+		connForm:    components.NewConnectionForm(connectionProfiles(cfg)),
+		dashboard:   components.NewDashboard(),
+		statusBar:   components.NewStatusBar(),
+		outboxStore: outboxinfra.NewFileStore(cfg.OutboxDir()),
+		layoutFile:  state.New(cfg.App.StateDir, layoutFile),
+		profiles:    cfg.Profiles,
+		chartFile:   cfg.Chart.File,
+		screen:      ScreenConnection,
+	}
+	if err := ApplyTheme(cfg.Theme); err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Theme not applied: %s", err), 2)
+	}
+	keys, err := LoadKeyMap(cfg.Keys)
+	if err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Key bindings not applied: %s", err), 2)
+		keys = DefaultKeyMap()
+	}
+	m.keys = keys
+	m.statusBar.SetHints(keys.statusHints())
+	layouts, err := loadLayouts(m.layoutFile)
+	if err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Table layouts not restored: %s", err), 2)
 	}
+	m.layouts = layouts
+	m.restoreLayouts()
+	if cfg.Profile != "" && m.connForm.SelectProfile(cfg.Profile) {
+		m.startup = m.connect()
+	}
+	return m
+}
+
+// tabViews names the dashboard tabs in order for commands, matching export
+// view names.
+var tabViews = []string{"accounts", "transfers", "balance-sheet", "pending"}
+
+func init() {
+	registerCommand(paletteCommand{
+		name:    "goto",
+		args:    "<tab> [account]",
+		summary: "switch tab; transfers takes an account to scope to",
+		complete: func(m *Model, n int, args []string) []string {
+			switch {
+			case n == 0:
+				return tabViews
+			case n == 1 && args[0] == "transfers":
+				return m.selectedIDs()
+			}
+			return nil
+		},
+		run: func(m *Model, args []string) (tea.Cmd, error) {
+			if len(args) == 0 {
+				return nil, fmt.Errorf("goto needs a tab: %s", strings.Join(tabViews, ", "))
+			}
+			view, err := matchArg("tab", args[0], tabViews)
+			if err != nil {
+				return nil, err
+			}
+			tab := slices.Index(tabViews, view)
+			m.dashboard.SetTab(tab)
+			if len(args) < 2 {
+				return nil, nil
+			}
+			if tab != 1 {
+				return nil, fmt.Errorf("only transfers can be scoped to an account")
+			}
+			id, err := domain.ParseID(args[1])
+			if err != nil {
+				return nil, err
+			}
+			m.dashboard.CloseHistory()
+			m.dashboard.Transfers().SetScope(id)
+			return m.reloadTransfers(), nil
+		},
+	})
+	registerCommand(paletteCommand{
+		name:    "refresh",
+		summary: "reload the active tab",
+		run: func(m *Model, _ []string) (tea.Cmd, error) {
+			return m.refresh(), nil
+		},
+	})
+	registerCommand(paletteCommand{
+		name:    "create",
+		args:    "<accounts|transfers>",
+		summary: "open the Create Account or Create Transfer form",
+		complete: func(_ *Model, n int, _ []string) []string {
+			if n > 0 {
+				return nil
+			}
+			return []string{"accounts", "transfers"}
+		},
+		run: func(m *Model, args []string) (tea.Cmd, error) {
+			if m.accounts == nil {
+				return nil, errNotConnected
+			}
+			if err := m.writable(); err != nil {
+				return nil, err
+			}
+			if len(args) == 0 {
+				return nil, fmt.Errorf("create needs accounts or transfers")
+			}
+			what, err := matchArg("form", args[0], []string{"accounts", "transfers"})
+			if err != nil {
+				return nil, err
+			}
+			m.openCreate(what)
+			return nil, nil
+		},
+	})
+	registerCommand(paletteCommand{
+		name:    "disconnect",
+		summary: "close the connection and return to the connection screen",
+		run: func(m *Model, _ []string) (tea.Cmd, error) {
+			m.disconnect()
+			return nil, nil
+		},
+	})
+	registerCommand(paletteCommand{
+		name:    "quit",
+		summary: "quit tiger-tui",
+		run: func(m *Model, _ []string) (tea.Cmd, error) {
+			m.quitting = true
+			return tea.Quit, nil
+		},
+	})
 }
 
 // Init initializes the TUI model.
 func (m Model) Init() tea.Cmd {
-	return textinputBlink()
+	return tea.Batch(textinputBlink(), m.startup)
 }
 
 // textinputBlink returns a command that starts the textinput cursor blinking.
@@ -63,15 +237,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.connForm.SetWidth(msg.Width)
 		m.dashboard.SetSize(msg.Width, msg.Height)
 		m.statusBar.SetWidth(msg.Width)
+		m.help.SetHeight(msg.Height - 4)
 		return m, nil
 
 	case tea.KeyMsg:
 		// Global: always allow quit
-		if key.Matches(msg, m.keys.Quit) {
+		if key.Matches(msg, m.keys.ForceQuit) {
 			m.quitting = true
 			return m, tea.Quit
 		}
 
+		// Modals capture all keys while open
+		if m.overlay != OverlayNone {
+			return m.updateOverlay(msg)
+		}
+
 		// Route to screen-specific handler
 		switch m.screen {
 		case ScreenConnection:
@@ -87,16 +267,281 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.screen = ScreenDashboard
 		m.connForm.SetStatus(2)
 		m.statusBar.SetConnection(2, m.connForm.ClusterID(), m.connForm.Address())
+		m.statusBar.SetProfile(m.profile)
 		m.statusBar.SetMessage("Connected to TigerBeetle", 1)
-		return m, nil
+		// The swap is atomic; commands still in flight keep the chart they read.
+		domain.UseChart(msg.Chart)
+		accountsRepo := accountsinfra.NewRepository(msg.Client.Raw())
+		transfersRepo := transfersinfra.NewRepository(msg.Client.Raw())
+		var (
+			accountsPort  accountsapp.Repository  = accountsRepo
+			transfersPort transfersapp.Repository = transfersRepo
+			resubmit      tea.Cmd
+		)
+		ob, err := outboxapp.NewService(m.outboxStore, msg.Client.ClusterID(), accountsRepo, transfersRepo)
+		if err != nil {
+			m.statusBar.SetMessage(fmt.Sprintf("Outbox unavailable, writes are not journaled: %s", err), 2)
+		} else {
+			m.outbox = ob
+			accountsPort = ob.JournalAccounts(accountsRepo)
+			transfersPort = ob.JournalTransfers(transfersRepo)
+			switch n := ob.Pending(); {
+			case n > 0 && m.profile.ReadOnly:
+				m.statusBar.SetMessage(fmt.Sprintf("Read-only profile: %d pending write(s) left in the outbox", n), 2)
+			case n > 0:
+				m.statusBar.SetMessage(fmt.Sprintf("Resubmitting %d pending write(s)...", n), 2)
+				resubmit = ResubmitOutboxCmd(ob)
+			}
+		}
+		m.statusBar.SetPendingWrites(m.pendingWrites())
+		m.accounts = accountsapp.NewService(accountsPort)
+		m.transfers = transfersapp.NewService(transfersPort)
+		m.balanceSheet = balancesheetapp.NewService(m.accounts)
+		m.lookup = lookupapp.NewService(m.accounts, m.transfers)
+		cmds := []tea.Cmd{resubmit, m.reloadAccounts(), m.reloadTransfers(), m.reloadBalanceSheet(), m.reloadHolds()}
+		if !m.ticking {
+			m.ticking = true
+			cmds = append(cmds, PendingTickCmd())
+		}
+		return m, tea.Batch(cmds...)
 
 	case ConnectionFailedMsg:
 		m.connStatus = Disconnected
+		m.profile = components.ConnectionProfile{}
 		m.connForm.SetStatus(0)
 		m.connForm.SetError(msg.Err.Error())
 		m.statusBar.SetMessage(fmt.Sprintf("Connection failed: %s", msg.Err), 3)
 		return m, nil
 
+	case AccountsLoadedMsg:
+		m.dashboard.Accounts().SetPage(msg.Page, msg.Append)
+		// Client-side filters may leave a page short; keep scanning.
+		return m, m.loadMore()
+
+	case AccountsLoadFailedMsg:
+		// Keep the last loaded snapshot; only report the failure.
+		m.dashboard.Accounts().SetLoading(false)
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to load accounts: %s", msg.Err), 3)
+		return m, nil
+
+	case AccountHistoryLoadedMsg:
+		if msg.AccountID == m.dashboard.History().AccountID() {
+			m.dashboard.History().SetBalances(msg.Balances)
+		}
+		return m, nil
+
+	case AccountHistoryLoadFailedMsg:
+		if msg.AccountID == m.dashboard.History().AccountID() {
+			m.dashboard.History().SetError(msg.Err.Error())
+		}
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to load balance history: %s", msg.Err), 3)
+		return m, nil
+
+	case AccountsCreatedMsg:
+		m.statusBar.SetPendingWrites(m.pendingWrites())
+		m.createAccount.SetResults(msg.Outcomes)
+		failed := 0
+		for _, o := range msg.Outcomes {
+			if !o.OK() {
+				failed++
+			}
+		}
+		if failed > 0 {
+			m.statusBar.SetMessage(fmt.Sprintf("%d of %d accounts failed", failed, len(msg.Outcomes)), 3)
+			if failed == len(msg.Outcomes) {
+				return m, nil
+			}
+		} else {
+			m.overlay = OverlayNone
+			m.statusBar.SetMessage(fmt.Sprintf("Created %d account(s)", len(msg.Outcomes)), 1)
+		}
+		return m, tea.Batch(m.reloadAccounts(), m.reloadBalanceSheet())
+
+	case TransfersCreatedMsg:
+		m.statusBar.SetPendingWrites(m.pendingWrites())
+		m.createTransfer.SetResults(msg.Outcomes)
+		failed := 0
+		for _, o := range msg.Outcomes {
+			if !o.OK() {
+				failed++
+			}
+		}
+		if failed > 0 {
+			m.statusBar.SetMessage(fmt.Sprintf("%d of %d transfers failed", failed, len(msg.Outcomes)), 3)
+			if failed == len(msg.Outcomes) {
+				return m, nil
+			}
+		} else {
+			m.overlay = OverlayNone
+			m.statusBar.SetMessage(fmt.Sprintf("Created %d transfer(s)", len(msg.Outcomes)), 1)
+		}
+		return m, tea.Batch(m.reloadTransfers(), m.reloadAccounts(), m.reloadBalanceSheet(), m.reloadHolds())
+
+	case TransfersCreateFailedMsg:
+		m.statusBar.SetPendingWrites(m.pendingWrites())
+		m.createTransfer.SetError(msg.Err.Error())
+		m.statusBar.SetMessage(fmt.Sprintf("Create transfers failed: %s", msg.Err), 3)
+		return m, nil
+
+	case AccountsCreateFailedMsg:
+		m.statusBar.SetPendingWrites(m.pendingWrites())
+		m.createAccount.SetError(msg.Err.Error())
+		m.statusBar.SetMessage(fmt.Sprintf("Create accounts failed: %s", msg.Err), 3)
+		return m, nil
+
+	case TransfersImportedMsg:
+		m.statusBar.SetPendingWrites(m.pendingWrites())
+		r := msg.Report
+		m.importForm.SetReport(r, msg.ResultsPath)
+		level := 1
+		if r.Failures() > 0 {
+			level = 2
+		}
+		m.statusBar.SetMessage("Import: "+r.Summary(), level)
+		if r.Created == 0 {
+			return m, nil
+		}
+		return m, tea.Batch(m.reloadTransfers(), m.reloadAccounts(), m.reloadBalanceSheet(), m.reloadHolds())
+
+	case TransfersImportFailedMsg:
+		m.statusBar.SetPendingWrites(m.pendingWrites())
+		if len(msg.Report.Results) > 0 {
+			m.importForm.SetReport(msg.Report, msg.ResultsPath)
+		}
+		m.importForm.SetError(msg.Err.Error())
+		m.statusBar.SetMessage(fmt.Sprintf("Import failed: %s", msg.Err), 3)
+		if msg.Report.Created == 0 {
+			return m, nil
+		}
+		return m, tea.Batch(m.reloadTransfers(), m.reloadAccounts(), m.reloadBalanceSheet(), m.reloadHolds())
+
+	case ExportProgressMsg:
+		if msg.Job != m.export {
+			// Canceled: let the next step discard the partial file.
+			return m, ExportStepCmd(msg.Job)
+		}
+		m.statusBar.SetTask(fmt.Sprintf("Exporting %s: %s rows", msg.Job.View, groupCount(msg.Rows)))
+		return m, ExportStepCmd(msg.Job)
+
+	case ExportDoneMsg:
+		if msg.Job != m.export {
+			return m, nil
+		}
+		m.export = nil
+		m.statusBar.SetTask("")
+		m.statusBar.SetMessage(fmt.Sprintf("Exported %s rows of %s to %s", groupCount(msg.Rows), msg.Job.View, msg.Job.Path), 1)
+		return m, nil
+
+	case ExportFailedMsg:
+		if msg.Job != m.export {
+			return m, nil
+		}
+		m.export = nil
+		m.statusBar.SetTask("")
+		m.statusBar.SetMessage(fmt.Sprintf("Export failed: %s", msg.Err), 3)
+		return m, nil
+
+	case TransfersLoadedMsg:
+		// Drop pages requested for a scope that is no longer shown
+		if msg.Page.Next.AccountID != m.dashboard.Transfers().Scope() {
+			return m, nil
+		}
+		m.dashboard.Transfers().SetPage(msg.Page, msg.Append)
+		// Client-side filters may leave a page short; keep scanning.
+		return m, m.loadMore()
+
+	case TransfersLoadFailedMsg:
+		m.dashboard.Transfers().SetLoading(false)
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to load transfers: %s", msg.Err), 3)
+		return m, nil
+
+	case BalanceSheetLoadedMsg:
+		m.dashboard.BalanceSheet().SetSummary(msg.Summary)
+		if n := msg.Summary.Unbalanced(); n > 0 {
+			m.statusBar.SetMessage(fmt.Sprintf("Trial balance: %d ledger(s) unbalanced", n), 3)
+		}
+		return m, nil
+
+	case BalanceSheetLoadFailedMsg:
+		m.dashboard.BalanceSheet().SetLoading(false)
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to load balance sheet: %s", msg.Err), 3)
+		return m, nil
+
+	case HoldsLoadedMsg:
+		m.dashboard.Pending().SetHolds(msg.Holds, time.Now())
+		return m, nil
+
+	case HoldsLoadFailedMsg:
+		m.dashboard.Pending().SetLoading(false)
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to load pending transfers: %s", msg.Err), 3)
+		return m, nil
+
+	case PendingResolvedMsg:
+		m.statusBar.SetPendingWrites(m.pendingWrites())
+		o := msg.Outcome
+		action, done := "post", "Posted"
+		if o.Transfer.TransferFlags().VoidPendingTransfer {
+			action, done = "void", "Voided"
+		}
+		pendingID := domain.FormatID(o.Transfer.PendingID)
+		if !o.OK() {
+			result := transfersdomain.TransferResultName(o.Result)
+			m.postPartial.SetError(result)
+			m.statusBar.SetMessage(fmt.Sprintf("Could not %s pending transfer %s: %s", action, pendingID, result), 3)
+			return m, nil
+		}
+		if m.overlay == OverlayPostPartial {
+			m.overlay = OverlayNone
+		}
+		m.dashboard.Pending().Remove(o.Transfer.PendingID)
+		m.statusBar.SetMessage(fmt.Sprintf("%s pending transfer %s", done, pendingID), 1)
+		return m, tea.Batch(m.reloadTransfers(), m.reloadAccounts(), m.reloadBalanceSheet(), m.reloadHolds())
+
+	case PendingResolveFailedMsg:
+		m.statusBar.SetPendingWrites(m.pendingWrites())
+		m.postPartial.SetError(msg.Err.Error())
+		m.statusBar.SetMessage(fmt.Sprintf("Resolve pending transfer failed: %s", msg.Err), 3)
+		return m, nil
+
+	case PendingTickMsg:
+		if m.transfers == nil {
+			m.ticking = false
+			return m, nil
+		}
+		m.dashboard.Pending().Tick(time.Time(msg))
+		m.statusBar.SetPendingWrites(m.pendingWrites())
+		return m, PendingTickCmd()
+
+	case OutboxResubmittedMsg:
+		r := msg.Report
+		m.statusBar.SetPendingWrites(m.pendingWrites())
+		level := 1
+		if r.Failed > 0 {
+			level = 3
+		}
+		m.statusBar.SetMessage(fmt.Sprintf("Resubmitted %d pending write(s): %d created, %d already existed, %d failed",
+			r.Entries, r.Created, r.Existed, r.Failed), level)
+		return m, tea.Batch(m.reloadAccounts(), m.reloadTransfers(), m.reloadBalanceSheet(), m.reloadHolds())
+
+	case OutboxResubmitFailedMsg:
+		m.statusBar.SetPendingWrites(m.pendingWrites())
+		m.statusBar.SetMessage(fmt.Sprintf("Resubmitting pending writes failed after %d: %s", msg.Report.Entries, msg.Err), 3)
+		return m, nil
+
+	case LookupDoneMsg:
+		if m.overlay != OverlayLookup {
+			return m, nil
+		}
+		m.lookupDetail = components.NewLookupDetail(msg.Result)
+		m.overlay = OverlayLookupResult
+		return m, nil
+
+	case LookupFailedMsg:
+		text := strings.ReplaceAll(msg.Err.Error(), "\n", "; ")
+		m.lookupPrompt.SetError(text)
+		m.statusBar.SetMessage(fmt.Sprintf("Lookup failed: %s", text), 3)
+		return m, nil
+
 	case ErrorMsg:
 		m.statusBar.SetMessage(msg.Err.Error(), 3)
 		return m, nil
@@ -121,34 +566,29 @@ func (m Model) updateConnection(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case key.Matches(msg, m.keys.Enter):
-		if m.connForm.IsButtonFocused() {
-			// Validate and submit
-			if m.connForm.ClusterID() == "" {
-				m.connForm.SetError("Cluster ID is required")
-				return m, nil
-			}
-			if m.connForm.Address() == "" {
-				m.connForm.SetError("Address is required")
-				return m, nil
-			}
-
-			m.connStatus = Connecting
-			m.connForm.SetStatus(1)
-			m.connForm.SetError("")
-			m.statusBar.SetMessage("Connecting...", 0)
-
-			return m, ConnectCmd(m.connForm.ClusterID(), m.connForm.Address())
+		if m.connForm.IsButtonFocused() || m.connForm.IsPickerFocused() {
+			return m, m.connect()
 		}
 		// Enter on text fields moves to next
 		m.connForm.FocusNext()
 		return m, nil
 
-	case msg.String() == "q":
-		// On connection screen, q in a text field types 'q'; on button it quits
-		if m.connForm.IsButtonFocused() {
-			m.quitting = true
-			return m, tea.Quit
-		}
+	case key.Matches(msg, m.keys.Up) && m.connForm.IsPickerFocused():
+		m.connForm.PickPrev()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Down) && m.connForm.IsPickerFocused():
+		m.connForm.PickNext()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Help):
+		m.openHelp("")
+		return m, nil
+
+	case key.Matches(msg, m.keys.Quit) && (m.connForm.IsButtonFocused() || m.connForm.IsPickerFocused()):
+		// In a text field the key is typed instead
+		m.quitting = true
+		return m, tea.Quit
 	}
 
 	// Forward to text inputs
@@ -158,6 +598,14 @@ func (m Model) updateConnection(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // updateDashboard handles keys on the dashboard screen.
 func (m Model) updateDashboard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	msg, count, ok := m.readKey(msg)
+	if !ok {
+		return m, nil
+	}
+	if m.dashboard.DetailsFocused() {
+		return m.updateDetails(msg, count)
+	}
+
 	switch {
 	case key.Matches(msg, m.keys.Tab):
 		m.dashboard.NextTab()
@@ -167,19 +615,170 @@ func (m Model) updateDashboard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.dashboard.PrevTab()
 		return m, nil
 
-	case key.Matches(msg, m.keys.Escape):
-		// Close TB client and return to connection screen
-		if m.tbClient != nil {
-			m.tbClient.Close()
-			m.tbClient = nil
+	case key.Matches(msg, m.keys.Up):
+		m.dashboard.MoveUp(max(count, 1))
+		return m, nil
+
+	case key.Matches(msg, m.keys.Down):
+		m.dashboard.MoveDown(max(count, 1))
+		return m, m.loadMore()
+
+	case key.Matches(msg, m.keys.HalfPageUp):
+		m.dashboard.MoveUp(max(count, 1) * m.dashboard.PageRows())
+		return m, nil
+
+	case key.Matches(msg, m.keys.HalfPageDown):
+		m.dashboard.MoveDown(max(count, 1) * m.dashboard.PageRows())
+		return m, m.loadMore()
+
+	case key.Matches(msg, m.keys.Top, m.keys.Bottom) && count > 0:
+		// With a count both go to that row, as in vim
+		m.dashboard.MoveFirst()
+		m.dashboard.MoveDown(count - 1)
+		return m, m.loadMore()
+
+	case key.Matches(msg, m.keys.Top):
+		m.dashboard.MoveFirst()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Bottom):
+		m.dashboard.MoveLast()
+		return m, m.loadMore()
+
+	case key.Matches(msg, m.keys.Refresh):
+		return m, m.refresh()
+
+	case key.Matches(msg, m.keys.Details):
+		m.toggleDetails()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Right) && m.dashboard.DetailsOpen():
+		m.dashboard.FocusDetails(true)
+		return m, nil
+
+	case key.Matches(msg, m.keys.History) && m.dashboard.ActiveTab() == 0 && !m.dashboard.HistoryOpen():
+		acc, ok := m.dashboard.Accounts().Selected()
+		if !ok {
+			return m, nil
+		}
+		m.dashboard.OpenHistory(acc)
+		return m, m.reloadHistory()
+
+	case key.Matches(msg, m.keys.Create, m.keys.Import, m.keys.Post, m.keys.PostPartial, m.keys.Void) && m.writable() != nil:
+		m.statusBar.SetMessage(m.writable().Error(), 2)
+		return m, nil
+
+	case key.Matches(msg, m.keys.Create) && m.dashboard.ActiveTab() == 0 && m.accounts != nil:
+		m.openCreate("accounts")
+		return m, nil
+
+	case key.Matches(msg, m.keys.Create) && m.dashboard.ActiveTab() == 1 && m.transfers != nil:
+		m.openCreate("transfers")
+		return m, nil
+
+	case key.Matches(msg, m.keys.Import) && m.dashboard.ActiveTab() == 1 && m.transfers != nil:
+		m.openImport()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Export):
+		if err := m.openExport(); err != nil {
+			m.statusBar.SetMessage(err.Error(), 2)
 		}
-		m.screen = ScreenConnection
-		m.connStatus = Disconnected
-		m.connForm.SetStatus(0)
-		m.statusBar.SetConnection(0, "", "")
 		return m, nil
 
-	case msg.String() == "q":
+	case key.Matches(msg, m.keys.Search):
+		m.openSearch()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Lookup):
+		if m.lookup == nil {
+			m.statusBar.SetMessage(errNotConnected.Error(), 2)
+			return m, nil
+		}
+		m.openLookup()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Filter):
+		m.openFilter()
+		return m, nil
+
+	case key.Matches(msg, m.keys.NextMatch, m.keys.PrevMatch) && m.searchActive():
+		t, _ := m.dashboard.SearchTable()
+		t.SearchNext(key.Matches(msg, m.keys.PrevMatch))
+		return m, m.loadMore()
+
+	case key.Matches(msg, m.keys.Command):
+		m.openCommandBar()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Sort, m.keys.ReverseSort):
+		return m, m.cycleSort(key.Matches(msg, m.keys.ReverseSort))
+
+	case key.Matches(msg, m.keys.Columns):
+		m.openColumns()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Help):
+		m.openHelp("")
+		return m, nil
+
+	case key.Matches(msg, m.keys.Post) && m.dashboard.ActiveTab() == 3 && m.transfers != nil:
+		hold, ok := m.dashboard.Pending().Selected()
+		if !ok {
+			return m, nil
+		}
+		return m, m.resolveHold(hold, transfersdomain.PostPending(hold.Transfer, hold.Transfer.Amount))
+
+	case key.Matches(msg, m.keys.PostPartial) && m.dashboard.ActiveTab() == 3 && m.transfers != nil:
+		hold, ok := m.dashboard.Pending().Selected()
+		if !ok {
+			return m, nil
+		}
+		m.postPartial = components.NewPostPartialForm(hold)
+		m.overlay = OverlayPostPartial
+		return m, nil
+
+	case key.Matches(msg, m.keys.Void) && m.dashboard.ActiveTab() == 3 && m.transfers != nil:
+		hold, ok := m.dashboard.Pending().Selected()
+		if !ok {
+			return m, nil
+		}
+		return m, m.resolveHold(hold, transfersdomain.VoidPending(hold.Transfer))
+
+	case key.Matches(msg, m.keys.Escape) && m.searchActive():
+		t, _ := m.dashboard.SearchTable()
+		t.ClearSearch()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Escape) && m.dashboard.DetailsOpen():
+		m.dashboard.ToggleDetails()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Escape) && m.dashboard.HistoryOpen():
+		m.dashboard.CloseHistory()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Enter):
+		// Drill down from an account into its transfers
+		if m.dashboard.ActiveTab() == 0 && !m.dashboard.HistoryOpen() {
+			if acc, ok := m.dashboard.Accounts().Selected(); ok {
+				m.dashboard.Transfers().SetScope(acc.ID)
+				m.dashboard.SetTab(1)
+				return m, m.reloadTransfers()
+			}
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Escape) && m.dashboard.ActiveTab() == 1 && m.dashboard.Transfers().Scoped():
+		// Leave account scope before leaving the dashboard
+		m.dashboard.Transfers().SetScope(types.Uint128{})
+		return m, m.reloadTransfers()
+
+	case key.Matches(msg, m.keys.Escape):
+		m.disconnect()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Quit):
 		m.quitting = true
 		return m, tea.Quit
 	}
@@ -187,6 +786,292 @@ func (m Model) updateDashboard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateOverlay handles keys while a modal is open.
+func (m Model) updateOverlay(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, m.keys.Escape) && m.overlay != OverlaySearch {
+		m.overlay = OverlayNone
+		return m, nil
+	}
+
+	switch m.overlay {
+	case OverlayCreateAccount:
+		return m.updateCreateAccount(msg)
+	case OverlayCreateTransfer:
+		return m.updateCreateTransfer(msg)
+	case OverlayImport:
+		return m.updateImport(msg)
+	case OverlayExport:
+		return m.updateExport(msg)
+	case OverlayPostPartial:
+		return m.updatePostPartial(msg)
+	case OverlayLookup:
+		return m.updateLookup(msg)
+	case OverlayLookupResult:
+		return m.updateLookupResult(msg)
+	case OverlayCommand:
+		return m.updateCommandBar(msg)
+	case OverlaySearch:
+		return m.updateSearch(msg)
+	case OverlayFilter:
+		return m.updateFilter(msg)
+	case OverlayColumns:
+		return m.updateColumns(msg)
+	case OverlayHelp:
+		return m.updateHelp(msg)
+	}
+	return m, nil
+}
+
+// updateCreateAccount handles keys in the Create Account modal.
+func (m Model) updateCreateAccount(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	f := &m.createAccount
+	switch {
+	case key.Matches(msg, m.keys.Tab):
+		f.FocusNext()
+		return m, nil
+
+	case key.Matches(msg, m.keys.ShiftTab):
+		f.FocusPrev()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Enter):
+		switch {
+		case f.IsAddFocused():
+			f.AddToBatch()
+			return m, nil
+		case f.IsSubmitFocused():
+			if f.Submitting() || m.accounts == nil {
+				return m, nil
+			}
+			accounts, err := f.Pending()
+			if err != nil {
+				return m, nil
+			}
+			f.SetSubmitting(true)
+			return m, CreateAccountsCmd(m.accounts, accounts)
+		}
+		f.FocusNext()
+		return m, nil
+	}
+
+	return m, f.Update(msg)
+}
+
+// updateCreateTransfer handles keys in the Create Transfer modal.
+func (m Model) updateCreateTransfer(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	f := &m.createTransfer
+	switch {
+	case key.Matches(msg, m.keys.Tab):
+		f.FocusNext()
+		return m, nil
+
+	case key.Matches(msg, m.keys.ShiftTab):
+		f.FocusPrev()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Enter):
+		switch {
+		case f.IsAddFocused():
+			f.AddToBatch()
+			return m, nil
+		case f.IsSubmitFocused():
+			if f.Submitting() || m.transfers == nil {
+				return m, nil
+			}
+			transfers, err := f.Pending()
+			if err != nil {
+				return m, nil
+			}
+			f.SetSubmitting(true)
+			return m, CreateTransfersCmd(m.transfers, transfers)
+		}
+		f.FocusNext()
+		return m, nil
+	}
+
+	return m, f.Update(msg)
+}
+
+// updatePostPartial handles keys in the Post Partial prompt.
+func (m Model) updatePostPartial(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	f := &m.postPartial
+	if key.Matches(msg, m.keys.Enter) {
+		if f.Submitting() || m.transfers == nil {
+			return m, nil
+		}
+		transfer, err := f.Build()
+		if err != nil {
+			return m, nil
+		}
+		f.SetSubmitting(true)
+		return m, ResolvePendingCmd(m.transfers, transfer)
+	}
+	return m, f.Update(msg)
+}
+
+// refresh reloads the active tab.
+func (m *Model) refresh() tea.Cmd {
+	switch m.dashboard.ActiveTab() {
+	case 0:
+		if m.dashboard.HistoryOpen() {
+			return m.reloadHistory()
+		}
+		return m.reloadAccounts()
+	case 1:
+		return m.reloadTransfers()
+	case 2:
+		return m.reloadBalanceSheet()
+	case 3:
+		return m.reloadHolds()
+	}
+	return nil
+}
+
+// openCreate opens the Create Account ("accounts") or Create Transfer
+// ("transfers") modal.
+func (m *Model) openCreate(what string) {
+	if what == "accounts" {
+		m.createAccount = components.NewCreateAccountForm(m.keys.modalKeys())
+		m.overlay = OverlayCreateAccount
+		return
+	}
+	m.createTransfer = components.NewCreateTransferForm(m.dashboard.Accounts().Loaded(), m.keys.modalKeys())
+	m.overlay = OverlayCreateTransfer
+}
+
+// disconnect closes the TigerBeetle client, cancels a running export and
+// returns to the connection screen.
+func (m *Model) disconnect() {
+	if m.tbClient != nil {
+		m.tbClient.Close()
+		m.tbClient = nil
+	}
+	m.accounts = nil
+	m.transfers = nil
+	m.balanceSheet = nil
+	m.lookup = nil
+	m.outbox = nil
+	if m.export != nil {
+		m.export.Cancel()
+		m.export = nil
+		m.statusBar.SetTask("")
+	}
+	m.statusBar.SetPendingWrites(0)
+	m.dashboard = components.NewDashboard()
+	m.dashboard.SetSize(m.width, m.height)
+	m.restoreLayouts()
+	m.screen = ScreenConnection
+	m.connStatus = Disconnected
+	m.profile = components.ConnectionProfile{}
+	m.connForm.SetStatus(0)
+	m.statusBar.SetConnection(0, "", "")
+	m.statusBar.SetProfile(m.profile)
+}
+
+// resolveHold submits a post or void for hold unless it has already expired.
+func (m *Model) resolveHold(hold transfersdomain.Hold, transfer types.Transfer) tea.Cmd {
+	if hold.Expired(time.Now()) {
+		m.statusBar.SetMessage(fmt.Sprintf("Pending transfer %s has expired", domain.FormatID(hold.Transfer.ID)), 2)
+		return nil
+	}
+	m.statusBar.SetMessage("Submitting...", 0)
+	return ResolvePendingCmd(m.transfers, transfer)
+}
+
+// pendingWrites returns the number of journaled writes awaiting a reply.
+func (m *Model) pendingWrites() int {
+	if m.outbox == nil {
+		return 0
+	}
+	return m.outbox.Pending()
+}
+
+// accountsQuery is the Accounts tab's query with its filters, without the
+// pagination cursor.
+func (m *Model) accountsQuery() domain.AccountQuery {
+	return m.filterPlan(0).Accounts(domain.AccountQuery{Limit: domain.DefaultPageSize})
+}
+
+// transfersQuery is the Transfers tab's query (newest first, within the
+// current scope) with its filters, without the pagination cursor.
+func (m *Model) transfersQuery() transfersdomain.TransferQuery {
+	return m.filterPlan(1).Transfers(transfersdomain.TransferQuery{
+		AccountID: m.dashboard.Transfers().Scope(),
+		Limit:     domain.DefaultPageSize,
+		Reversed:  true,
+	})
+}
+
+// reloadAccounts requests the first page of accounts, replacing current rows.
+func (m *Model) reloadAccounts() tea.Cmd {
+	m.dashboard.Accounts().SetFilters(chipViews(m.filters[0], m.filterPlan(0)))
+	if m.accounts == nil {
+		return nil
+	}
+	m.dashboard.Accounts().SetLoading(true)
+	return LoadAccountsCmd(m.accounts, m.accountsQuery(), false)
+}
+
+// reloadHistory loads balance snapshots for the account in the history panel.
+// Accounts without the history flag are not queried.
+func (m *Model) reloadHistory() tea.Cmd {
+	h := m.dashboard.History()
+	if m.accounts == nil || !domain.HasHistory(h.Account()) {
+		return nil
+	}
+	h.Open(h.Account())
+	return LoadAccountHistoryCmd(m.accounts, h.AccountID())
+}
+
+// reloadTransfers requests the first page of transfers (newest first) for the
+// current scope, replacing current rows.
+func (m *Model) reloadTransfers() tea.Cmd {
+	m.dashboard.Transfers().SetFilters(chipViews(m.filters[1], m.filterPlan(1)))
+	if m.transfers == nil {
+		return nil
+	}
+	m.dashboard.Transfers().SetLoading(true)
+	return LoadTransfersCmd(m.transfers, m.transfersQuery(), false)
+}
+
+// reloadHolds brings the open pending transfers up to date.
+func (m *Model) reloadHolds() tea.Cmd {
+	if m.transfers == nil {
+		return nil
+	}
+	m.dashboard.Pending().SetLoading(true)
+	return LoadHoldsCmd(m.transfers)
+}
+
+// reloadBalanceSheet recomputes the balance sheet from all accounts.
+func (m *Model) reloadBalanceSheet() tea.Cmd {
+	if m.balanceSheet == nil {
+		return nil
+	}
+	m.dashboard.BalanceSheet().SetLoading(true)
+	return LoadBalanceSheetCmd(m.balanceSheet)
+}
+
+// loadMore requests the next page of the active tab when the selection nears
+// the end of the loaded rows.
+func (m *Model) loadMore() tea.Cmd {
+	switch m.dashboard.ActiveTab() {
+	case 0:
+		if m.accounts == nil || !m.dashboard.Accounts().NeedsMore() {
+			return nil
+		}
+		m.dashboard.Accounts().SetLoading(true)
+		return LoadAccountsCmd(m.accounts, m.dashboard.Accounts().Next(), true)
+	case 1:
+		if m.transfers == nil || !m.dashboard.Transfers().NeedsMore() {
+			return nil
+		}
+		m.dashboard.Transfers().SetLoading(true)
+		return LoadTransfersCmd(m.transfers, m.dashboard.Transfers().Next(), true)
+	}
+	return nil
+}
+
 // View renders the TUI.
 func (m Model) View() string {
 	if m.quitting {
@@ -205,12 +1090,15 @@ func (m Model) View() string {
 		content = m.viewDashboard()
 	}
 
-	return content
+	return theme.Symbols(content)
 }
 
 // viewConnection renders the connection screen.
 func (m Model) viewConnection() string {
 	formContent := m.connForm.View()
+	if modal := m.overlayView(); modal != "" {
+		formContent = modal
+	}
 
 	// Center vertically
 	formHeight := lipgloss.Height(formContent)
@@ -244,26 +1132,70 @@ func (m Model) viewDashboard() string {
 	var sb strings.Builder
 	sb.WriteString(topBar)
 	sb.WriteString("\n\n")
-	sb.WriteString(m.dashboard.View())
+	if modal := m.overlayView(); modal != "" {
+		sb.WriteString(lipgloss.Place(m.width, m.height-4, lipgloss.Center, lipgloss.Center, modal))
+	} else {
+		sb.WriteString(m.dashboard.View())
+	}
 
-	// Fill remaining space, then status bar
-	currentHeight := lipgloss.Height(sb.String()) + 1
-	remaining := m.height - currentHeight - 1
-	if remaining > 0 {
-		sb.WriteString(strings.Repeat("\n", remaining))
+	// The status bar, or the command or search line in its place, takes the
+	// last lines; content that does not fit above it, such as under the
+	// command bar's completions, is cut.
+	bottom := m.statusBar.View()
+	switch m.overlay {
+	case OverlayCommand:
+		bottom = m.commandBar.View()
+	case OverlaySearch:
+		bottom = m.searchBarView()
 	}
-	sb.WriteString(m.statusBar.View())
+	lines := strings.Split(sb.String(), "\n")
+	avail := max(m.height-lipgloss.Height(bottom), 0)
+	if len(lines) > avail {
+		lines = lines[:avail]
+	}
+	for len(lines) < avail {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n") + "\n" + bottom
+}
 
-	return sb.String()
+// overlayView renders the open modal, if any.
+func (m Model) overlayView() string {
+	switch m.overlay {
+	case OverlayCreateAccount:
+		return m.createAccount.View()
+	case OverlayCreateTransfer:
+		return m.createTransfer.View()
+	case OverlayImport:
+		return m.importForm.View()
+	case OverlayExport:
+		return m.exportForm.View()
+	case OverlayPostPartial:
+		return m.postPartial.View()
+	case OverlayLookup:
+		return m.lookupPrompt.View()
+	case OverlayLookupResult:
+		return m.lookupDetail.View()
+	case OverlayFilter:
+		return m.filterForm.View()
+	case OverlayColumns:
+		return m.columnChooser.View()
+	case OverlayHelp:
+		return m.help.View()
+	}
+	return ""
 }
 
 // renderTopBar renders the dashboard top bar.
 func (m Model) renderTopBar() string {
-	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorAccent)
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent)
 	title := titleStyle.Render(" tiger-tui ")
 
 	connStyle := StatusConnected
 	connText := connStyle.Render(fmt.Sprintf("● Connected %s:%s", m.connForm.ClusterID(), m.connForm.Address()))
+	if m.profile.Name != "" {
+		connText = components.ProfileBadge(m.profile) + " " + connText
+	}
 
 	gap := m.width - lipgloss.Width(title) - lipgloss.Width(connText) - 2
 	if gap < 1 {
@@ -273,12 +1205,21 @@ func (m Model) renderTopBar() string {
 	return title + strings.Repeat(" ", gap) + connText
 }
 
+// groupCount renders a count with thousands separators.
+func groupCount(n int) string {
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
 // Program holds the Bubble Tea program instance for external access.
 var Program *tea.Program
 
 // Run starts the Bubble Tea program.
-func Run() error {
-	Program = tea.NewProgram(New(), tea.WithAltScreen())
+func Run(cfg *config.Config) error {
+	Program = tea.NewProgram(New(cfg), tea.WithAltScreen())
 	_, err := Program.Run()
 	return err
 }